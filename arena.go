@@ -0,0 +1,75 @@
+package set
+
+// Allocator provides the maps that back a Set. Implement it to control where the
+// storage for sets comes from, and pass it to `NewSetWithAllocator`.
+type Allocator[T comparable] interface {
+	// Alloc returns an empty map with room for at least `size` items
+	Alloc(size int) map[T]struct{}
+}
+
+// NewSetWithAllocator will return a Set object from an input slice, with its storage
+// coming from `a` instead of a fresh allocation
+func NewSetWithAllocator[T comparable, S ~[]T](data S, a Allocator[T]) Set[T] {
+	result := a.Alloc(len(data))
+
+	// Fill it up
+	for _, v := range data {
+		result[v] = struct{}{}
+	}
+
+	return Set[T]{data: result}
+}
+
+// Arena is an Allocator for batch jobs that make a lot of short lived sets. Every set
+// built from the arena is released at once by `Reset`, which takes constant time, and
+// the maps are handed out again by later calls to `Alloc` rather than being left for
+// the garbage collector. The zero value is ready to use.
+//
+// Sets built from an Arena must not be used after the arena is reset.
+type Arena[T comparable] struct {
+	maps []map[T]struct{}
+
+	// used is how many of `maps` have been handed out since the last reset
+	used int
+}
+
+// Alloc returns an empty map, reusing one that was released by `Reset` if there is one
+// available. `size` is only used when a new map has to be made.
+func (a *Arena[T]) Alloc(size int) map[T]struct{} {
+	if a.used < len(a.maps) {
+		// Maps are cleared when they are reused, rather than when they are released, so
+		// that `Reset` stays cheap
+		m := a.maps[a.used]
+		clear(m)
+		a.used += 1
+		return m
+	}
+
+	m := make(map[T]struct{}, size)
+	a.maps = append(a.maps, m)
+	a.used += 1
+	return m
+}
+
+// NewSet will return a Set object from an input slice, with its storage coming from the
+// arena
+func (a *Arena[T]) NewSet(data []T) Set[T] {
+	return NewSetWithAllocator(data, a)
+}
+
+// Len returns how many sets have been built from the arena since the last reset
+func (a *Arena[T]) Len() int {
+	return a.used
+}
+
+// Reset releases every set built from the arena, so that their storage can be reused
+func (a *Arena[T]) Reset() {
+	a.used = 0
+}
+
+// Free releases every set built from the arena, and lets go of their storage entirely
+// so that the garbage collector can reclaim it
+func (a *Arena[T]) Free() {
+	a.maps = nil
+	a.used = 0
+}
@@ -0,0 +1,62 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestArena(t *testing.T) {
+	var a Arena[string]
+
+	s1 := a.NewSet([]string{"a", "b"})
+	s2 := NewSetWithAllocator([]string{"c"}, &a)
+	if a.Len() != 2 {
+		t.Errorf("got %d sets, want 2", a.Len())
+	}
+	if want := NewSet([]string{"a", "b"}); !s1.Equals(want) {
+		t.Errorf("got %v, want %v", s1, want)
+	}
+	if want := NewSet([]string{"c"}); !s2.Equals(want) {
+		t.Errorf("got %v, want %v", s2, want)
+	}
+
+	// After a reset, the storage is reused, and must come back empty
+	a.Reset()
+	if a.Len() != 0 {
+		t.Errorf("got %d sets, want 0", a.Len())
+	}
+	s3 := a.NewSet([]string{"d"})
+	if want := NewSet([]string{"d"}); !s3.Equals(want) {
+		t.Errorf("got %v, want %v", s3, want)
+	}
+	if len(a.maps) != 2 {
+		t.Errorf("got %d maps in the arena, want 2", len(a.maps))
+	}
+
+	a.Free()
+	if len(a.maps) != 0 || a.Len() != 0 {
+		t.Errorf("Free should release all storage")
+	}
+}
+
+func BenchmarkArena(b *testing.B) {
+	in := []int{1, 2, 3, 4, 5}
+
+	b.Run("no arena", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < 1000; j++ {
+				NewSet(in)
+			}
+		}
+	})
+	b.Run("arena", func(b *testing.B) {
+		b.ReportAllocs()
+		var a Arena[int]
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < 1000; j++ {
+				a.NewSet(in)
+			}
+			a.Reset()
+		}
+	})
+}
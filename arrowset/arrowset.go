@@ -0,0 +1,106 @@
+// arrowset builds sets straight from the buffers of Apache Arrow arrays, and writes
+// them back out, without boxing each value in an interface on the way. It works on the
+// plain slices an Arrow array hands out, so it doesn't import the Arrow library. For an
+// `*array.Int64` called `arr`, that is
+//
+//	s := arrowset.FromValues(arr.Int64Values(), arr.NullBitmapBytes(), arr.Data().Offset())
+//
+// The typed values, like `Int64Values` and `ValueOffsets`, already start at the array's
+// offset, but the validity bitmap does not, so the offset is passed alongside it.
+package arrowset
+
+import (
+	"fmt"
+
+	"github.com/natemcintosh/set"
+	"github.com/natemcintosh/set/bitset"
+	"golang.org/x/exp/constraints"
+)
+
+// is_valid returns true if bit `i` of an Arrow validity bitmap is set. A nil bitmap
+// means there are no nulls.
+func is_valid(validity []byte, i int) bool {
+	return validity == nil || validity[i>>3]&(1<<uint(i&7)) != 0
+}
+
+// FromValues will return a set of the non-null items of a fixed width Arrow array, given
+// its values, its validity bitmap, and the array's offset into that bitmap. A nil
+// bitmap means every value is present.
+func FromValues[T comparable](values []T, validity []byte, offset int) set.Set[T] {
+	result := set.NewSetWithCapacity([]T{}, len(values))
+	for i, v := range values {
+		if is_valid(validity, offset+i) {
+			result.Add(v)
+		}
+	}
+	return result
+}
+
+// FromStrings will return a set of the non-null items of an Arrow string array, given
+// its value offsets (one more than the number of items), its value bytes, its validity
+// bitmap, and the array's offset into that bitmap
+func FromStrings(offsets []int32, data []byte, validity []byte, offset int) set.Set[string] {
+	n := max(len(offsets)-1, 0)
+	result := set.NewSetWithCapacity([]string{}, n)
+	for i := 0; i < n; i++ {
+		if is_valid(validity, offset+i) {
+			result.Add(string(data[offsets[i]:offsets[i+1]]))
+		}
+	}
+	return result
+}
+
+// BitsetFromValues will return a bitset of the non-null items of an Arrow integer array,
+// the same as `FromValues`. Unsigned values above math.MaxInt wrap around.
+func BitsetFromValues[I constraints.Integer](values []I, validity []byte, offset int) bitset.Set {
+	result := bitset.NewSet([]int{})
+	for i, v := range values {
+		if is_valid(validity, offset+i) {
+			result.Add(int(v))
+		}
+	}
+	return result
+}
+
+// Int64Values returns the items of `s` in ascending order, ready to pass to an Arrow
+// Int64 builder's `AppendValues`
+func Int64Values(s bitset.Set) []int64 {
+	result := make([]int64, 0, s.Len())
+	s.ForEach(func(item int) bool {
+		result = append(result, int64(item))
+		return true
+	})
+	return result
+}
+
+// StringBuffers returns the value offsets and value bytes of an Arrow string array
+// holding the items of `s`, in no particular order. It panics if the items add up to
+// more bytes than an int32 offset can reach; use a large string array for those.
+func StringBuffers(s set.Set[string]) (offsets []int32, data []byte) {
+	offsets = make([]int32, 1, s.Len()+1)
+	s.Iterate(func(item string) bool {
+		data = append(data, item...)
+		if len(data) > 1<<31-1 {
+			panic(fmt.Sprintf("arrowset: %d bytes of strings is too many for int32 offsets", len(data)))
+		}
+		offsets = append(offsets, int32(len(data)))
+		return true
+	})
+	return offsets, data
+}
+
+// Bitmap returns an Arrow bitmap of `n` bits, where bit `i` is set if `lo + i` is in
+// `s`. Bits are numbered from the least significant bit of each byte, as Arrow does, so
+// the result can be used as a validity bitmap, or as the values of a boolean array,
+// marking which rows of an array whose row `i` stands for the number `lo + i` are in the
+// set.
+func Bitmap(s bitset.Set, lo, n int) []byte {
+	result := make([]byte, (n+7)/8)
+	s.Iterate(func(item int) bool {
+		if i := item - lo; item >= lo && i < n {
+			result[i>>3] |= 1 << uint(i&7)
+		}
+		return true
+	})
+	return result
+}
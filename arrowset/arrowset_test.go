@@ -0,0 +1,94 @@
+package arrowset
+
+import (
+	"testing"
+
+	"github.com/natemcintosh/set"
+	"github.com/natemcintosh/set/bitset"
+	"golang.org/x/exp/slices"
+)
+
+func TestFromValues(t *testing.T) {
+	values := []int64{5, 6, 7, 5, 8, 9}
+	testCases := []struct {
+		desc     string
+		validity []byte
+		offset   int
+		want     []int64
+	}{
+		{desc: "no nulls", validity: nil, want: []int64{5, 6, 7, 8, 9}},
+		// Bits 0, 2, 3 and 5 are set
+		{desc: "nulls", validity: []byte{0b101101}, want: []int64{5, 7, 9}},
+		// The array starts 2 bits into the bitmap, so values 0, 1 and 3 use bits 2, 3 and 5
+		{desc: "offset", validity: []byte{0b101101}, offset: 2, want: []int64{5, 6}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got := FromValues(values, tC.validity, tC.offset)
+			if want := set.NewSet(tC.want); !got.Equals(want) {
+				t.Errorf("got %v; want %v", got, want)
+			}
+
+			bits := BitsetFromValues(values, tC.validity, tC.offset)
+			if want := bitset.NewSet(to_ints(tC.want)); !bits.Equals(want) {
+				t.Errorf("got %v; want %v", bits, want)
+			}
+		})
+	}
+}
+
+func to_ints(values []int64) []int {
+	result := make([]int, len(values))
+	for i, v := range values {
+		result[i] = int(v)
+	}
+	return result
+}
+
+func TestStrings(t *testing.T) {
+	// ["ab", null, "c", "ab", ""]
+	offsets := []int32{0, 2, 2, 3, 5, 5}
+	data := []byte("abcab")
+	validity := []byte{0b11101}
+
+	got := FromStrings(offsets, data, validity, 0)
+	if want := set.NewSet([]string{"ab", "c", ""}); !got.Equals(want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	// Writing the buffers back out and reading them again gives the same set
+	out_offsets, out_data := StringBuffers(got)
+	if len(out_offsets) != got.Len()+1 {
+		t.Errorf("got %d offsets; want %d", len(out_offsets), got.Len()+1)
+	}
+	if again := FromStrings(out_offsets, out_data, nil, 0); !again.Equals(got) {
+		t.Errorf("got %v; want %v", again, got)
+	}
+
+	if empty := FromStrings(nil, nil, nil, 0); !empty.IsEmpty() {
+		t.Errorf("got %v; want an empty set", empty)
+	}
+}
+
+func TestInt64Values(t *testing.T) {
+	s := bitset.NewSet([]int{64, -1, 3})
+	if got, want := Int64Values(s), []int64{-1, 3, 64}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestBitmap(t *testing.T) {
+	s := bitset.NewSet([]int{-5, 10, 11, 17, 19, 100})
+	// Rows 0 to 9 stand for the numbers 10 to 19
+	got := Bitmap(s, 10, 10)
+	want := []byte{0b10000011, 0b10}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %08b; want %08b", got, want)
+	}
+
+	// It reads back as a validity bitmap
+	rows := []int64{10, 11, 12, 13, 14, 15, 16, 17, 18, 19}
+	if back := FromValues(rows, got, 0); back.Len() != 4 {
+		t.Errorf("got %v; want 4 rows set", back)
+	}
+}
@@ -0,0 +1,324 @@
+// backend separates how a set stores its items from what you can do with it. A Storage
+// only has to know how to add, remove, find, and visit items. Set builds the rest of
+// the API (set algebra, subset tests, iteration, JSON) on top of any Storage, so adding
+// a new representation means writing those few methods rather than the whole API and
+// its tests again.
+//
+// Storage is provided backed by a hash map (`Hash`), a bitset (`Bits`), and a sorted
+// slice (`Sorted`). Other representations, such as roaring bitmaps, can be plugged in by
+// implementing Storage.
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/natemcintosh/set"
+)
+
+var (
+	// This error is returned when you try to remove an item from a set that doesn't
+	// exist. It is set.ErrElementNotFound, so errors.Is works the same for every set.
+	ErrElementNotFound = set.ErrElementNotFound
+)
+
+// Storage is the small set of methods a representation has to provide
+type Storage[T comparable] interface {
+	Contains(item T) bool
+	Add(item T)
+	Discard(item T)
+	Len() int
+	Clear()
+
+	// Iterate calls `f` on every item, stopping early if `f` returns false
+	Iterate(f func(item T) bool)
+
+	// Empty returns a new, empty Storage of the same kind, with room for `size` items
+	Empty(size int) Storage[T]
+}
+
+// Set provides the full set API on top of a Storage. Set operations between two sets
+// return a set using the same kind of Storage as the receiver, but the other set may
+// use any kind.
+type Set[T comparable] struct {
+	storage Storage[T]
+}
+
+// NewSet will return a Set using `storage`, with the items from `data` added to it
+func NewSet[T comparable, S ~[]T](storage Storage[T], data S) Set[T] {
+	for _, v := range data {
+		storage.Add(v)
+	}
+	return Set[T]{storage: storage}
+}
+
+// empty_like returns an empty set using the same kind of Storage as `s`
+func (s *Set[T]) empty_like(size int) Set[T] {
+	return Set[T]{storage: s.storage.Empty(size)}
+}
+
+func (s Set[T]) String() string {
+	var b strings.Builder
+	last_index := s.Len() - 1
+	index := -1
+	b.WriteString("{")
+	s.Iterate(func(v T) bool {
+		index += 1
+
+		if index < last_index {
+			b.WriteString(fmt.Sprintf("%v, ", v))
+		} else {
+			b.WriteString(fmt.Sprintf("%v", v))
+		}
+		return true
+	})
+	b.WriteString("}")
+
+	return b.String()
+}
+
+// Storage returns the Storage holding the items of the set
+func (s *Set[T]) Storage() Storage[T] {
+	return s.storage
+}
+
+// Iterate calls `f` on every item in the set. It stops early if `f` returns false.
+func (s *Set[T]) Iterate(f func(item T) bool) {
+	s.storage.Iterate(f)
+}
+
+// Slice will return all the items in the set as a slice. The order is whatever order
+// the Storage visits them in.
+func (s *Set[T]) Slice() []T {
+	result := make([]T, 0, s.Len())
+	s.Iterate(func(v T) bool {
+		result = append(result, v)
+		return true
+	})
+	return result
+}
+
+// Contains will return true if the set contains the item
+func (s *Set[T]) Contains(item T) bool {
+	return s.storage.Contains(item)
+}
+
+// Len returns the length of the Set
+func (s *Set[T]) Len() int {
+	return s.storage.Len()
+}
+
+// IsEmpty returns true if the set is empty
+func (s *Set[T]) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+// Add will add a new item to `s`. If it already exists, it is ignored
+func (s *Set[T]) Add(item T) {
+	s.storage.Add(item)
+}
+
+// Remove removes an item from the set. Returns an error if the item doesn't exist.
+// See `Discard` for method that does not return an error
+func (s *Set[T]) Remove(item T) error {
+	if !s.Contains(item) {
+		return ErrElementNotFound
+	}
+
+	s.storage.Discard(item)
+	return nil
+}
+
+// Discard removes an item from the set. If it doesn't exist, it is ignored
+func (s *Set[T]) Discard(item T) {
+	s.storage.Discard(item)
+}
+
+// Pop will remove and return an arbitrary item from the set. If the set is empty,
+// it will return an error
+func (s *Set[T]) Pop() (item T, err error) {
+	if s.IsEmpty() {
+		return item, ErrElementNotFound
+	}
+
+	s.Iterate(func(v T) bool {
+		item = v
+		return false
+	})
+	s.Discard(item)
+
+	return item, nil
+}
+
+// Clear will remove all items from the set
+func (s *Set[T]) Clear() {
+	s.storage.Clear()
+}
+
+// Copy makes a deep copy, using the same kind of Storage
+func (s *Set[T]) Copy() Set[T] {
+	result := s.empty_like(s.Len())
+	result.UnionInPlace(*s)
+	return result
+}
+
+// Equals will return true if `s` and `t` contain the same elements
+func (s *Set[T]) Equals(t Set[T]) bool {
+	return s.Len() == t.Len() && s.IsSubsetOf(t)
+}
+
+// Union will create a new Set, and fill it with the union of `s` and `t`
+func (s *Set[T]) Union(t Set[T]) Set[T] {
+	result := s.Copy()
+	result.UnionInPlace(t)
+	return result
+}
+
+// UnionInPlace will add all the items in set `t` to set `s`
+func (s *Set[T]) UnionInPlace(t Set[T]) {
+	t.Iterate(func(v T) bool {
+		s.Add(v)
+		return true
+	})
+}
+
+// Intersection will create a new Set, and fill it with the intersection of `s` and `t`
+func (s *Set[T]) Intersection(t Set[T]) Set[T] {
+	// Iterate over the smaller of the two sets, checking the larger
+	small, large := s, &t
+	if t.Len() < s.Len() {
+		small, large = &t, s
+	}
+
+	result := s.empty_like(small.Len())
+	small.Iterate(func(v T) bool {
+		if large.Contains(v) {
+			result.Add(v)
+		}
+		return true
+	})
+	return result
+}
+
+// IntersectionInPlace will remove any items from `s` that are not in `t`
+func (s *Set[T]) IntersectionInPlace(t Set[T]) {
+	// Not every Storage can be changed while it is being iterated over
+	for _, v := range s.Slice() {
+		if !t.Contains(v) {
+			s.Discard(v)
+		}
+	}
+}
+
+// IsDisjoint will return true if the set has no elements in common with `t`
+func (s *Set[T]) IsDisjoint(t Set[T]) bool {
+	small, large := s, &t
+	if t.Len() < s.Len() {
+		small, large = &t, s
+	}
+
+	result := true
+	small.Iterate(func(v T) bool {
+		result = !large.Contains(v)
+		return result
+	})
+	return result
+}
+
+// IsSubsetOf tests whether every element in `s` is in `t`
+func (s *Set[T]) IsSubsetOf(t Set[T]) bool {
+	if s.Len() > t.Len() {
+		return false
+	}
+
+	result := true
+	s.Iterate(func(v T) bool {
+		result = t.Contains(v)
+		return result
+	})
+	return result
+}
+
+// IsProperSubsetOf tests whether every element in `s` is in `t`, but that
+// `s.Equals(t) == false`
+func (s *Set[T]) IsProperSubsetOf(t Set[T]) bool {
+	return s.Len() < t.Len() && s.IsSubsetOf(t)
+}
+
+// IsSuperSetOf tests whether every element in `t` is in `s`
+func (s *Set[T]) IsSuperSetOf(t Set[T]) bool {
+	return t.IsSubsetOf(*s)
+}
+
+// IsProperSuperSetOf tests whether every element in `t` is in `s`, but that
+// `s.Equals(t) == false`
+func (s *Set[T]) IsProperSuperSetOf(t Set[T]) bool {
+	return t.IsProperSubsetOf(*s)
+}
+
+// Difference returns a new set with elements in `s` that are not in `t`
+func (s *Set[T]) Difference(t Set[T]) Set[T] {
+	result := s.empty_like(s.Len())
+	s.Iterate(func(v T) bool {
+		if !t.Contains(v) {
+			result.Add(v)
+		}
+		return true
+	})
+	return result
+}
+
+// DifferenceInPlace removes any elements in `s` that are in `t`
+func (s *Set[T]) DifferenceInPlace(t Set[T]) {
+	// `t` may share its Storage with `s`, so walk a snapshot of it
+	for _, v := range t.Slice() {
+		s.Discard(v)
+	}
+}
+
+// SymmetricDifference returns a new set with elements in either `s` or `t`, but not both
+func (s *Set[T]) SymmetricDifference(t Set[T]) Set[T] {
+	result := s.Difference(t)
+	t.Iterate(func(v T) bool {
+		if !s.Contains(v) {
+			result.Add(v)
+		}
+		return true
+	})
+	return result
+}
+
+// SymmetricDifferenceInPlace removes any elements in `s` that are in `t`, and adds any
+// elements in `t` that are not in `s`
+func (s *Set[T]) SymmetricDifferenceInPlace(t Set[T]) {
+	// As with DifferenceInPlace, `t` may be `s` itself
+	for _, v := range t.Slice() {
+		if s.Contains(v) {
+			s.Discard(v)
+		} else {
+			s.Add(v)
+		}
+	}
+}
+
+// MarshalJSON encodes the set as a JSON array
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Slice())
+}
+
+// UnmarshalJSON adds the items of a JSON array to the set. If the set has no Storage
+// yet, `Hash` is used.
+func (s *Set[T]) UnmarshalJSON(b []byte) error {
+	var items []T
+	if err := json.Unmarshal(b, &items); err != nil {
+		return err
+	}
+	if s.storage == nil {
+		s.storage = Hash[T]()
+	}
+	for _, v := range items {
+		s.Add(v)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package backend
+
+import (
+	"encoding/json"
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+// storages are the int Storage constructors that every test is run against
+var storages = []struct {
+	desc string
+	new  func() Storage[int]
+}{
+	{desc: "hash", new: Hash[int]},
+	{desc: "bits", new: Bits},
+	{desc: "sorted", new: Sorted[int]},
+}
+
+func sorted_slice(s Set[int]) []int {
+	result := s.Slice()
+	slices.Sort(result)
+	return result
+}
+
+func TestOperations(t *testing.T) {
+	for _, st := range storages {
+		t.Run(st.desc, func(t *testing.T) {
+			s1 := NewSet(st.new(), []int{1, 2, 3, 200})
+			// The other side of each operation uses a different kind of Storage
+			s2 := NewSet(Hash[int](), []int{2, 3, 4, -5})
+
+			testCases := []struct {
+				desc string
+				got  Set[int]
+				want []int
+			}{
+				{desc: "union", got: s1.Union(s2), want: []int{-5, 1, 2, 3, 4, 200}},
+				{desc: "intersection", got: s1.Intersection(s2), want: []int{2, 3}},
+				{desc: "difference", got: s1.Difference(s2), want: []int{1, 200}},
+				{
+					desc: "symmetric difference",
+					got:  s1.SymmetricDifference(s2),
+					want: []int{-5, 1, 4, 200},
+				},
+			}
+			for _, tC := range testCases {
+				t.Run(tC.desc, func(t *testing.T) {
+					if got := sorted_slice(tC.got); !slices.Equal(got, tC.want) {
+						t.Errorf("got %v; want %v", got, tC.want)
+					}
+				})
+			}
+
+			if s1.IsDisjoint(s2) {
+				t.Errorf("%v and %v should not be disjoint", s1, s2)
+			}
+			inter := s1.Intersection(s2)
+			if !inter.IsProperSubsetOf(s1) || !s1.IsProperSuperSetOf(inter) {
+				t.Errorf("%v should be a proper subset of %v", inter, s1)
+			}
+		})
+	}
+}
+
+func TestInPlace(t *testing.T) {
+	for _, st := range storages {
+		t.Run(st.desc, func(t *testing.T) {
+			s := NewSet(st.new(), []int{1, 2, 3})
+			other := NewSet(Sorted[int](), []int{3, 4})
+
+			s.SymmetricDifferenceInPlace(other)
+			if got, want := sorted_slice(s), []int{1, 2, 4}; !slices.Equal(got, want) {
+				t.Errorf("got %v; want %v", got, want)
+			}
+
+			s.IntersectionInPlace(NewSet(Hash[int](), []int{1, 4, 10}))
+			if got, want := sorted_slice(s), []int{1, 4}; !slices.Equal(got, want) {
+				t.Errorf("got %v; want %v", got, want)
+			}
+
+			c := s.Copy()
+			c.Add(100)
+			if s.Contains(100) {
+				t.Errorf("changing a copy should not change the original")
+			}
+
+			if err := s.Remove(1); err != nil {
+				t.Errorf("got error %v, want nil", err)
+			}
+			if err := s.Remove(1); err != ErrElementNotFound {
+				t.Errorf("got error %v, want %v", err, ErrElementNotFound)
+			}
+			if v, err := s.Pop(); err != nil || v != 4 {
+				t.Errorf("got %v, %v; want 4, nil", v, err)
+			}
+			if _, err := s.Pop(); err != ErrElementNotFound {
+				t.Errorf("got error %v, want %v", err, ErrElementNotFound)
+			}
+		})
+	}
+}
+
+func TestInPlaceWithItself(t *testing.T) {
+	for _, st := range storages {
+		t.Run(st.desc, func(t *testing.T) {
+			testCases := []struct {
+				desc string
+				op   func(s *Set[int])
+				want []int
+			}{
+				{desc: "union", op: func(s *Set[int]) { s.UnionInPlace(*s) }, want: []int{1, 2, 3, 4}},
+				{desc: "intersection", op: func(s *Set[int]) { s.IntersectionInPlace(*s) }, want: []int{1, 2, 3, 4}},
+				{desc: "difference", op: func(s *Set[int]) { s.DifferenceInPlace(*s) }, want: []int{}},
+				{desc: "symmetric difference", op: func(s *Set[int]) { s.SymmetricDifferenceInPlace(*s) }, want: []int{}},
+			}
+			for _, tC := range testCases {
+				t.Run(tC.desc, func(t *testing.T) {
+					s := NewSet(st.new(), []int{1, 2, 3, 4})
+					tC.op(&s)
+					if got := sorted_slice(s); !slices.Equal(got, tC.want) {
+						t.Errorf("got %v; want %v", got, tC.want)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestSortedIterationOrder(t *testing.T) {
+	s := NewSet(Sorted[string](), []string{"c", "a", "b", "a"})
+	want := []string{"a", "b", "c"}
+	if got := s.Slice(); !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+	if got := s.String(); got != "{a, b, c}" {
+		t.Errorf("got %v; want {a, b, c}", got)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	s := NewSet(Sorted[int](), []int{3, 1, 2})
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if string(data) != "[1,2,3]" {
+		t.Errorf("got %s; want [1,2,3]", data)
+	}
+
+	var got Set[int]
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if !got.Equals(s) {
+		t.Errorf("got %v; want %v", got, s)
+	}
+}
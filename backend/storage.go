@@ -0,0 +1,90 @@
+package backend
+
+import (
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+
+	"github.com/natemcintosh/set"
+	"github.com/natemcintosh/set/bitset"
+)
+
+// hash_storage keeps items as the keys of a map, using `set.Set`
+type hash_storage[T comparable] struct {
+	set.Set[T]
+}
+
+// Hash will return an empty Storage backed by a hash map. It works for any comparable
+// type, and is the best general purpose choice.
+func Hash[T comparable]() Storage[T] {
+	return &hash_storage[T]{Set: set.NewSet([]T{})}
+}
+
+func (h *hash_storage[T]) Empty(size int) Storage[T] {
+	return &hash_storage[T]{Set: set.NewSetWithCapacity([]T{}, size)}
+}
+
+// bits_storage keeps ints as bits, using `bitset.Set`
+type bits_storage struct {
+	bitset.Set
+}
+
+// Bits will return an empty Storage backed by a bitset. It is the smallest and fastest
+// choice for dense ints.
+func Bits() Storage[int] {
+	return &bits_storage{Set: bitset.NewSet([]int{})}
+}
+
+func (b *bits_storage) Empty(int) Storage[int] {
+	return Bits()
+}
+
+// sorted_storage keeps items in a sorted slice
+type sorted_storage[T constraints.Ordered] struct {
+	items []T
+}
+
+// Sorted will return an empty Storage backed by a sorted slice. Lookups are a binary
+// search, and it is iterated over in ascending order. Adding and removing items is
+// O(n), so it suits sets that are built once and then mostly read.
+func Sorted[T constraints.Ordered]() Storage[T] {
+	return &sorted_storage[T]{}
+}
+
+func (s *sorted_storage[T]) Empty(size int) Storage[T] {
+	return &sorted_storage[T]{items: make([]T, 0, size)}
+}
+
+func (s *sorted_storage[T]) Contains(item T) bool {
+	_, found := slices.BinarySearch(s.items, item)
+	return found
+}
+
+func (s *sorted_storage[T]) Add(item T) {
+	idx, found := slices.BinarySearch(s.items, item)
+	if !found {
+		s.items = slices.Insert(s.items, idx, item)
+	}
+}
+
+func (s *sorted_storage[T]) Discard(item T) {
+	idx, found := slices.BinarySearch(s.items, item)
+	if found {
+		s.items = slices.Delete(s.items, idx, idx+1)
+	}
+}
+
+func (s *sorted_storage[T]) Len() int {
+	return len(s.items)
+}
+
+func (s *sorted_storage[T]) Clear() {
+	s.items = s.items[:0]
+}
+
+func (s *sorted_storage[T]) Iterate(f func(item T) bool) {
+	for _, v := range s.items {
+		if !f(v) {
+			return
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package bitset
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+var (
+	// This error is returned by `DecodeString` when the input is not in the form written
+	// by `EncodeString`
+	ErrInvalidString = errors.New("bitset: invalid string encoding")
+)
+
+// EncodeString encodes the set as a short string that is safe to use in URLs, cookies,
+// and JSON strings. The words are written in ascending order: first the number of words
+// as a uvarint, then for each word its index and its 8 bytes in little endian order.
+// The first index is a zig-zag varint, and every index after that is a uvarint of how
+// many words were skipped since the previous one, so a dense set pays one byte per word
+// for its indexes. The bytes are then encoded as unpadded URL-safe base64.
+func (s *Set) EncodeString() string {
+	keys := sorted_keys(s.data)
+
+	buf := make([]byte, 0, binary.MaxVarintLen64+9*len(keys))
+	buf = binary.AppendUvarint(buf, uint64(len(keys)))
+	for idx, k := range keys {
+		if idx == 0 {
+			buf = binary.AppendVarint(buf, k)
+		} else {
+			buf = binary.AppendUvarint(buf, uint64(k-keys[idx-1]-1))
+		}
+		buf = binary.LittleEndian.AppendUint64(buf, s.data[k])
+	}
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// DecodeString replaces the contents of the set with the string written by
+// `EncodeString`. The set is left unchanged on error.
+func (s *Set) DecodeString(str string) error {
+	data, err := base64.RawURLEncoding.DecodeString(str)
+	if err != nil {
+		return ErrInvalidString
+	}
+
+	n, size := binary.Uvarint(data)
+	if size <= 0 {
+		return ErrInvalidString
+	}
+	data = data[size:]
+	// Every word takes at least 9 bytes, so don't trust a count that couldn't fit
+	if n > uint64(len(data)/9) {
+		return ErrInvalidString
+	}
+
+	result := Set{data: make(map[int64]uint64, n)}
+	var prev int64
+	for i := uint64(0); i < n; i++ {
+		var k int64
+		if i == 0 {
+			v, size := binary.Varint(data)
+			if size <= 0 {
+				return ErrInvalidString
+			}
+			data = data[size:]
+			k = v
+		} else {
+			skip, size := binary.Uvarint(data)
+			if size <= 0 {
+				return ErrInvalidString
+			}
+			data = data[size:]
+			if prev == math.MaxInt64 || skip > uint64(math.MaxInt64-prev-1) {
+				return ErrInvalidString
+			}
+			k = prev + 1 + int64(skip)
+		}
+		if check_word_index(k) != nil {
+			return ErrInvalidString
+		}
+
+		if len(data) < 8 {
+			return ErrInvalidString
+		}
+		result.set_word(k, binary.LittleEndian.Uint64(data))
+		data = data[8:]
+		prev = k
+	}
+	if len(data) != 0 {
+		return ErrInvalidString
+	}
+
+	*s = result
+	return nil
+}
@@ -0,0 +1,96 @@
+package bitset
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestStringRoundTrip(t *testing.T) {
+	testCases := []struct {
+		desc string
+		data []int
+	}{
+		{desc: "empty", data: []int{}},
+		{desc: "negative", data: []int{-65, -64, -63, -1}},
+		{desc: "scattered", data: []int{-1 << 40, 0, 2, 4, 1 << 40}},
+		{desc: "extremes", data: []int{-1 << 63, 1<<63 - 1}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := NewSet(tC.data)
+			str := s.EncodeString()
+			if url.QueryEscape(str) != str {
+				t.Errorf("%q is not safe to put in a URL as is", str)
+			}
+
+			var got Set
+			if err := got.DecodeString(str); err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if !got.Equals(s) {
+				t.Errorf("got %v; want %v", got, s)
+			}
+		})
+	}
+}
+
+func TestEncodeStringDense(t *testing.T) {
+	// 100,000 consecutive IDs take 1,563 words, at 9 bytes each before base64
+	s := NewRange(1_000_000, 1_099_999)
+	str := s.EncodeString()
+	if len(str) > 19_000 {
+		t.Errorf("got %d characters, want at most 19,000", len(str))
+	}
+
+	var got Set
+	if err := got.DecodeString(str); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if !got.Equals(s) {
+		t.Errorf("decoded set doesn't match, got length %d; want %d", got.Len(), s.Len())
+	}
+}
+
+func TestDecodeStringErrors(t *testing.T) {
+	enc := base64.RawURLEncoding.EncodeToString
+	testCases := []struct {
+		desc string
+		str  string
+	}{
+		{desc: "empty", str: ""},
+		{desc: "not base64", str: "a+b/"},
+		{desc: "padded", str: enc([]byte{0}) + "=="},
+		{desc: "count too large", str: enc([]byte{5, 0, 1, 0, 0, 0, 0, 0, 0, 0})},
+		{desc: "missing word", str: enc([]byte{1, 0, 1, 0})},
+		{desc: "trailing bytes", str: enc([]byte{1, 0, 1, 0, 0, 0, 0, 0, 0, 0, 7})},
+		{desc: "index overflows", str: enc(append(
+			[]byte{2, 0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 1, 0, 0, 0, 0, 0, 0, 0, 0},
+			1, 0, 0, 0, 0, 0, 0, 0)),
+		},
+		// Word 2^60 holds numbers past math.MaxInt
+		{desc: "index out of range", str: enc(append(
+			binary.AppendVarint([]byte{1}, 1<<60),
+			1, 0, 0, 0, 0, 0, 0, 0)),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := NewSet([]int{100})
+			if err := s.DecodeString(tC.str); err != ErrInvalidString {
+				t.Errorf("got error %v, want %v", err, ErrInvalidString)
+			}
+			if !s.Contains(100) {
+				t.Errorf("a failed DecodeString should leave the set alone, got %v", s)
+			}
+		})
+	}
+
+	// Only the URL-safe alphabet is used
+	s := NewRange(0, 10_000)
+	if str := s.EncodeString(); strings.ContainsAny(str, "+/=") {
+		t.Errorf("%q uses characters outside the URL-safe alphabet", str)
+	}
+}
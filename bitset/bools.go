@@ -0,0 +1,45 @@
+package bitset
+
+import (
+	"math/bits"
+)
+
+// FromBools will return a Set holding `offset + i` for every `i` where `b[i]` is true,
+// reading a dense mask such as those many numeric and graph libraries pass around. The
+// bits of each word are gathered before it is stored, so the map is written once per
+// word rather than once per item.
+func FromBools(offset int, b []bool) Set {
+	result := NewSet([]int{})
+	var k int64
+	var w uint64
+	for i, v := range b {
+		if !v {
+			continue
+		}
+		nk, slot := word_of(offset + i)
+		if nk != k {
+			result.set_word(k, result.data[k]|w)
+			k, w = nk, 0
+		}
+		w |= slot
+	}
+	result.set_word(k, result.data[k]|w)
+	return result
+}
+
+// ToBools returns a mask of every number from `lo` to `hi` inclusive, where element `i`
+// is true if `lo + i` is in the set. Only the words covering the range are looked at, and
+// only their set bits are visited. If `lo > hi`, the mask is empty.
+func (s *Set) ToBools(lo, hi int) []bool {
+	if lo > hi {
+		return []bool{}
+	}
+	result := make([]bool, hi-lo+1)
+	range_words(lo, hi, func(k int64, mask uint64) {
+		base := int(k) * 64
+		for w := s.data[k] & mask; w != 0; w &= w - 1 {
+			result[base+bits.TrailingZeros64(w)-lo] = true
+		}
+	})
+	return result
+}
@@ -0,0 +1,69 @@
+package bitset
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestFromBools(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		offset int
+		b      []bool
+		want   []int
+	}{
+		{desc: "empty", offset: 0, b: []bool{}, want: []int{}},
+		{desc: "all false", offset: 5, b: []bool{false, false}, want: []int{}},
+		{desc: "offset", offset: 10, b: []bool{true, false, true}, want: []int{10, 12}},
+		{desc: "negative across words", offset: -2, b: []bool{true, true, true, false}, want: []int{-2, -1, 0}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got := FromBools(tC.offset, tC.b)
+			if want := NewSet(tC.want); !got.Equals(want) || got.Len() != want.Len() {
+				t.Errorf("got %v; want %v", got, want)
+			}
+		})
+	}
+
+	// A long mask spanning several words, with gaps
+	b := make([]bool, 300)
+	want := []int{}
+	for i := range b {
+		if i%3 == 0 || (i > 100 && i < 200) {
+			b[i] = true
+			want = append(want, i-130)
+		}
+	}
+	if got := FromBools(-130, b); !slices.Equal(got.Slice(), want) {
+		t.Errorf("got %v; want %v", got.Slice(), want)
+	}
+}
+
+func TestToBools(t *testing.T) {
+	s := NewSet([]int{-65, -1, 0, 3, 64, 1000})
+	testCases := []struct {
+		desc   string
+		lo, hi int
+		want   []bool
+	}{
+		{desc: "backwards", lo: 5, hi: 4, want: []bool{}},
+		{desc: "one", lo: 3, hi: 3, want: []bool{true}},
+		{desc: "around zero", lo: -2, hi: 3, want: []bool{false, true, true, false, false, true}},
+		{desc: "across a word", lo: 62, hi: 65, want: []bool{false, false, true, false}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := s.ToBools(tC.lo, tC.hi); !slices.Equal(got, tC.want) {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+
+	// Round trip through a wide mask
+	mask := s.ToBools(-100, 1100)
+	if back := FromBools(-100, mask); !back.Equals(s) {
+		t.Errorf("got %v; want %v", back, s)
+	}
+}
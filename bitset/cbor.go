@@ -0,0 +1,69 @@
+package bitset
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/natemcintosh/set/internal/cbor"
+)
+
+// MarshalCBOR encodes the set as a CBOR map from word index to word. Word `k` holds the
+// numbers 64*k through 64*k + 63, and is written as an 8 byte little endian byte string
+// where bit `i` is set if 64*k + i is in the set. The words are written in ascending
+// order, and empty words are left out.
+func (s Set) MarshalCBOR() ([]byte, error) {
+	keys := sorted_keys(s.data)
+
+	result := cbor.AppendHead(nil, cbor.MajorMap, uint64(len(keys)))
+	var buf [8]byte
+	for _, k := range keys {
+		result = cbor.AppendInt(result, k)
+		binary.LittleEndian.PutUint64(buf[:], s.data[k])
+		result = cbor.AppendBytes(result, buf[:])
+	}
+	return result, nil
+}
+
+// UnmarshalCBOR replaces the contents of the set with the words of a CBOR map, in the
+// form written by `MarshalCBOR`. Byte strings shorter than 8 bytes are treated as if
+// they were padded with zeros.
+func (s *Set) UnmarshalCBOR(data []byte) error {
+	d := cbor.NewDecoder(data)
+	n, err := d.ReadMapHeader()
+	if err != nil {
+		return err
+	}
+
+	result := NewSet([]int{})
+	for i := 0; i < n; i++ {
+		k, err := d.ReadInt()
+		if err != nil {
+			return err
+		}
+		if err := check_word_index(k); err != nil {
+			return err
+		}
+		b, err := d.ReadBytes()
+		if err != nil {
+			return err
+		}
+		if len(b) > 8 {
+			return cbor.ErrMalformed
+		}
+		var buf [8]byte
+		copy(buf[:], b)
+		word := binary.LittleEndian.Uint64(buf[:])
+
+		for word != 0 {
+			idx := bits.TrailingZeros64(word)
+			result.Add(int(k)*64 + idx)
+			word &= word - 1
+		}
+	}
+	if !d.Done() {
+		return cbor.ErrMalformed
+	}
+
+	*s = result
+	return nil
+}
@@ -0,0 +1,96 @@
+package bitset
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/natemcintosh/set/internal/cbor"
+)
+
+func TestCBORRoundTrip(t *testing.T) {
+	testCases := []struct {
+		desc string
+		in   []int
+	}{
+		{
+			desc: "empty",
+			in:   []int{},
+		},
+		{
+			desc: "around zero",
+			in:   []int{-65, -64, -63, -1, 0, 1, 63, 64, 65},
+		},
+		{
+			desc: "sparse",
+			in:   []int{-1 << 40, 7, 1 << 50},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			want := NewSet(tC.in)
+			data, err := want.MarshalCBOR()
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+
+			var got Set
+			if err := got.UnmarshalCBOR(data); err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if got.Len() != want.Len() || !got.IsSubsetOf(want) {
+				t.Errorf("got %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCBOREncoding(t *testing.T) {
+	s := NewSet([]int{-1, 0, 65})
+	got, err := s.MarshalCBOR()
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	want := []byte{
+		0xa3,
+		// -1 is bit 63 of word -1
+		0x20, 0x48, 0, 0, 0, 0, 0, 0, 0, 0x80,
+		// 0 is bit 0 of word 0
+		0x00, 0x48, 0x01, 0, 0, 0, 0, 0, 0, 0,
+		// 65 is bit 1 of word 1
+		0x01, 0x48, 0x02, 0, 0, 0, 0, 0, 0, 0,
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got %x; want %x", got, want)
+	}
+}
+
+func TestCBORErrors(t *testing.T) {
+	var s Set
+	if err := s.UnmarshalCBOR([]byte{0xa1, 0x00}); err == nil {
+		t.Errorf("truncated input should fail")
+	}
+	if err := s.UnmarshalCBOR([]byte{0x81, 0x00}); err == nil {
+		t.Errorf("an array should not decode into a bitset")
+	}
+}
+
+func TestCBORWordRange(t *testing.T) {
+	// The words holding math.MinInt and math.MaxInt are the furthest out allowed
+	edges := NewSet([]int{math.MinInt, math.MaxInt})
+	data, _ := edges.MarshalCBOR()
+	var got Set
+	if err := got.UnmarshalCBOR(data); err != nil || !got.Equals(edges) {
+		t.Errorf("got %v, %v; want %v, nil", got, err, edges)
+	}
+
+	for _, k := range []int64{math.MaxInt>>6 + 1, math.MinInt>>6 - 1, 1 << 62} {
+		data := cbor.AppendHead(nil, cbor.MajorMap, 1)
+		data = cbor.AppendInt(data, k)
+		data = cbor.AppendBytes(data, []byte{1})
+		if err := got.UnmarshalCBOR(data); !errors.Is(err, ErrWordOutOfRange) {
+			t.Errorf("word %d: got error %v, want %v", k, err, ErrWordOutOfRange)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package bitset
+
+import (
+	"fmt"
+	"math/bits"
+	"sync/atomic"
+)
+
+// Concurrent is a set of ints from a fixed range that is safe to use from many
+// goroutines at once, such as workers marking which jobs are done. The words are kept
+// in one slice, like Dense, and each one is updated with atomic compare-and-swap, so
+// there is no mutex and goroutines only contend when they touch the same word.
+//
+// Each single item operation is atomic. Methods that look at the whole set, like `Len`
+// and `Snapshot`, read the words one at a time, so changes made while they run may or
+// may not be seen. A Concurrent must not be copied after first use.
+type Concurrent struct {
+	lo, hi int
+	base   int64
+	words  []atomic.Uint64
+}
+
+// NewConcurrent will return an empty Concurrent that can hold every integer from `lo` to
+// `hi` inclusive. It panics if `lo > hi`.
+func NewConcurrent(lo, hi int) *Concurrent {
+	if lo > hi {
+		panic(fmt.Sprintf("bitset: NewConcurrent range [%d, %d] is empty", lo, hi))
+	}
+	first, last := int64(lo>>6), int64(hi>>6)
+	return &Concurrent{lo: lo, hi: hi, base: first, words: make([]atomic.Uint64, last-first+1)}
+}
+
+// Range returns the smallest and largest items the set can hold
+func (c *Concurrent) Range() (lo, hi int) {
+	return c.lo, c.hi
+}
+
+// word returns the word holding `item`, and its bit in that word. It panics if `item`
+// is outside the range of the set, as with indexing past the end of a slice.
+func (c *Concurrent) word(item int) (*atomic.Uint64, uint64) {
+	if item < c.lo || item > c.hi {
+		panic(fmt.Sprintf("bitset: %d is outside the range [%d, %d] of the Concurrent set", item, c.lo, c.hi))
+	}
+	k, slot := word_of(item)
+	return &c.words[k-c.base], slot
+}
+
+// Contains will return true if the set contains the item. Items outside the range of
+// the set are never in it.
+func (c *Concurrent) Contains(item int) bool {
+	if item < c.lo || item > c.hi {
+		return false
+	}
+	w, slot := c.word(item)
+	return w.Load()&slot != 0
+}
+
+// Add will add `item` to the set, and returns true if it wasn't already there. When
+// several goroutines add the same item, exactly one of them gets true. It panics if
+// `item` is outside the range of the set.
+func (c *Concurrent) Add(item int) bool {
+	w, slot := c.word(item)
+	for {
+		old := w.Load()
+		if old&slot != 0 {
+			return false
+		}
+		if w.CompareAndSwap(old, old|slot) {
+			return true
+		}
+	}
+}
+
+// TryRemove removes `item` from the set, and returns true if it was there. When several
+// goroutines remove the same item, exactly one of them gets true. Items outside the
+// range of the set are never in it.
+func (c *Concurrent) TryRemove(item int) bool {
+	if item < c.lo || item > c.hi {
+		return false
+	}
+	w, slot := c.word(item)
+	for {
+		old := w.Load()
+		if old&slot == 0 {
+			return false
+		}
+		if w.CompareAndSwap(old, old&^slot) {
+			return true
+		}
+	}
+}
+
+// Discard removes an item from the set. If it doesn't exist, it is ignored
+func (c *Concurrent) Discard(item int) {
+	c.TryRemove(item)
+}
+
+// Len returns the number of items in the set
+func (c *Concurrent) Len() int {
+	res := 0
+	for i := range c.words {
+		res += bits.OnesCount64(c.words[i].Load())
+	}
+	return res
+}
+
+// IsEmpty returns true if the set is empty
+func (c *Concurrent) IsEmpty() bool {
+	for i := range c.words {
+		if c.words[i].Load() != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Clear will remove all items from the set
+func (c *Concurrent) Clear() {
+	for i := range c.words {
+		c.words[i].Store(0)
+	}
+}
+
+// Iterate calls `f` on every item in the set, in ascending order. It stops early if `f`
+// returns false. Each word is loaded once, so `f` may add and remove items.
+func (c *Concurrent) Iterate(f func(item int) bool) {
+	for i := range c.words {
+		base := 64 * int(c.base+int64(i))
+		for w := c.words[i].Load(); w != 0; w &= w - 1 {
+			if !f(base + bits.TrailingZeros64(w)) {
+				return
+			}
+		}
+	}
+}
+
+// Snapshot will return a Set holding the items of `c`. It is not a single atomic read,
+// so items changed while it runs may or may not be included.
+func (c *Concurrent) Snapshot() Set {
+	result := NewSet([]int{})
+	for i := range c.words {
+		result.set_word(c.base+int64(i), c.words[i].Load())
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+package bitset
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestConcurrent(t *testing.T) {
+	c := NewConcurrent(-100, 1000)
+	if lo, hi := c.Range(); lo != -100 || hi != 1000 {
+		t.Errorf("got range [%d, %d]; want [-100, 1000]", lo, hi)
+	}
+
+	for _, v := range []int{-100, -1, 0, 64, 1000} {
+		if !c.Add(v) {
+			t.Errorf("adding %d the first time should return true", v)
+		}
+		if c.Add(v) {
+			t.Errorf("adding %d the second time should return false", v)
+		}
+	}
+	if !c.Contains(64) || c.Contains(63) || c.Contains(5000) {
+		t.Errorf("got wrong membership for %v", c.Snapshot())
+	}
+
+	if !c.TryRemove(64) || c.TryRemove(64) || c.TryRemove(5000) {
+		t.Errorf("TryRemove should return true only for an item in the set")
+	}
+	c.Discard(-1)
+
+	want := []int{-100, 0, 1000}
+	got := []int{}
+	c.Iterate(func(item int) bool {
+		got = append(got, item)
+		return true
+	})
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+	if c.Len() != len(want) {
+		t.Errorf("got length %d; want %d", c.Len(), len(want))
+	}
+	snapshot := c.Snapshot()
+	if want_set := NewSet(want); !snapshot.Equals(want_set) {
+		t.Errorf("got %v; want %v", snapshot, want_set)
+	}
+
+	c.Clear()
+	if !c.IsEmpty() {
+		t.Errorf("set should be empty after Clear, got %v", c.Snapshot())
+	}
+}
+
+func TestConcurrentOutOfRange(t *testing.T) {
+	c := NewConcurrent(0, 10)
+	defer func() {
+		if recover() == nil {
+			t.Errorf("adding an item outside the range should panic")
+		}
+	}()
+	c.Add(11)
+}
+
+func TestConcurrentAddFromManyGoroutines(t *testing.T) {
+	const n = 10_000
+	const workers = 8
+	c := NewConcurrent(0, n-1)
+
+	// Every worker tries to add every item, so each item is raced over, and exactly one
+	// worker should win it
+	var wins atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				if c.Add(i) {
+					wins.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if wins.Load() != n {
+		t.Errorf("got %d successful adds; want %d", wins.Load(), n)
+	}
+	if c.Len() != n {
+		t.Errorf("got length %d; want %d", c.Len(), n)
+	}
+}
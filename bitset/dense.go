@@ -0,0 +1,288 @@
+package bitset
+
+import (
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// Dense is a set of ints stored as one contiguous slice of words, rather than a map of
+// words. Word `i` of the slice holds the numbers 64*(base+i) through 64*(base+i) + 63.
+// There is no per word overhead, and iteration walks memory in order, so it is the
+// better choice when the items fill a known, bounded range. The slice grows to cover
+// any item added, so a set holding a few numbers far apart will use a lot of memory;
+// use Set for that.
+type Dense struct {
+	base  int64
+	words []uint64
+}
+
+// NewDense will return a Dense holding `data`. The words are allocated once, to cover
+// the smallest through the largest item.
+func NewDense[S ~[]int](data S) Dense {
+	if len(data) == 0 {
+		return Dense{}
+	}
+	lo, hi := data[0], data[0]
+	for _, v := range data {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+
+	result := NewDenseWithRange(lo, hi)
+	for _, v := range data {
+		result.Add(v)
+	}
+	return result
+}
+
+// NewDenseWithRange will return an empty Dense, with the words for every integer from
+// `lo` to `hi` inclusive already allocated. Items outside that range can still be
+// added, but will grow the slice. If `lo > hi`, nothing is allocated.
+func NewDenseWithRange(lo, hi int) Dense {
+	if lo > hi {
+		return Dense{}
+	}
+	first, last := int64(lo>>6), int64(hi>>6)
+	return Dense{base: first, words: make([]uint64, last-first+1)}
+}
+
+// ToDense will return a Dense holding the same items as `s`
+func (s *Set) ToDense() Dense {
+	result := Dense{}
+	lo, ok := s.Min()
+	if !ok {
+		return result
+	}
+	hi, _ := s.Max()
+
+	result = NewDenseWithRange(lo, hi)
+	for k, w := range s.data {
+		result.words[k-result.base] |= w
+	}
+	return result
+}
+
+// ToSet will return a Set holding the same items as `d`
+func (d *Dense) ToSet() Set {
+	result := Set{data: make(map[int64]uint64)}
+	for i, w := range d.words {
+		if w != 0 {
+			result.set_word(d.base+int64(i), w)
+		}
+	}
+	return result
+}
+
+// word returns a pointer to word `k`, growing the slice to reach it if needed
+func (d *Dense) word(k int64) *uint64 {
+	if len(d.words) == 0 {
+		d.base = k
+		d.words = append(d.words[:0], 0)
+		return &d.words[0]
+	}
+
+	if k < d.base {
+		grown := make([]uint64, int64(len(d.words))+d.base-k, int64(cap(d.words))+d.base-k)
+		copy(grown[d.base-k:], d.words)
+		d.base, d.words = k, grown
+	} else if last := d.base + int64(len(d.words)) - 1; k > last {
+		d.words = append(d.words, make([]uint64, k-last)...)
+	}
+	return &d.words[k-d.base]
+}
+
+// lookup returns word `k`, or 0 if it is outside the slice
+func (d *Dense) lookup(k int64) uint64 {
+	if k < d.base || k-d.base >= int64(len(d.words)) {
+		return 0
+	}
+	return d.words[k-d.base]
+}
+
+// Contains will return true if the set contains the item
+func (d *Dense) Contains(item int) bool {
+	k, slot := word_of(item)
+	return d.lookup(k)&slot != 0
+}
+
+// Add will add a new item to `d`. If it already exists, it is ignored
+func (d *Dense) Add(item int) {
+	k, slot := word_of(item)
+	*d.word(k) |= slot
+}
+
+// TryRemove removes an item from the set, and returns true if it was there
+func (d *Dense) TryRemove(item int) bool {
+	k, slot := word_of(item)
+	if d.lookup(k)&slot == 0 {
+		return false
+	}
+	d.words[k-d.base] &^= slot
+	return true
+}
+
+// Remove removes an item from the set. Returns a *NotFoundError if the item doesn't
+// exist
+func (d *Dense) Remove(item int) error {
+	if !d.TryRemove(item) {
+		return &NotFoundError{Item: item}
+	}
+	return nil
+}
+
+// Discard removes an item from the set. If it doesn't exist, it is ignored
+func (d *Dense) Discard(item int) {
+	d.TryRemove(item)
+}
+
+// Pop will remove and return the largest item from the set. If the set is empty, it
+// will return an error. Empty words left at the end of the slice are dropped, so
+// popping everything off doesn't rescan them.
+func (d *Dense) Pop() (item int, err error) {
+	for i := len(d.words) - 1; i >= 0; i-- {
+		w := d.words[i]
+		if w == 0 {
+			d.words = d.words[:i]
+			continue
+		}
+		idx := 63 - bits.LeadingZeros64(w)
+		d.words[i] &^= 1 << uint(idx)
+		return 64*int(d.base+int64(i)) + idx, nil
+	}
+	return item, ErrElementNotFound
+}
+
+// Clear will remove all items from the set. The words are kept, so the set can be
+// filled again without allocating.
+func (d *Dense) Clear() {
+	clear(d.words)
+}
+
+// Len returns the length of the set
+func (d *Dense) Len() int {
+	res := 0
+	for _, w := range d.words {
+		res += bits.OnesCount64(w)
+	}
+	return res
+}
+
+// IsEmpty returns true if the set is empty
+func (d *Dense) IsEmpty() bool {
+	for _, w := range d.words {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ForEach calls `f` on every item in the set, in ascending order. It stops early if `f`
+// returns false.
+func (d *Dense) ForEach(f func(item int) bool) {
+	for i, w := range d.words {
+		base := 64 * int(d.base+int64(i))
+		for ; w != 0; w &= w - 1 {
+			if !f(base + bits.TrailingZeros64(w)) {
+				return
+			}
+		}
+	}
+}
+
+// Iterate calls `f` on every item in the set. It stops early if `f` returns false. The
+// items happen to be in ascending order, as with ForEach.
+func (d *Dense) Iterate(f func(item int) bool) {
+	d.ForEach(f)
+}
+
+// Slice will return all the items in the set as a slice, in ascending order
+func (d *Dense) Slice() []int {
+	result := make([]int, 0, d.Len())
+	d.ForEach(func(item int) bool {
+		result = append(result, item)
+		return true
+	})
+	return result
+}
+
+// String prints the items in ascending order, such as "{-1, 3, 64}"
+func (d Dense) String() string {
+	var b strings.Builder
+	b.WriteRune('{')
+	for idx, v := range d.Slice() {
+		if idx > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(strconv.Itoa(v))
+	}
+	b.WriteRune('}')
+	return b.String()
+}
+
+// Min returns the smallest item in the set. The bool is false if the set is empty.
+func (d *Dense) Min() (int, bool) {
+	for i, w := range d.words {
+		if w != 0 {
+			return 64*int(d.base+int64(i)) + bits.TrailingZeros64(w), true
+		}
+	}
+	return 0, false
+}
+
+// Max returns the largest item in the set. The bool is false if the set is empty.
+func (d *Dense) Max() (int, bool) {
+	for i := len(d.words) - 1; i >= 0; i-- {
+		if w := d.words[i]; w != 0 {
+			return 64*int(d.base+int64(i)) + 63 - bits.LeadingZeros64(w), true
+		}
+	}
+	return 0, false
+}
+
+// Copy makes a deep copy
+func (d *Dense) Copy() Dense {
+	words := make([]uint64, len(d.words))
+	copy(words, d.words)
+	return Dense{base: d.base, words: words}
+}
+
+// Equals will return true if `d` and `t` contain the same elements. The two sets don't
+// need to have allocated the same range of words.
+func (d *Dense) Equals(t Dense) bool {
+	for i, w := range d.words {
+		if w != t.lookup(d.base+int64(i)) {
+			return false
+		}
+	}
+	for i, w := range t.words {
+		if w != d.lookup(t.base+int64(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// UnionInPlace will add all the items in set `t` to set `d`
+func (d *Dense) UnionInPlace(t Dense) {
+	for i, w := range t.words {
+		if w != 0 {
+			*d.word(t.base + int64(i)) |= w
+		}
+	}
+}
+
+// IntersectionInPlace will remove any items from `d` that are not in `t`
+func (d *Dense) IntersectionInPlace(t Dense) {
+	for i := range d.words {
+		d.words[i] &= t.lookup(d.base + int64(i))
+	}
+}
+
+// DifferenceInPlace removes any elements in `d` that are in `t`
+func (d *Dense) DifferenceInPlace(t Dense) {
+	for i := range d.words {
+		d.words[i] &^= t.lookup(d.base + int64(i))
+	}
+}
@@ -0,0 +1,195 @@
+package bitset
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestNewDense(t *testing.T) {
+	testCases := []struct {
+		desc string
+		data []int
+		want []int
+	}{
+		{desc: "empty", data: []int{}, want: []int{}},
+		{desc: "one item", data: []int{5}, want: []int{5}},
+		{desc: "duplicates", data: []int{3, 1, 3, 2}, want: []int{1, 2, 3}},
+		{desc: "across zero", data: []int{64, -1, 0, -64, -65, 63}, want: []int{-65, -64, -1, 0, 63, 64}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			d := NewDense(tC.data)
+			if got := d.Slice(); !slices.Equal(got, tC.want) {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+			if d.Len() != len(tC.want) {
+				t.Errorf("got length %d; want %d", d.Len(), len(tC.want))
+			}
+		})
+	}
+}
+
+func TestDenseGrows(t *testing.T) {
+	d := NewDenseWithRange(0, 127)
+	if len(d.words) != 2 {
+		t.Errorf("got %d words; want 2", len(d.words))
+	}
+
+	// Below and above the allocated range
+	items := []int{50, -200, 1000, 0, -1}
+	for _, v := range items {
+		d.Add(v)
+	}
+	want := []int{-200, -1, 0, 50, 1000}
+	if got := d.Slice(); !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+	for _, v := range want {
+		if !d.Contains(v) {
+			t.Errorf("%d should be in the set", v)
+		}
+	}
+	for _, v := range []int{-10_000, -199, 1, 999, 10_000} {
+		if d.Contains(v) {
+			t.Errorf("%d should not be in the set", v)
+		}
+	}
+}
+
+func TestDenseRemove(t *testing.T) {
+	d := NewDense([]int{-3, 0, 70})
+
+	if err := d.Remove(0); err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+	if err := d.Remove(0); !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrElementNotFound)
+	}
+	if err := d.Remove(5000); !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrElementNotFound)
+	}
+
+	d.Discard(-3)
+	d.Discard(-3000)
+	if got, want := d.Slice(), []int{70}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestDensePop(t *testing.T) {
+	d := NewDense([]int{-100, 2, 300})
+	for _, want := range []int{300, 2, -100} {
+		got, err := d.Pop()
+		if err != nil || got != want {
+			t.Errorf("got %v, %v; want %v, nil", got, err, want)
+		}
+	}
+	if _, err := d.Pop(); !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrElementNotFound)
+	}
+}
+
+func TestDenseMinMax(t *testing.T) {
+	var empty Dense
+	if _, ok := empty.Min(); ok {
+		t.Errorf("an empty set should have no Min")
+	}
+	if _, ok := empty.Max(); ok {
+		t.Errorf("an empty set should have no Max")
+	}
+
+	d := NewDenseWithRange(-1000, 1000)
+	d.Add(-65)
+	d.Add(130)
+	if got, _ := d.Min(); got != -65 {
+		t.Errorf("got %v; want %v", got, -65)
+	}
+	if got, _ := d.Max(); got != 130 {
+		t.Errorf("got %v; want %v", got, 130)
+	}
+}
+
+func TestDenseConvert(t *testing.T) {
+	items := []int{-130, -64, -1, 0, 1, 63, 64, 1000}
+	s := NewSet(items)
+
+	d := s.ToDense()
+	if got := d.Slice(); !slices.Equal(got, items) {
+		t.Errorf("got %v; want %v", got, items)
+	}
+
+	back := d.ToSet()
+	if !back.Equals(s) {
+		t.Errorf("got %v; want %v", back, s)
+	}
+}
+
+func TestDenseEquals(t *testing.T) {
+	d := NewDense([]int{1, 2, 3})
+	wide := NewDenseWithRange(-500, 500)
+	wide.Add(3)
+	wide.Add(2)
+	wide.Add(1)
+
+	if !d.Equals(wide) || !wide.Equals(d) {
+		t.Errorf("%v and %v should be equal", d, wide)
+	}
+	wide.Add(-500)
+	if d.Equals(wide) {
+		t.Errorf("%v and %v should not be equal", d, wide)
+	}
+
+	// Far apart, so only the allocated words should be compared
+	far := NewDense([]int{1 << 60})
+	if d.Equals(far) || far.Equals(d) {
+		t.Errorf("%v and %v should not be equal", d, far)
+	}
+}
+
+func TestDenseInPlace(t *testing.T) {
+	a_items := []int{-70, 0, 5, 64, 200}
+	b_items := []int{-70, 5, 65, 500}
+	a, b := NewSet(a_items), NewSet(b_items)
+
+	testCases := []struct {
+		desc string
+		f    func(d *Dense, t Dense)
+		want Set
+	}{
+		{desc: "union", f: (*Dense).UnionInPlace, want: a.Union(b)},
+		{desc: "intersection", f: (*Dense).IntersectionInPlace, want: a.Intersection(b)},
+		{desc: "difference", f: (*Dense).DifferenceInPlace, want: a.Difference(b)},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			d := NewDense(a_items)
+			tC.f(&d, NewDense(b_items))
+			got := d.ToSet()
+			if !got.Equals(tC.want) {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+}
+
+func BenchmarkDenseForEach(b *testing.B) {
+	d := NewDense([]int{})
+	s := NewSet([]int{})
+	for i := 0; i < 100_000; i += 3 {
+		d.Add(i)
+		s.Add(i)
+	}
+
+	b.Run("Dense", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			d.ForEach(func(int) bool { return true })
+		}
+	})
+	b.Run("Set", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s.ForEach(func(int) bool { return true })
+		}
+	})
+}
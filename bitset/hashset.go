@@ -0,0 +1,24 @@
+package bitset
+
+import (
+	"github.com/natemcintosh/set"
+)
+
+// EqualIntSets will return true if the hash set `a` and the bitset `b` hold the same
+// numbers, such as when checking a migration from one representation to the other. Both
+// lengths are kept up to date, so sets of different sizes are told apart at once.
+// Otherwise each item of `a` is looked up in its word of `b`, and nothing is sorted or
+// copied.
+func EqualIntSets(a set.Set[int], b Set) bool {
+	if a.Len() != b.length {
+		return false
+	}
+
+	result := true
+	a.Iterate(func(item int) bool {
+		k, slot := word_of(item)
+		result = b.data[k]&slot != 0
+		return result
+	})
+	return result
+}
@@ -0,0 +1,30 @@
+package bitset
+
+import (
+	"math"
+	"testing"
+
+	"github.com/natemcintosh/set"
+)
+
+func TestEqualIntSets(t *testing.T) {
+	testCases := []struct {
+		desc string
+		a    []int
+		b    []int
+		want bool
+	}{
+		{desc: "both empty", a: []int{}, b: []int{}, want: true},
+		{desc: "same", a: []int{-65, -1, 0, 64, math.MaxInt, math.MinInt}, b: []int{math.MinInt, 64, 0, -1, -65, math.MaxInt}, want: true},
+		{desc: "different lengths", a: []int{1, 2}, b: []int{1}, want: false},
+		{desc: "same length, different items", a: []int{1, 2}, b: []int{1, 3}, want: false},
+		{desc: "same bit, different word", a: []int{1}, b: []int{65}, want: false},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := EqualIntSets(set.NewSet(tC.a), NewSet(tC.b)); got != tC.want {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package bitset
+
+// The Into functions fill `dst` rather than returning a new Set. Its word map is cleared
+// but not thrown away, so one destination can be reused on every pass of a loop. `dst`
+// must not be `a` or `b`. As with the methods, `dst` takes the universe and pop mode of
+// `a`.
+
+// reset empties `s` ready to take a result, keeping the memory of its map, and gives it
+// the universe and pop mode of `from`
+func (s *Set) reset(from Set) {
+	if s.data == nil {
+		s.data = make(map[int64]uint64)
+	} else {
+		clear(s.data)
+	}
+	s.length = 0
+	s.universe = from.universe
+	s.pop_smallest = from.pop_smallest
+}
+
+// CopyInto replaces the contents of `dst` with the items of `s`
+func (s *Set) CopyInto(dst *Set) {
+	dst.reset(*s)
+	for key, slots := range s.data {
+		dst.data[key] = slots
+	}
+	dst.length = s.length
+}
+
+// UnionInto replaces the contents of `dst` with the union of `a` and `b`
+func UnionInto(dst *Set, a, b Set) {
+	a.CopyInto(dst)
+	for key, slots := range b.data {
+		dst.set_word(key, dst.data[key]|slots)
+	}
+}
+
+// IntersectionInto replaces the contents of `dst` with the intersection of `a` and `b`
+func IntersectionInto(dst *Set, a, b Set) {
+	dst.reset(a)
+
+	// Iterate over the smaller of the two sets, keeping the bits each word shares with
+	// the larger one
+	small, large := a.data, b.data
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	for key, slots := range small {
+		dst.set_word(key, slots&large[key])
+	}
+}
+
+// DifferenceInto replaces the contents of `dst` with the elements in `a` that are not
+// in `b`
+func DifferenceInto(dst *Set, a, b Set) {
+	dst.reset(a)
+	for key, slots := range a.data {
+		dst.set_word(key, slots&^b.data[key])
+	}
+}
+
+// SymmetricDifferenceInto replaces the contents of `dst` with the elements in either
+// `a` or `b`, but not both
+func SymmetricDifferenceInto(dst *Set, a, b Set) {
+	a.CopyInto(dst)
+	for key, slots := range b.data {
+		dst.set_word(key, dst.data[key]^slots)
+	}
+}
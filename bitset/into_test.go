@@ -0,0 +1,58 @@
+package bitset
+
+import (
+	"testing"
+)
+
+func TestInto(t *testing.T) {
+	s1 := NewSet([]int{-130, -64, -1, 0, 5, 63, 64, 1000})
+	s2 := NewSet([]int{-64, 0, 6, 63, 65, 1000, 2000})
+
+	testCases := []struct {
+		desc string
+		into func(dst *Set, a, b Set)
+		want Set
+	}{
+		{desc: "union", into: UnionInto, want: s1.Union(s2)},
+		{desc: "intersection", into: IntersectionInto, want: s1.Intersection(s2)},
+		{desc: "difference", into: DifferenceInto, want: s1.Difference(s2)},
+		{desc: "symmetric difference", into: SymmetricDifferenceInto, want: s1.SymmetricDifference(s2)},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			// Start with items that must all be cleared out, and reuse it a few times
+			dst := NewSet([]int{100, 200, 300})
+			for i := 0; i < 3; i++ {
+				tC.into(&dst, s1, s2)
+				if !dst.Equals(tC.want) {
+					t.Errorf("got %v; want %v", dst, tC.want)
+				}
+				if dst.Len() != tC.want.Len() {
+					t.Errorf("got length %d; want %d", dst.Len(), tC.want.Len())
+				}
+			}
+
+			// The zero value works as a destination too
+			var empty Set
+			tC.into(&empty, s1, s2)
+			if !empty.Equals(tC.want) {
+				t.Errorf("got %v; want %v", empty, tC.want)
+			}
+		})
+	}
+}
+
+func TestCopyInto(t *testing.T) {
+	s := NewSet([]int{-5, 1, 200})
+	dst := NewSet([]int{4, 5})
+	s.CopyInto(&dst)
+	if !dst.Equals(s) {
+		t.Errorf("got %v; want %v", dst, s)
+	}
+
+	// Changing the copy doesn't change the original
+	dst.Add(10)
+	if s.Contains(10) {
+		t.Errorf("CopyInto should not share the map with the original")
+	}
+}
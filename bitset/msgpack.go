@@ -0,0 +1,67 @@
+package bitset
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/natemcintosh/set/internal/msgpack"
+)
+
+// MarshalMsgpack encodes the set as a MessagePack map from word index to word, using the
+// same layout as `MarshalCBOR`: word `k` holds the numbers 64*k through 64*k + 63, and is
+// written as 8 bytes of little endian binary.
+func (s Set) MarshalMsgpack() ([]byte, error) {
+	keys := sorted_keys(s.data)
+
+	result := msgpack.AppendMapHeader(nil, len(keys))
+	var buf [8]byte
+	for _, k := range keys {
+		result = msgpack.AppendInt(result, k)
+		binary.LittleEndian.PutUint64(buf[:], s.data[k])
+		result = msgpack.AppendBinary(result, buf[:])
+	}
+	return result, nil
+}
+
+// UnmarshalMsgpack replaces the contents of the set with the words of a MessagePack map,
+// in the form written by `MarshalMsgpack`
+func (s *Set) UnmarshalMsgpack(data []byte) error {
+	d := msgpack.NewDecoder(data)
+	n, err := d.ReadMapHeader()
+	if err != nil {
+		return err
+	}
+
+	result := NewSet([]int{})
+	for i := 0; i < n; i++ {
+		k, err := d.ReadInt()
+		if err != nil {
+			return err
+		}
+		if err := check_word_index(k); err != nil {
+			return err
+		}
+		b, err := d.ReadBinary()
+		if err != nil {
+			return err
+		}
+		if len(b) > 8 {
+			return msgpack.ErrMalformed
+		}
+		var buf [8]byte
+		copy(buf[:], b)
+		word := binary.LittleEndian.Uint64(buf[:])
+
+		for word != 0 {
+			idx := bits.TrailingZeros64(word)
+			result.Add(int(k)*64 + idx)
+			word &= word - 1
+		}
+	}
+	if !d.Done() {
+		return msgpack.ErrMalformed
+	}
+
+	*s = result
+	return nil
+}
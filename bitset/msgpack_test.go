@@ -0,0 +1,68 @@
+package bitset
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/natemcintosh/set/internal/msgpack"
+)
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	testCases := []struct {
+		desc string
+		in   []int
+	}{
+		{
+			desc: "empty",
+			in:   []int{},
+		},
+		{
+			desc: "around zero",
+			in:   []int{-65, -64, -63, -1, 0, 1, 63, 64, 65},
+		},
+		{
+			desc: "sparse",
+			in:   []int{-1 << 40, 7, 1 << 50},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			want := NewSet(tC.in)
+			data, err := want.MarshalMsgpack()
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+
+			var got Set
+			if err := got.UnmarshalMsgpack(data); err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if got.Len() != want.Len() || !got.IsSubsetOf(want) {
+				t.Errorf("got %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestMsgpackErrors(t *testing.T) {
+	var s Set
+	if err := s.UnmarshalMsgpack([]byte{0x81, 0x00}); err == nil {
+		t.Errorf("truncated input should fail")
+	}
+	if err := s.UnmarshalMsgpack([]byte{0x91, 0x00}); err == nil {
+		t.Errorf("an array should not decode into a bitset")
+	}
+}
+
+func TestMsgpackWordRange(t *testing.T) {
+	for _, k := range []int64{math.MaxInt>>6 + 1, math.MinInt>>6 - 1} {
+		data := msgpack.AppendMapHeader(nil, 1)
+		data = msgpack.AppendInt(data, k)
+		data = msgpack.AppendBinary(data, []byte{1})
+		var s Set
+		if err := s.UnmarshalMsgpack(data); !errors.Is(err, ErrWordOutOfRange) {
+			t.Errorf("word %d: got error %v, want %v", k, err, ErrWordOutOfRange)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package bitset
+
+import (
+	"math"
+	"math/bits"
+
+	"golang.org/x/exp/slices"
+)
+
+// Rank returns the number of items in the set that are less than or equal to `x`. It
+// counts whole words at a time with popcounts, so it never looks at single items.
+func (s *Set) Rank(x int) int {
+	k := int64(x >> 6)
+	// Every bit up to and including bit x&63. For bit 63 the shift gives 0, and the
+	// subtraction wraps around to every bit.
+	mask := uint64(1)<<uint(x&63)<<1 - 1
+
+	result := 0
+	for idx, w := range s.data {
+		switch {
+		case idx < k:
+			result += bits.OnesCount64(w)
+		case idx == k:
+			result += bits.OnesCount64(w & mask)
+		}
+	}
+	return result
+}
+
+// sorted_keys returns the word indexes of `words` in ascending order
+func sorted_keys(words map[int64]uint64) []int64 {
+	result := make([]int64, 0, len(words))
+	for k := range words {
+		result = append(result, k)
+	}
+	slices.Sort(result)
+	return result
+}
+
+// Select returns the `i`th smallest item in the set, counting from 0. The bool is false
+// if `i` is negative, or not less than the length of the set. Whole words are skipped
+// using their popcounts.
+func (s *Set) Select(i int) (int, bool) {
+	if i < 0 {
+		return 0, false
+	}
+
+	for _, k := range sorted_keys(s.data) {
+		w := s.data[k]
+		count := bits.OnesCount64(w)
+		if i >= count {
+			i -= count
+			continue
+		}
+
+		// Drop the lowest `i` bits, and the item is the lowest one left
+		for ; i > 0; i-- {
+			w &= w - 1
+		}
+		return int(k)*64 + bits.TrailingZeros64(w), true
+	}
+	return 0, false
+}
+
+// NextAfter returns the smallest item in the set that is greater than `x`. The bool is
+// false if there isn't one. It takes one pass over the words, so can be used as a
+// cursor to walk the set in order.
+func (s *Set) NextAfter(x int) (int, bool) {
+	if x == math.MaxInt {
+		return 0, false
+	}
+	y := x + 1
+	k := int64(y >> 6)
+
+	found := false
+	result := 0
+	for idx, w := range s.data {
+		if idx < k {
+			continue
+		}
+		if idx == k {
+			// Only bits at or above y
+			w &= ^uint64(0) << uint(y&63)
+		}
+		if w == 0 {
+			continue
+		}
+		if v := int(idx)*64 + bits.TrailingZeros64(w); !found || v < result {
+			result = v
+			found = true
+		}
+	}
+	return result, found
+}
+
+// PrevBefore returns the largest item in the set that is less than `x`. The bool is
+// false if there isn't one.
+func (s *Set) PrevBefore(x int) (int, bool) {
+	if x == math.MinInt {
+		return 0, false
+	}
+	y := x - 1
+	k := int64(y >> 6)
+
+	found := false
+	result := 0
+	for idx, w := range s.data {
+		if idx > k {
+			continue
+		}
+		if idx == k {
+			// Only bits at or below y
+			w &= uint64(1)<<uint(y&63)<<1 - 1
+		}
+		if w == 0 {
+			continue
+		}
+		if v := int(idx)*64 + 63 - bits.LeadingZeros64(w); !found || v > result {
+			result = v
+			found = true
+		}
+	}
+	return result, found
+}
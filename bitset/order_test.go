@@ -0,0 +1,92 @@
+package bitset
+
+import (
+	"testing"
+)
+
+func TestRank(t *testing.T) {
+	s := NewSet([]int{-130, -64, -1, 0, 1, 63, 64, 1000})
+	testCases := []struct {
+		desc string
+		x    int
+		want int
+	}{
+		{desc: "below everything", x: -200, want: 0},
+		{desc: "smallest", x: -130, want: 1},
+		{desc: "between", x: -65, want: 1},
+		{desc: "word boundary", x: -64, want: 2},
+		{desc: "minus one", x: -1, want: 3},
+		{desc: "zero", x: 0, want: 4},
+		{desc: "end of word", x: 63, want: 6},
+		{desc: "start of word", x: 64, want: 7},
+		{desc: "above everything", x: 5000, want: 8},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := s.Rank(tC.x); got != tC.want {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	items := []int{-130, -64, -1, 0, 1, 63, 64, 1000}
+	s := NewSet(items)
+	for idx, want := range items {
+		got, ok := s.Select(idx)
+		if !ok || got != want {
+			t.Errorf("Select(%d): got %v, %v; want %v, true", idx, got, ok, want)
+		}
+		// Select and Rank undo each other
+		if rank := s.Rank(got); rank != idx+1 {
+			t.Errorf("Rank(%d): got %v; want %v", got, rank, idx+1)
+		}
+	}
+
+	for _, i := range []int{-1, len(items)} {
+		if got, ok := s.Select(i); ok {
+			t.Errorf("Select(%d): got %v, true; want false", i, got)
+		}
+	}
+}
+
+func TestNextAfterPrevBefore(t *testing.T) {
+	s := NewSet([]int{-130, -64, -1, 0, 63, 64, 1000})
+	testCases := []struct {
+		desc      string
+		x         int
+		want_next int
+		ok_next   bool
+		want_prev int
+		ok_prev   bool
+	}{
+		{desc: "below everything", x: -500, want_next: -130, ok_next: true, ok_prev: false},
+		{desc: "on an item", x: -64, want_next: -1, ok_next: true, want_prev: -130, ok_prev: true},
+		{desc: "across zero", x: -1, want_next: 0, ok_next: true, want_prev: -64, ok_prev: true},
+		{desc: "word boundary", x: 63, want_next: 64, ok_next: true, want_prev: 0, ok_prev: true},
+		{desc: "gap", x: 500, want_next: 1000, ok_next: true, want_prev: 64, ok_prev: true},
+		{desc: "above everything", x: 1000, ok_next: false, want_prev: 64, ok_prev: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			next, ok := s.NextAfter(tC.x)
+			if ok != tC.ok_next || (ok && next != tC.want_next) {
+				t.Errorf("NextAfter: got %v, %v; want %v, %v", next, ok, tC.want_next, tC.ok_next)
+			}
+			prev, ok := s.PrevBefore(tC.x)
+			if ok != tC.ok_prev || (ok && prev != tC.want_prev) {
+				t.Errorf("PrevBefore: got %v, %v; want %v, %v", prev, ok, tC.want_prev, tC.ok_prev)
+			}
+		})
+	}
+
+	// Walking with NextAfter visits every item in order
+	var walked []int
+	for v, ok := s.Min(); ok; v, ok = s.NextAfter(v) {
+		walked = append(walked, v)
+	}
+	if len(walked) != s.Len() {
+		t.Errorf("got %v; want all %d items", walked, s.Len())
+	}
+}
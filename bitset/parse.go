@@ -0,0 +1,42 @@
+package bitset
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	// This error is returned by `Parse` when the braces around the set don't match up
+	ErrUnbalancedBraces = errors.New("bitset: unbalanced braces")
+)
+
+// Parse will return a Set holding the integers in `str`, in the form printed by
+// `String`, such as "{1, 2, 3}". The braces are optional, and the items can be
+// separated by commas, whitespace, or both, so "1 2 3" and "1,2,3" work too. Returns an
+// error naming the position of the first item that isn't an integer.
+func Parse(str string) (Set, error) {
+	str = strings.TrimSpace(str)
+	has_open, has_close := strings.HasPrefix(str, "{"), strings.HasSuffix(str, "}")
+	if has_open != has_close {
+		return Set{}, ErrUnbalancedBraces
+	}
+	if has_open {
+		str = str[1 : len(str)-1]
+	}
+
+	fields := strings.FieldsFunc(str, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	items := make([]int, len(fields))
+	for idx, field := range fields {
+		v, err := strconv.Atoi(field)
+		if err != nil {
+			return Set{}, fmt.Errorf("bitset: item %d: %w", idx+1, err)
+		}
+		items[idx] = v
+	}
+	return NewSet(items), nil
+}
@@ -0,0 +1,70 @@
+package bitset
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		desc string
+		in   string
+		want []int
+	}{
+		{desc: "empty braces", in: "{}", want: []int{}},
+		{desc: "empty string", in: "", want: []int{}},
+		{desc: "String form", in: "{-65, -1, 0, 3, 64}", want: []int{-65, -1, 0, 3, 64}},
+		{desc: "no braces", in: "1, 2, 3", want: []int{1, 2, 3}},
+		{desc: "spaces only", in: "1 2\t3\n4", want: []int{1, 2, 3, 4}},
+		{desc: "commas only", in: "{5,6,7}", want: []int{5, 6, 7}},
+		{desc: "surrounding space and duplicates", in: "  { 2, 2 , 1 }  ", want: []int{1, 2}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := Parse(tC.in)
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if want := NewSet(tC.want); !got.Equals(want) {
+				t.Errorf("got %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	s := NewSet([]int{-1 << 40, -130, 0, 63, 64, 1 << 40})
+	got, err := Parse(s.String())
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if !got.Equals(s) {
+		t.Errorf("got %v; want %v", got, s)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	testCases := []struct {
+		desc string
+		in   string
+		want error
+	}{
+		{desc: "missing close", in: "{1, 2", want: ErrUnbalancedBraces},
+		{desc: "missing open", in: "1, 2}", want: ErrUnbalancedBraces},
+		{desc: "not a number", in: "{1, two, 3}", want: strconv.ErrSyntax},
+		{desc: "too large", in: "{99999999999999999999}", want: strconv.ErrRange},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if _, err := Parse(tC.in); !errors.Is(err, tC.want) {
+				t.Errorf("got error %v, want %v", err, tC.want)
+			}
+		})
+	}
+
+	_, err := Parse("{1, two, 3}")
+	if got, want := err.Error(), `bitset: item 2: strconv.Atoi: parsing "two": invalid syntax`; got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,198 @@
+package bitset
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+var (
+	// This error is returned when the indexes and words given to `FromProtoWords` are
+	// not the same length
+	ErrMismatchedWords = errors.New("indexes and words must be the same length")
+
+	// This error is returned when `UnmarshalProto` is given bytes that are not a valid
+	// Bitset message
+	ErrInvalidProto = errors.New("invalid Bitset protobuf message")
+)
+
+// Field numbers and wire types of the Bitset message in proto/bitset.proto
+const (
+	proto_indexes_field = 1
+	proto_words_field   = 2
+	wire_varint         = 0
+	wire_fixed64        = 1
+	wire_bytes          = 2
+	wire_fixed32        = 5
+)
+
+// ToProtoWords will return the set as the two fields of the Bitset message in
+// proto/bitset.proto. Word `k` holds the numbers 64*k through 64*k + 63. The words are
+// in ascending order of index, and empty words are left out.
+func (s *Set) ToProtoWords() (indexes []int64, words []uint64) {
+	indexes = sorted_keys(s.data)
+
+	words = make([]uint64, len(indexes))
+	for i, k := range indexes {
+		words[i] = s.data[k]
+	}
+	return indexes, words
+}
+
+// FromProtoWords will return a Set from the two fields of the Bitset message in
+// proto/bitset.proto. Returns an error if they are not the same length, or
+// ErrWordOutOfRange if an index holds numbers that don't fit in an int.
+func FromProtoWords(indexes []int64, words []uint64) (Set, error) {
+	if len(indexes) != len(words) {
+		return Set{}, ErrMismatchedWords
+	}
+
+	result := NewSet([]int{})
+	for i, k := range indexes {
+		if err := check_word_index(k); err != nil {
+			return Set{}, err
+		}
+		word := words[i]
+		for word != 0 {
+			idx := bits.TrailingZeros64(word)
+			result.Add(int(k)*64 + idx)
+			word &= word - 1
+		}
+	}
+	return result, nil
+}
+
+// MarshalProto encodes the set as a Bitset message from proto/bitset.proto, in the
+// protobuf wire format
+func (s *Set) MarshalProto() []byte {
+	indexes, words := s.ToProtoWords()
+	if len(indexes) == 0 {
+		return []byte{}
+	}
+
+	// Both fields are packed, so each is a single length delimited record
+	packed_indexes := make([]byte, 0, len(indexes)*2)
+	for _, k := range indexes {
+		packed_indexes = binary.AppendVarint(packed_indexes, k)
+	}
+
+	result := make([]byte, 0, len(packed_indexes)+8*len(words)+8)
+	result = binary.AppendUvarint(result, proto_indexes_field<<3|wire_bytes)
+	result = binary.AppendUvarint(result, uint64(len(packed_indexes)))
+	result = append(result, packed_indexes...)
+
+	result = binary.AppendUvarint(result, proto_words_field<<3|wire_bytes)
+	result = binary.AppendUvarint(result, uint64(8*len(words)))
+	for _, w := range words {
+		result = binary.LittleEndian.AppendUint64(result, w)
+	}
+	return result
+}
+
+// UnmarshalProto replaces the contents of the set with a Bitset message from
+// proto/bitset.proto, in the protobuf wire format. Both the packed and unpacked forms
+// of the repeated fields are accepted, and unknown fields are skipped.
+func (s *Set) UnmarshalProto(data []byte) error {
+	indexes := make([]int64, 0)
+	words := make([]uint64, 0)
+
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return ErrInvalidProto
+		}
+		data = data[n:]
+		field, wire := tag>>3, tag&7
+
+		switch {
+		case field == proto_indexes_field && wire == wire_bytes:
+			payload, rest, err := read_length_delimited(data)
+			if err != nil {
+				return err
+			}
+			for len(payload) > 0 {
+				k, n := binary.Varint(payload)
+				if n <= 0 {
+					return ErrInvalidProto
+				}
+				indexes = append(indexes, k)
+				payload = payload[n:]
+			}
+			data = rest
+		case field == proto_indexes_field && wire == wire_varint:
+			k, n := binary.Varint(data)
+			if n <= 0 {
+				return ErrInvalidProto
+			}
+			indexes = append(indexes, k)
+			data = data[n:]
+		case field == proto_words_field && wire == wire_bytes:
+			payload, rest, err := read_length_delimited(data)
+			if err != nil {
+				return err
+			}
+			if len(payload)%8 != 0 {
+				return ErrInvalidProto
+			}
+			for i := 0; i < len(payload); i += 8 {
+				words = append(words, binary.LittleEndian.Uint64(payload[i:]))
+			}
+			data = rest
+		case field == proto_words_field && wire == wire_fixed64:
+			if len(data) < 8 {
+				return ErrInvalidProto
+			}
+			words = append(words, binary.LittleEndian.Uint64(data))
+			data = data[8:]
+		default:
+			rest, err := skip_field(data, wire)
+			if err != nil {
+				return err
+			}
+			data = rest
+		}
+	}
+
+	result, err := FromProtoWords(indexes, words)
+	if err != nil {
+		return err
+	}
+	*s = result
+	return nil
+}
+
+// read_length_delimited splits a length prefixed record off the front of `data`
+func read_length_delimited(data []byte) (payload []byte, rest []byte, err error) {
+	length, n := binary.Uvarint(data)
+	if n <= 0 || length > uint64(len(data)-n) {
+		return nil, nil, ErrInvalidProto
+	}
+	data = data[n:]
+	return data[:length], data[length:], nil
+}
+
+// skip_field skips over the value of a field with an unknown number
+func skip_field(data []byte, wire uint64) ([]byte, error) {
+	switch wire {
+	case wire_varint:
+		_, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, ErrInvalidProto
+		}
+		return data[n:], nil
+	case wire_fixed64:
+		if len(data) < 8 {
+			return nil, ErrInvalidProto
+		}
+		return data[8:], nil
+	case wire_bytes:
+		_, rest, err := read_length_delimited(data)
+		return rest, err
+	case wire_fixed32:
+		if len(data) < 4 {
+			return nil, ErrInvalidProto
+		}
+		return data[4:], nil
+	}
+	return nil, ErrInvalidProto
+}
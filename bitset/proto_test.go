@@ -0,0 +1,109 @@
+package bitset
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestProtoWords(t *testing.T) {
+	s := NewSet([]int{-1, 0, 1, 65})
+	indexes, words := s.ToProtoWords()
+
+	want_indexes := []int64{-1, 0, 1}
+	want_words := []uint64{1 << 63, 0b11, 0b10}
+	if len(indexes) != len(want_indexes) {
+		t.Fatalf("got indexes %v; want %v", indexes, want_indexes)
+	}
+	for i := range indexes {
+		if indexes[i] != want_indexes[i] || words[i] != want_words[i] {
+			t.Errorf("word %d: got %d:%b; want %d:%b",
+				i, indexes[i], words[i], want_indexes[i], want_words[i])
+		}
+	}
+
+	got, err := FromProtoWords(indexes, words)
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if got.Len() != s.Len() || !got.IsSubsetOf(s) {
+		t.Errorf("got %v; want %v", got, s)
+	}
+
+	if _, err := FromProtoWords([]int64{1}, nil); err != ErrMismatchedWords {
+		t.Errorf("got error %v, want %v", err, ErrMismatchedWords)
+	}
+	// Word 2^60 would hold numbers past math.MaxInt, and used to wrap around to {0}
+	if _, err := FromProtoWords([]int64{1 << 60}, []uint64{1}); err != ErrWordOutOfRange {
+		t.Errorf("got error %v, want %v", err, ErrWordOutOfRange)
+	}
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	testCases := []struct {
+		desc string
+		in   []int
+	}{
+		{
+			desc: "empty",
+			in:   []int{},
+		},
+		{
+			desc: "around zero",
+			in:   []int{-65, -64, -63, -1, 0, 1, 63, 64, 65},
+		},
+		{
+			desc: "sparse",
+			in:   []int{-1 << 40, 7, 1 << 50},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			want := NewSet(tC.in)
+			data := want.MarshalProto()
+
+			var got Set
+			if err := got.UnmarshalProto(data); err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if got.Len() != want.Len() || !got.IsSubsetOf(want) {
+				t.Errorf("got %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestProtoWireFormat(t *testing.T) {
+	s := NewSet([]int{0, 64})
+	got := s.MarshalProto()
+	want := []byte{
+		// indexes: field 1, length 2, zigzag 0 and 1
+		0x0a, 0x02, 0x00, 0x02,
+		// words: field 2, length 16, two fixed64 values of 1
+		0x12, 0x10,
+		0x01, 0, 0, 0, 0, 0, 0, 0,
+		0x01, 0, 0, 0, 0, 0, 0, 0,
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got %x; want %x", got, want)
+	}
+
+	// The unpacked form, with an unknown field thrown in, decodes to the same set
+	unpacked := []byte{
+		0x08, 0x00,
+		0x18, 0x96, 0x01,
+		0x08, 0x02,
+		0x11, 0x01, 0, 0, 0, 0, 0, 0, 0,
+		0x11, 0x01, 0, 0, 0, 0, 0, 0, 0,
+	}
+	var decoded Set
+	if err := decoded.UnmarshalProto(unpacked); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if decoded.Len() != s.Len() || !decoded.IsSubsetOf(s) {
+		t.Errorf("got %v; want %v", decoded, s)
+	}
+
+	if err := decoded.UnmarshalProto([]byte{0x0a, 0x05, 0x00}); err != ErrInvalidProto {
+		t.Errorf("got error %v, want %v", err, ErrInvalidProto)
+	}
+}
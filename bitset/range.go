@@ -0,0 +1,153 @@
+package bitset
+
+import (
+	"math/bits"
+)
+
+// word_mask returns the bits of word `k` that hold numbers from `lo` to `hi` inclusive
+func word_mask(k int64, lo, hi int) uint64 {
+	first, last := int64(lo>>6), int64(hi>>6)
+	if lo > hi || k < first || k > last {
+		return 0
+	}
+	mask := ^uint64(0)
+	if k == first {
+		mask &= ^uint64(0) << uint(lo&63)
+	}
+	if k == last {
+		mask &= uint64(1)<<uint(hi&63)<<1 - 1
+	}
+	return mask
+}
+
+// range_words calls `f` with each word index, and the mask of bits in its word, that
+// together cover every number from `lo` to `hi` inclusive
+func range_words(lo, hi int, f func(k int64, mask uint64)) {
+	range_words_until(lo, hi, func(k int64, mask uint64) bool {
+		f(k, mask)
+		return true
+	})
+}
+
+// range_words_until is range_words, but stops as soon as `f` returns false
+func range_words_until(lo, hi int, f func(k int64, mask uint64) bool) {
+	if lo > hi {
+		return
+	}
+	first, last := int64(lo>>6), int64(hi>>6)
+	for k := first; ; k++ {
+		// Checked after `f` rather than in the loop condition, so a range ending in the
+		// last word doesn't overflow `k`
+		if !f(k, word_mask(k, lo, hi)) || k == last {
+			return
+		}
+	}
+}
+
+// stored_words calls `f` with each word of the set that holds numbers from `lo` to `hi`
+// inclusive, and the mask of its bits in that range. If the range spans more words than
+// the set stores, the stored words are looped over instead of the range, so a wide
+// range over a sparse set costs O(len(s.data)) rather than O(hi - lo). `f` may change
+// or drop the word it is given.
+func (s *Set) stored_words(lo, hi int, f func(k int64, mask uint64)) {
+	if lo > hi {
+		return
+	}
+	first, last := int64(lo>>6), int64(hi>>6)
+	if uint64(last-first) < uint64(len(s.data)) {
+		range_words(lo, hi, func(k int64, mask uint64) {
+			if _, ok := s.data[k]; ok {
+				f(k, mask)
+			}
+		})
+		return
+	}
+	for k := range s.data {
+		if k >= first && k <= last {
+			f(k, word_mask(k, lo, hi))
+		}
+	}
+}
+
+// NewRange will return a Set holding every integer from `lo` to `hi` inclusive. Whole
+// words are written at once, so even a range of millions is quick to build. If
+// `lo > hi`, the set is empty.
+func NewRange(lo, hi int) Set {
+	result := NewSet([]int{})
+	result.AddRange(lo, hi)
+	return result
+}
+
+// AddRange adds every integer from `lo` to `hi` inclusive. Whole words are set at once
+// with masks, rather than adding one item at a time. If `lo > hi`, nothing is added.
+func (s *Set) AddRange(lo, hi int) {
+	if s.data == nil {
+		s.data = make(map[int64]uint64)
+	}
+	range_words(lo, hi, func(k int64, mask uint64) {
+		s.set_word(k, s.data[k]|mask)
+	})
+}
+
+// DiscardRange removes every integer from `lo` to `hi` inclusive that is in the set.
+// Whole words are cleared at once with masks, and words left empty are dropped. Only
+// words the set stores are visited, so a wide range over a sparse set is cheap.
+func (s *Set) DiscardRange(lo, hi int) {
+	s.stored_words(lo, hi, func(k int64, mask uint64) {
+		s.set_word(k, s.data[k]&^mask)
+	})
+}
+
+// RemoveRange removes every integer from `lo` to `hi` inclusive. If any of them is not
+// in the set, a *NotFoundError holding one of the missing items is returned, and the
+// set is left unchanged. The check stops at the first word with a missing item, so it
+// never walks further than one word past the words the set stores.
+func (s *Set) RemoveRange(lo, hi int) error {
+	var missing error
+	range_words_until(lo, hi, func(k int64, mask uint64) bool {
+		if absent := mask &^ s.data[k]; absent != 0 {
+			missing = &NotFoundError{Item: 64*int(k) + bits.TrailingZeros64(absent)}
+			return false
+		}
+		return true
+	})
+	if missing != nil {
+		return missing
+	}
+
+	s.DiscardRange(lo, hi)
+	return nil
+}
+
+// CountRange returns how many items of the set are from `lo` to `hi` inclusive. Each
+// stored word in the range is masked and popcounted, so no items are listed out.
+func (s *Set) CountRange(lo, hi int) int {
+	result := 0
+	s.stored_words(lo, hi, func(k int64, mask uint64) {
+		result += bits.OnesCount64(s.data[k] & mask)
+	})
+	return result
+}
+
+// Complement will create a new Set holding every integer from `lo` to `hi` inclusive
+// that is not in `s`, such as the free slots in a block of IDs. It is built a word at a
+// time, without making the full range first.
+func (s *Set) Complement(lo, hi int) Set {
+	result := NewSet([]int{})
+	range_words(lo, hi, func(k int64, mask uint64) {
+		result.set_word(k, mask&^s.data[k])
+	})
+	return result
+}
+
+// FlipRange adds every integer from `lo` to `hi` inclusive that is not in the set, and
+// removes every one that is. Whole words are flipped at once with XOR masks, and words
+// left empty are dropped.
+func (s *Set) FlipRange(lo, hi int) {
+	if s.data == nil {
+		s.data = make(map[int64]uint64)
+	}
+	range_words(lo, hi, func(k int64, mask uint64) {
+		s.set_word(k, s.data[k]^mask)
+	})
+}
@@ -0,0 +1,195 @@
+package bitset
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNewRange(t *testing.T) {
+	testCases := []struct {
+		desc string
+		lo   int
+		hi   int
+	}{
+		{desc: "empty", lo: 5, hi: 4},
+		{desc: "single", lo: 7, hi: 7},
+		{desc: "within a word", lo: 3, hi: 40},
+		{desc: "across words", lo: 60, hi: 200},
+		{desc: "negative", lo: -200, hi: -60},
+		{desc: "across zero", lo: -70, hi: 70},
+		{desc: "ends at zero", lo: -64, hi: 0},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			data := []int{}
+			for v := tC.lo; v <= tC.hi; v++ {
+				data = append(data, v)
+			}
+			want := NewSet(data)
+
+			got := NewRange(tC.lo, tC.hi)
+			if !got.Equals(want) {
+				t.Errorf("got %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkNewRange(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewRange(0, 10_000_000)
+	}
+}
+
+func TestAddRange(t *testing.T) {
+	s := NewSet([]int{-300, 5, 1000})
+	s.AddRange(-70, 70)
+
+	want := NewRange(-70, 70)
+	want.Add(-300)
+	want.Add(1000)
+	if !s.Equals(want) {
+		t.Errorf("got %v; want %v", s, want)
+	}
+
+	// An empty range changes nothing
+	s.AddRange(10, 0)
+	if !s.Equals(want) {
+		t.Errorf("got %v; want %v", s, want)
+	}
+
+	var zero Set
+	zero.AddRange(1, 3)
+	if zero.Len() != 3 {
+		t.Errorf("got %v; want 3 items", zero)
+	}
+}
+
+func TestDiscardRange(t *testing.T) {
+	s := NewRange(-200, 200)
+	s.DiscardRange(-100, 150)
+
+	want := NewRange(-200, -101)
+	want.AddRange(151, 200)
+	if !s.Equals(want) {
+		t.Errorf("got %v; want %v", s, want)
+	}
+
+	// Discarding things that aren't there is fine
+	s.DiscardRange(1000, 2000)
+	if !s.Equals(want) {
+		t.Errorf("got %v; want %v", s, want)
+	}
+
+	// A range of far more words than the set stores only visits the stored words
+	sparse := NewSet([]int{math.MinInt, -5, 70, math.MaxInt})
+	sparse.DiscardRange(math.MinInt+1, math.MaxInt)
+	if want := NewSet([]int{math.MinInt}); !sparse.Equals(want) {
+		t.Errorf("got %v; want %v", sparse, want)
+	}
+}
+
+func TestRemoveRange(t *testing.T) {
+	s := NewRange(0, 100)
+	if err := s.RemoveRange(10, 20); err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+	if s.Len() != 90 {
+		t.Errorf("got length %d, want 90", s.Len())
+	}
+
+	// 10 through 20 are gone now, so this fails and changes nothing
+	err := s.RemoveRange(-5, 15)
+	var not_found *NotFoundError
+	if !errors.As(err, &not_found) {
+		t.Fatalf("got error %v, want a *NotFoundError", err)
+	}
+	if s.Contains(not_found.Item) {
+		t.Errorf("%d is in the set, so shouldn't be reported missing", not_found.Item)
+	}
+	if s.Len() != 90 {
+		t.Errorf("a failed RemoveRange should leave the set alone, got length %d", s.Len())
+	}
+
+	// The check stops at the first missing word rather than walking the whole range
+	err = s.RemoveRange(0, math.MaxInt)
+	if !errors.As(err, &not_found) || not_found.Item != 10 {
+		t.Errorf("got error %v; want 10 reported missing", err)
+	}
+}
+
+func TestCountRange(t *testing.T) {
+	s := NewSet([]int{math.MinInt, -130, -64, -1, 0, 1, 63, 64, 1000, math.MaxInt})
+	testCases := []struct {
+		desc string
+		lo   int
+		hi   int
+		want int
+	}{
+		{desc: "bottom word", lo: math.MinInt, hi: math.MinInt + 1, want: 1},
+		{desc: "middle", lo: -1000, hi: 1000, want: 8},
+		{desc: "top word", lo: math.MaxInt - 1, hi: math.MaxInt, want: 1},
+		{desc: "empty range", lo: 5, hi: 4, want: 0},
+		{desc: "negative", lo: -200, hi: -1, want: 3},
+		{desc: "across zero", lo: -1, hi: 1, want: 3},
+		{desc: "word boundary", lo: 63, hi: 64, want: 2},
+		{desc: "gap", lo: 65, hi: 999, want: 0},
+		// Far more words than the set stores, so only the stored words are visited
+		{desc: "everything", lo: math.MinInt, hi: math.MaxInt, want: 10},
+		{desc: "wide with cut edges", lo: -1000, hi: math.MaxInt - 1, want: 8},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := s.CountRange(tC.lo, tC.hi); got != tC.want {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+}
+
+func TestComplement(t *testing.T) {
+	s := NewSet([]int{-100, -3, 0, 2, 64, 500})
+	testCases := []struct {
+		desc string
+		lo   int
+		hi   int
+	}{
+		{desc: "empty range", lo: 1, hi: 0},
+		{desc: "small", lo: 0, hi: 5},
+		{desc: "across zero", lo: -130, hi: 130},
+		{desc: "nothing in the set", lo: 1000, hi: 1100},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			universe := NewRange(tC.lo, tC.hi)
+			want := universe.Difference(s)
+
+			got := s.Complement(tC.lo, tC.hi)
+			if !got.Equals(want) {
+				t.Errorf("got %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFlipRange(t *testing.T) {
+	s := NewSet([]int{-100, -2, 0, 5, 70, 300})
+	s.FlipRange(-70, 80)
+
+	// Inside the range, the set becomes its own complement
+	want := NewSet([]int{-100, 300})
+	in_range := NewSet([]int{-2, 0, 5, 70})
+	complement := in_range.Complement(-70, 80)
+	want.UnionInPlace(complement)
+	if !s.Equals(want) {
+		t.Errorf("got %v; want %v", s, want)
+	}
+
+	// Flipping twice puts everything back
+	s.FlipRange(-70, 80)
+	original := NewSet([]int{-100, -2, 0, 5, 70, 300})
+	if !s.Equals(original) {
+		t.Errorf("got %v; want %v", s, original)
+	}
+}
@@ -0,0 +1,140 @@
+package bitset
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+)
+
+var (
+	// This error is returned by `UnmarshalRLE` when the input is not in the form written
+	// by `MarshalRLE`
+	ErrInvalidRLE = errors.New("bitset: invalid run-length encoding")
+	// This error is returned by `UnmarshalRLE` when the runs would need more than
+	// `MaxRLEWords` words
+	ErrRLETooLarge = errors.New("bitset: run-length encoding is too large")
+)
+
+// MaxRLEWords is the most 64 bit words `UnmarshalRLE` will fill, which is 128 MiB of
+// words by default. A few bytes of run-length encoding can describe a run of 2^62
+// numbers, so without a limit a hostile input could use up all of memory. Raise it
+// before decoding if you trust the input and expect larger sets.
+var MaxRLEWords = 1 << 24
+
+// Runs returns the items of the set as runs of consecutive numbers, in ascending order.
+// Each run is the first and last number in it, inclusive.
+func (s *Set) Runs() [][2]int {
+	keys := sorted_keys(s.data)
+
+	result := make([][2]int, 0)
+	for _, k := range keys {
+		w := s.data[k]
+		base := int(k) * 64
+		for w != 0 {
+			start := bits.TrailingZeros64(w)
+			// The number of ones in a row, starting at `start`
+			ones := bits.TrailingZeros64(^(w >> uint(start)))
+			lo, hi := base+start, base+start+ones-1
+
+			// Join runs that carry on from the previous word
+			if n := len(result); n > 0 && result[n-1][1] == lo-1 {
+				result[n-1][1] = hi
+			} else {
+				result = append(result, [2]int{lo, hi})
+			}
+
+			if start+ones == 64 {
+				break
+			}
+			w &^= 1<<uint(start+ones) - 1
+		}
+	}
+	return result
+}
+
+// MarshalRLE encodes the set as runs of consecutive numbers. This is far smaller than
+// the word encodings when the set is mostly long runs. The output is the number of runs
+// as a uvarint, then the first number of the first run as a zig-zag varint. Every run
+// after that is given as a uvarint of how many numbers were skipped since the previous
+// run, minus one. Each run then ends with a uvarint of its length, minus one.
+func (s *Set) MarshalRLE() ([]byte, error) {
+	runs := s.Runs()
+	result := binary.AppendUvarint(nil, uint64(len(runs)))
+	for idx, r := range runs {
+		if idx == 0 {
+			result = binary.AppendVarint(result, int64(r[0]))
+		} else {
+			result = binary.AppendUvarint(result, uint64(r[0]-runs[idx-1][1]-2))
+		}
+		result = binary.AppendUvarint(result, uint64(r[1]-r[0]))
+	}
+	return result, nil
+}
+
+// UnmarshalRLE replaces the contents of the set with the runs written by `MarshalRLE`.
+// Each run is written a whole word at a time. If the runs would fill more than
+// `MaxRLEWords` words, it returns ErrRLETooLarge. The set is left unchanged on error.
+func (s *Set) UnmarshalRLE(data []byte) error {
+	next_uvarint := func() (uint64, error) {
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return 0, ErrInvalidRLE
+		}
+		data = data[n:]
+		return v, nil
+	}
+
+	n, err := next_uvarint()
+	if err != nil {
+		return err
+	}
+
+	result := NewSet([]int{})
+	var prev_hi int64
+	words := uint64(0)
+	for i := uint64(0); i < n; i++ {
+		var lo int64
+		if i == 0 {
+			v, size := binary.Varint(data)
+			if size <= 0 {
+				return ErrInvalidRLE
+			}
+			data = data[size:]
+			lo = v
+		} else {
+			skip, err := next_uvarint()
+			if err != nil {
+				return err
+			}
+			if prev_hi > math.MaxInt64-2 || skip > uint64(math.MaxInt64-prev_hi-2) {
+				return ErrInvalidRLE
+			}
+			lo = prev_hi + 2 + int64(skip)
+		}
+
+		length, err := next_uvarint()
+		if err != nil {
+			return err
+		}
+		if length > uint64(math.MaxInt64-lo) {
+			return ErrInvalidRLE
+		}
+		hi := lo + int64(length)
+
+		// Runs are in ascending order and don't touch, so at most one word is shared
+		// with the previous run, and counting it twice only makes the limit stricter
+		words += uint64(hi>>6-lo>>6) + 1
+		if words > uint64(MaxRLEWords) {
+			return ErrRLETooLarge
+		}
+		result.AddRange(int(lo), int(hi))
+		prev_hi = hi
+	}
+	if len(data) != 0 {
+		return ErrInvalidRLE
+	}
+
+	*s = result
+	return nil
+}
@@ -0,0 +1,128 @@
+package bitset
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestRuns(t *testing.T) {
+	testCases := []struct {
+		desc string
+		data []int
+		want [][2]int
+	}{
+		{desc: "empty", data: []int{}, want: [][2]int{}},
+		{desc: "single", data: []int{5}, want: [][2]int{{5, 5}}},
+		{desc: "across words", data: []int{62, 63, 64, 65}, want: [][2]int{{62, 65}}},
+		{desc: "across zero", data: []int{-2, -1, 0, 1}, want: [][2]int{{-2, 1}}},
+		{
+			desc: "several",
+			data: []int{-100, 1, 2, 3, 10, 11},
+			want: [][2]int{{-100, -100}, {1, 3}, {10, 11}},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := NewSet(tC.data)
+			got := s.Runs()
+			if len(got) != len(tC.want) {
+				t.Fatalf("got %v; want %v", got, tC.want)
+			}
+			for idx := range got {
+				if got[idx] != tC.want[idx] {
+					t.Errorf("got %v; want %v", got, tC.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRLERoundTrip(t *testing.T) {
+	full_words := make([]int, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		full_words = append(full_words, i)
+	}
+
+	testCases := []struct {
+		desc string
+		data []int
+	}{
+		{desc: "empty", data: []int{}},
+		{desc: "negative", data: []int{-65, -64, -63, -1}},
+		{desc: "long run", data: full_words},
+		{desc: "scattered", data: []int{-1 << 40, 0, 2, 4, 1 << 40}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := NewSet(tC.data)
+			data, err := s.MarshalRLE()
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			var got Set
+			if err := got.UnmarshalRLE(data); err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if !got.Equals(s) {
+				t.Errorf("got %v; want %v", got, s)
+			}
+		})
+	}
+
+	// A single long run is only a few bytes
+	s := NewSet(full_words)
+	if data, _ := s.MarshalRLE(); len(data) > 4 {
+		t.Errorf("got %d bytes for one run, want at most 4", len(data))
+	}
+}
+
+func TestUnmarshalRLEErrors(t *testing.T) {
+	testCases := []struct {
+		desc string
+		data []byte
+	}{
+		{desc: "empty", data: []byte{}},
+		{desc: "missing run", data: []byte{1}},
+		{desc: "missing length", data: []byte{1, 2}},
+		{desc: "trailing bytes", data: []byte{1, 2, 0, 0}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := NewSet([]int{100})
+			if err := s.UnmarshalRLE(tC.data); err != ErrInvalidRLE {
+				t.Errorf("got error %v, want %v", err, ErrInvalidRLE)
+			}
+			if !s.Contains(100) {
+				t.Errorf("a failed UnmarshalRLE should leave the set alone, got %v", s)
+			}
+		})
+	}
+}
+
+func TestUnmarshalRLELargeRun(t *testing.T) {
+	// A run of ten million is written a word at a time, so decodes quickly
+	s := NewRange(-5, 10_000_000)
+	data, err := s.MarshalRLE()
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	var got Set
+	if err := got.UnmarshalRLE(data); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if !got.Equals(s) {
+		t.Errorf("got %d items; want %d", got.Len(), s.Len())
+	}
+
+	// One run of 2^62 numbers fits in a few bytes, but is refused rather than filled
+	hostile := binary.AppendUvarint(nil, 1)
+	hostile = binary.AppendVarint(hostile, 0)
+	hostile = binary.AppendUvarint(hostile, 1<<62)
+	got = NewSet([]int{100})
+	if err := got.UnmarshalRLE(hostile); err != ErrRLETooLarge {
+		t.Errorf("got error %v, want %v", err, ErrRLETooLarge)
+	}
+	if !got.Contains(100) {
+		t.Errorf("a failed UnmarshalRLE should leave the set alone, got %v", got)
+	}
+}
@@ -0,0 +1,83 @@
+package bitset
+
+import (
+	"io"
+	"math/bits"
+
+	"github.com/natemcintosh/set/internal/binfile"
+)
+
+var (
+	// This error is returned by `Load` when the input is not a saved bitset, or is cut
+	// short
+	ErrInvalidFile = binfile.ErrInvalidFile
+
+	// This error is returned by `Load` when the file was saved by a newer version
+	ErrUnsupportedVersion = binfile.ErrUnsupportedVersion
+
+	// This error is returned by `Load` when the file has been corrupted
+	ErrChecksum = binfile.ErrChecksum
+)
+
+// The file format version written by `Save`
+const save_version = 1
+
+var save_magic = [4]byte{'G', 'B', 'I', 'T'}
+
+// Save writes the set to `w` in a compact binary form that `Load` can read back. The
+// file starts with a magic number and format version, then the number of words, and
+// then each word index and word in ascending order, in the same layout as
+// `MarshalCBOR`. It ends with a checksum. Words are streamed through a buffer, so the
+// encoded set is never held in memory.
+func (s *Set) Save(w io.Writer) error {
+	keys := sorted_keys(s.data)
+
+	bw := binfile.NewWriter(w, save_magic, save_version)
+	bw.Uvarint(uint64(len(keys)))
+	for _, k := range keys {
+		bw.Varint(k)
+		bw.Uint64(s.data[k])
+	}
+	return bw.Close()
+}
+
+// Load replaces the contents of the set with a set written by `Save`. It returns an
+// error if the file is not a saved bitset or fails its checksum. The set is left
+// unchanged on error.
+func (s *Set) Load(r io.Reader) error {
+	br, err := binfile.NewReader(r, save_magic, save_version)
+	if err != nil {
+		return err
+	}
+	n, err := br.Uvarint()
+	if err != nil {
+		return err
+	}
+
+	result := NewSet([]int{})
+	for i := uint64(0); i < n; i++ {
+		k, err := br.Varint()
+		if err != nil {
+			return err
+		}
+		if err := check_word_index(k); err != nil {
+			return err
+		}
+		word, err := br.Uint64()
+		if err != nil {
+			return err
+		}
+
+		for word != 0 {
+			idx := bits.TrailingZeros64(word)
+			result.Add(int(k)*64 + idx)
+			word &= word - 1
+		}
+	}
+	if err := br.Close(); err != nil {
+		return err
+	}
+
+	*s = result
+	return nil
+}
@@ -0,0 +1,76 @@
+package bitset
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/natemcintosh/set/internal/binfile"
+)
+
+func TestSaveLoad(t *testing.T) {
+	testCases := []struct {
+		desc string
+		data []int
+	}{
+		{desc: "empty", data: []int{}},
+		{desc: "positive", data: []int{0, 1, 63, 64, 1000}},
+		{desc: "negative", data: []int{-1, -64, -65, -1000}},
+		{desc: "both", data: []int{-129, -1, 0, 5, 1 << 40}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := NewSet(tC.data)
+			var buf bytes.Buffer
+			if err := s.Save(&buf); err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			var got Set
+			if err := got.Load(&buf); err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if !got.Equals(s) {
+				t.Errorf("got %v; want %v", got, s)
+			}
+		})
+	}
+}
+
+func TestLoadChecksum(t *testing.T) {
+	s := NewSet([]int{1, 2, 3})
+	var buf bytes.Buffer
+	if err := s.Save(&buf); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	data := buf.Bytes()
+	data[len(data)-5] ^= 0x01
+
+	got := NewSet([]int{100})
+	if err := got.Load(bytes.NewReader(data)); !errors.Is(err, ErrChecksum) {
+		t.Errorf("got error %v, want %v", err, ErrChecksum)
+	}
+	if !got.Contains(100) {
+		t.Errorf("a failed Load should leave the set alone, got %v", got)
+	}
+}
+
+func TestLoadWordRange(t *testing.T) {
+	// A well formed file whose only word holds numbers past math.MaxInt
+	var buf bytes.Buffer
+	bw := binfile.NewWriter(&buf, save_magic, save_version)
+	bw.Uvarint(1)
+	bw.Varint(math.MaxInt>>6 + 1)
+	bw.Uint64(1)
+	if err := bw.Close(); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	got := NewSet([]int{100})
+	if err := got.Load(&buf); !errors.Is(err, ErrWordOutOfRange) {
+		t.Errorf("got error %v, want %v", err, ErrWordOutOfRange)
+	}
+	if !got.Contains(100) {
+		t.Errorf("a failed Load should leave the set alone, got %v", got)
+	}
+}
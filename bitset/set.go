@@ -6,15 +6,39 @@ package bitset
 import (
 	"errors"
 	"fmt"
+	"math"
 	"math/bits"
+	"strconv"
 	"strings"
+
+	"github.com/natemcintosh/set"
+	"golang.org/x/exp/slices"
 )
 
 var (
-	// This error is returned when you try to remove an item from a set that doesn't exist
-	ErrElementNotFound = errors.New("element not found")
+	// This error is returned when you try to remove an item from a set that doesn't
+	// exist. It is set.ErrElementNotFound, so errors.Is works the same for every set.
+	ErrElementNotFound = set.ErrElementNotFound
+	// This error is returned by the decoders when a word index is read whose numbers
+	// would not fit in an int
+	ErrWordOutOfRange = errors.New("bitset: word index out of range")
 )
 
+// NotFoundError is returned by `Remove` when the item isn't in the set. It matches
+// ErrElementNotFound with `errors.Is`, and `errors.As` gives back the missing item.
+type NotFoundError struct {
+	Item int
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("element not found: %d", e.Item)
+}
+
+// Unwrap returns ErrElementNotFound
+func (e *NotFoundError) Unwrap() error {
+	return ErrElementNotFound
+}
+
 // abs returns the absolute value of x.
 func abs(x int) int {
 	if x < 0 {
@@ -23,102 +47,122 @@ func abs(x int) int {
 	return x
 }
 
-type key struct {
-	is_positive bool
-	multiplier  uint64
-}
-
 type Set struct {
-	data map[key]uint64
+	// data maps a word index `k` to a word holding the numbers 64*k through 64*k + 63,
+	// where bit `i` is set if 64*k + i is in the set. Negative numbers use the same
+	// floor division, so -1 is bit 63 of word -1.
+	// Words with no bits set are deleted rather than stored.
+	data map[int64]uint64
+	// length is the number of items in the set, kept up to date by everything that
+	// changes `data`
+	length int
+	// universe is what `Not` takes the complement within. It is nil if none was set.
+	universe *universe
+	// pop_smallest makes Pop take the smallest item, rather than the quickest to find
+	pop_smallest bool
 }
 
 func NewSet[S ~[]int](data S) Set {
-	// Create the underlying set
-	uset := make(map[key]uint64)
+	result := Set{data: make(map[int64]uint64)}
+	result.AddSlice(data)
+	return result
+}
 
-	for _, v := range data {
-		// Get the new data representation
-		is_positive, multiplier, slot := number_to_bitset_representation(v)
+// NewSetWithCapacity will return a Set holding `data`, with room already made for the
+// words needed to hold numbers spread over `expected_range` consecutive integers. Use it
+// when building a large set a little at a time, to avoid growing the word map over and
+// over.
+func NewSetWithCapacity[S ~[]int](data S, expected_range int) Set {
+	words := expected_range/64 + 1
+	if expected_range < 0 {
+		words = 0
+	}
+	result := Set{data: make(map[int64]uint64, words)}
+	result.AddSlice(data)
+	return result
+}
 
-		// Create the key for the map
-		key := key{
-			is_positive: is_positive,
-			multiplier:  multiplier,
+// NewSetFromSorted will return a Set holding `data`, which should be in ascending order.
+// Sorted items fill one word before moving on to the next, so the words can be counted
+// up front to size the map exactly, and each word is written to the map only once.
+// Unsorted input still gives the right set, just more slowly.
+func NewSetFromSorted[S ~[]int](data S) Set {
+	words := 0
+	for idx, v := range data {
+		if idx == 0 || v>>6 != data[idx-1]>>6 {
+			words++
 		}
+	}
+	result := Set{data: make(map[int64]uint64, words)}
+	result.AddSlice(data)
+	return result
+}
 
-		// Union if it already exists, else just add it
-		if bits, ok := uset[key]; ok {
-			uset[key] = bits | slot
-		} else {
-			uset[key] = slot
+// AddSlice adds every item of `data`. Items that land in the same word one after
+// another are gathered up first, so clustered or sorted input only touches the map once
+// per word rather than once per item.
+func (s *Set) AddSlice(data []int) {
+	if s.data == nil {
+		s.data = make(map[int64]uint64)
+	}
+	if len(data) == 0 {
+		return
+	}
+
+	var pending int64
+	var pending_bits uint64
+	for idx, v := range data {
+		k, slot := word_of(v)
+
+		if idx > 0 && k != pending {
+			s.set_word(pending, s.data[pending]|pending_bits)
+			pending_bits = 0
 		}
+		pending = k
+		pending_bits |= slot
 	}
+	s.set_word(pending, s.data[pending]|pending_bits)
+}
 
-	return Set{data: uset}
+// set_word replaces word `k` with `w`, keeping the length up to date. If `w` is empty,
+// the word is deleted instead, so the map never holds words with no bits set.
+func (s *Set) set_word(k int64, w uint64) {
+	s.length += bits.OnesCount64(w) - bits.OnesCount64(s.data[k])
+	if w == 0 {
+		delete(s.data, k)
+	} else {
+		s.data[k] = w
+	}
 }
 
-// number_to_bitset_representation will take an int and return the following
+// word_of will take an int and return the following
 //
-// - `is_positive`: true if n >= 0
+// - `k`: the index of the word holding n, rounding down: floor(n / 64)
 //
-// - `multiplier`: how many times 64 goes into n: abs(n) / 64
-//
-// - `slot`: using an uint64 to represent the 64 bins for the remainder: abs(n) % 64
-func number_to_bitset_representation(n int) (
-	is_positive bool,
-	multiplier uint64,
-	slot uint64,
-) {
-	if n >= 0 {
-		is_positive = true
-		multiplier = uint64(n) / 64
-		if n%64 == 0 {
-			slot = uint64(1)
-		} else {
-			slot = two_to_power_n_minus_1(n % 64)
-		}
-	} else {
-		is_positive = false
-		multiplier = uint64(-n) / 64
-		if -n%64 == 0 {
-			slot = 1
-		} else {
-			slot = two_to_power_n_minus_1(-n % 64)
-		}
-	}
-	return
+// - `slot`: the bit for n within that word: 1 << (n mod 64)
+func word_of(n int) (k int64, slot uint64) {
+	return int64(n >> 6), uint64(1) << uint(n&63)
 }
 
-func two_to_power_n_minus_1(n int) uint64 {
-	return 1 << uint64(n)
+// check_word_index returns ErrWordOutOfRange if word `k` holds numbers outside the range
+// of an int. Word indexes from a decoder must be checked before they are stored, as
+// `int(k)*64` would otherwise wrap around silently.
+func check_word_index(k int64) error {
+	if k < math.MinInt>>6 || k > math.MaxInt>>6 {
+		return ErrWordOutOfRange
+	}
+	return nil
 }
 
+// String prints the items in ascending order, such as "{-1, 3, 64}"
 func (u Set) String() string {
 	var b strings.Builder
 	b.WriteRune('{')
-	upper_idx := -1
-	for key, bits := range u.data {
-		upper_idx += 1
-		// For each bit in `bits`, want to extract the index of the bit if it is 1
-		// and then add it to the string
-		m := 64 * key.multiplier
-		vals := slots_from_uint64(bits)
-		for idx, v := range vals {
-			val := m + uint64(v)
-			if key.is_positive {
-				if (idx+1)+(upper_idx+1) != len(vals)+len(u.data) {
-					b.WriteString(fmt.Sprintf("%d, ", val))
-				} else {
-					b.WriteString(fmt.Sprintf("%d", val))
-				}
-			} else {
-				if (idx+1)+(upper_idx+1) != len(vals)+len(u.data) {
-					b.WriteString(fmt.Sprintf("-%d, ", val))
-				} else {
-					b.WriteString(fmt.Sprintf("-%d", val))
-				}
-			}
+	for idx, v := range u.Slice() {
+		if idx > 0 {
+			b.WriteString(", ")
 		}
+		b.WriteString(strconv.Itoa(v))
 	}
 	b.WriteRune('}')
 	return b.String()
@@ -126,7 +170,7 @@ func (u Set) String() string {
 
 func slots_from_uint64(u uint64) []int {
 	if u == 0 {
-		return []int{0}
+		return []int{}
 	}
 	var idx int
 	result := make([]int, 0, bits.OnesCount64(u))
@@ -139,25 +183,49 @@ func slots_from_uint64(u uint64) []int {
 	return result
 }
 
-// Slice will return all the items in the set as a slice. They are not guaranteed in any
-// particular order.
+// Slice will return all the items in the set as a slice, in ascending order
 func (s *Set) Slice() []int {
-	result := make([]int, 0)
+	return s.AppendTo(make([]int, 0, s.Len()))
+}
+
+// AppendTo appends the items of the set to `dst` in ascending order, and returns the
+// extended slice. Passing the same buffer, resliced to zero length, on every call avoids
+// allocating a new slice of items each time; only the word indexes are sorted in a
+// fresh slice.
+func (s *Set) AppendTo(dst []int) []int {
+	dst = slices.Grow(dst, s.length)
+	s.ForEach(func(item int) bool {
+		dst = append(dst, item)
+		return true
+	})
+	return dst
+}
+
+// ForEach calls `f` on every item in the set, in ascending order. It stops early if `f`
+// returns false. The words are visited in numeric order, so only the word indexes are
+// sorted, and the items are never gathered into a slice.
+func (s *Set) ForEach(f func(item int) bool) {
+	for _, k := range sorted_keys(s.data) {
+		base := int(k) * 64
+		for w := s.data[k]; w != 0; w &= w - 1 {
+			if !f(base + bits.TrailingZeros64(w)) {
+				return
+			}
+		}
+	}
+}
+
+// Iterate calls `f` on every item in the set, in no particular order. It stops early if
+// `f` returns false.
+func (s *Set) Iterate(f func(item int) bool) {
 	for key, bits := range s.data {
-		// For each bit in `bits`, want to extract the index of the bit if it is 1
-		// and then add it to the string
-		m := 64 * int(key.multiplier)
-		vals := slots_from_uint64(bits)
-		for _, v := range vals {
-			val := m + v
-			if key.is_positive {
-				result = append(result, val)
-			} else {
-				result = append(result, -val)
+		m := 64 * int(key)
+		for _, v := range slots_from_uint64(bits) {
+			if !f(m + v) {
+				return
 			}
 		}
 	}
-	return result
 }
 
 // Contains will return true if the set contains the item. If the set is empty, returns
@@ -168,9 +236,7 @@ func (s *Set) Contains(item int) bool {
 	}
 
 	// Get the new data representation
-	is_positive, multiplier, slot := number_to_bitset_representation(item)
-
-	key := key{is_positive: is_positive, multiplier: multiplier}
+	key, slot := word_of(item)
 
 	if bits, ok := s.data[key]; ok {
 		if bits&slot != 0 {
@@ -182,60 +248,116 @@ func (s *Set) Contains(item int) bool {
 
 }
 
-// Len returns the length of the Set
-func (s *Set) Len() int {
-	res := 0
-	for _, v := range s.data {
-		res += bits.OnesCount64(v)
+// ContainsAllOf will return true if every item of `items` is in the set. Items that land
+// in the same word one after another share a single map lookup, so clustered or sorted
+// queries are much cheaper than calling `Contains` on each. It stops at the first item
+// that is missing.
+func (s *Set) ContainsAllOf(items []int) bool {
+	var last_key int64
+	var last_word uint64
+	for idx, v := range items {
+		key, slot := word_of(v)
+		if idx == 0 || key != last_key {
+			last_key, last_word = key, s.data[key]
+		}
+		if last_word&slot == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsMask will return a slice the same length as `items`, holding true at each
+// index where the item is in the set. As with `ContainsAllOf`, items in the same word
+// one after another share a single map lookup.
+func (s *Set) ContainsMask(items []int) []bool {
+	result := make([]bool, len(items))
+	var last_key int64
+	var last_word uint64
+	for idx, v := range items {
+		key, slot := word_of(v)
+		if idx == 0 || key != last_key {
+			last_key, last_word = key, s.data[key]
+		}
+		result[idx] = last_word&slot != 0
 	}
-	return res
+	return result
+}
+
+// Len returns the length of the Set. The count is kept up to date as the set changes, so
+// nothing needs to be counted here.
+func (s *Set) Len() int {
+	return s.length
 }
 
 // IsEmpty returns true if the set is empty
 func (s *Set) IsEmpty() bool {
-	return s.Len() == 0
+	return s.length == 0
 }
 
 // Add will add a new item to `s`. If it already exists, it is ignored
 func (s *Set) Add(item int) {
 	// Get the new data representation
-	is_positive, multiplier, slot := number_to_bitset_representation(item)
-
-	key := key{is_positive: is_positive, multiplier: multiplier}
+	key, slot := word_of(item)
 
-	// Union if it already exists, else just add it
-	if bits, ok := s.data[key]; ok {
+	// Only count it if it wasn't there already
+	if bits := s.data[key]; bits&slot == 0 {
 		s.data[key] = bits | slot
-	} else {
-		s.data[key] = slot
+		s.length++
 	}
 }
 
-// Remove removes an item from the set. Returns an error if the item doesn't exist
+// Remove removes an item from the set. Returns a *NotFoundError if the item doesn't
+// exist
 func (s *Set) Remove(item int) error {
 	if len(s.data) == 0 {
-		return ErrElementNotFound
+		return &NotFoundError{Item: item}
 	}
 
 	// Get the new data representation
-	is_positive, multiplier, slot := number_to_bitset_representation(item)
-
-	key := key{is_positive: is_positive, multiplier: multiplier}
+	key, slot := word_of(item)
 
 	if bits, ok := s.data[key]; !ok {
 		// This uint64 doesn't exist in the map
-		return ErrElementNotFound
+		return &NotFoundError{Item: item}
 	} else {
 		if bits&slot == 0 {
 			// Was not found in this uint64
-			return ErrElementNotFound
+			return &NotFoundError{Item: item}
 		}
 		// Remove the element
-		s.data[key] = bits ^ slot
+		s.set_word(key, bits^slot)
 	}
 	return nil
 }
 
+// Flip adds `item` if it is not in the set, or removes it if it is, and returns true if
+// it is in the set afterwards. It is a single XOR on the word holding the item.
+func (s *Set) Flip(item int) bool {
+	if s.data == nil {
+		s.data = make(map[int64]uint64)
+	}
+	key, slot := word_of(item)
+
+	w := s.data[key] ^ slot
+	s.set_word(key, w)
+	return w&slot != 0
+}
+
+// TryRemove removes an item from the set, and returns true if it was there. Use it
+// instead of `Remove` when a missing item is a normal condition, as it doesn't need to
+// build an error.
+func (s *Set) TryRemove(item int) bool {
+	key, slot := word_of(item)
+
+	bits, ok := s.data[key]
+	if !ok || bits&slot == 0 {
+		return false
+	}
+	s.set_word(key, bits&^slot)
+	return true
+}
+
 // Discard removes an item from the set. If it doesn't exist, it is ignored
 func (s *Set) Discard(item int) {
 	if len(s.data) == 0 {
@@ -243,85 +365,93 @@ func (s *Set) Discard(item int) {
 	}
 
 	// Get the new data representation
-	is_positive, multiplier, slot := number_to_bitset_representation(item)
+	key, slot := word_of(item)
 
-	key := key{is_positive: is_positive, multiplier: multiplier}
-
-	if bits, ok := s.data[key]; !ok {
-		// This uint64 doesn't exist in the map
+	if bits, ok := s.data[key]; !ok || bits&slot == 0 {
+		// This uint64 doesn't exist in the map, or doesn't hold the item
 		return
 	} else {
 		// Remove the element
-		s.data[key] = bits ^ slot
+		s.set_word(key, bits&^slot)
 	}
 	return
 }
 
 // Pop will remove and return an arbitrary item from the set. If the set is empty,
-// it will return an error
+// it will return an error. Which item is arbitrary, and changes from run to run, unless
+// `SetPopSmallest` has been called.
 func (s *Set) Pop() (item int, err error) {
 	if s.IsEmpty() {
 		return item, ErrElementNotFound
 	}
 
-	var to_return int
-	// Iterate to the first item
+	if s.pop_smallest {
+		item, _ = s.Min()
+		s.Discard(item)
+		return item, nil
+	}
+
+	// Every word has at least one bit set, so take the first one
 	for key, slots := range s.data {
-		to_return = bits.TrailingZeros64(slots)
+		idx := bits.TrailingZeros64(slots)
 		// Erase that bit
-		s.data[key] &= ^(1 << uint(to_return))
+		s.set_word(key, slots&^(1<<uint(idx)))
+
+		item = 64*int(key) + idx
 		break
 	}
 
-	return to_return, nil
+	return item, nil
 
 }
 
+// NewSetPopSmallest will return a Set holding `data`, whose Pop always takes the
+// smallest item
+func NewSetPopSmallest[S ~[]int](data S) Set {
+	result := NewSet(data)
+	result.pop_smallest = true
+	return result
+}
+
+// SetPopSmallest makes Pop always take the smallest item when `on` is true, so popping
+// is the same on every run. Each Pop then scans the word indexes for the smallest, which
+// is O(words). The setting is kept by `Copy`.
+func (s *Set) SetPopSmallest(on bool) {
+	s.pop_smallest = on
+}
+
 // Clear will remove all items from the set
 func (s *Set) Clear() {
-	s.data = make(map[key]uint64)
+	s.data = make(map[int64]uint64)
+	s.length = 0
 }
 
 // Copy makes a deep copy as quickly as possible
 func (s *Set) Copy() Set {
 	// Make sure to allocate the same size
-	copy := make(map[key]uint64, len(s.data))
+	copy := make(map[int64]uint64, len(s.data))
 
 	// Fill it up
 	for key, slots := range s.data {
 		copy[key] = slots
 	}
 
-	return Set{data: copy}
+	return Set{data: copy, length: s.length, universe: s.universe, pop_smallest: s.pop_smallest}
 }
 
-// Equals will return true if `s` and `t` are
-// - the same length
-// - contain the same elements
+// Equals will return true if `s` and `t` contain the same elements. Empty words are
+// never stored, so sets with different lengths or numbers of words can't be equal, and
+// otherwise whole words are compared until one differs.
 func (s *Set) Equals(t Set) bool {
-	if len(s.data) != len(t.data) {
-		return false
-	}
-
-	if s.Len() != t.Len() {
+	if s.length != t.length || len(s.data) != len(t.data) {
 		return false
 	}
 
-	for skey, sbits := range s.data {
-		if tbits, ok := t.data[skey]; !ok {
-			return false
-		} else if sbits != tbits {
-			return false
-		}
-	}
-
-	// We've checked that all keys in `s` are in `t`, but not the other way around
-	for tkey := range t.data {
-		if _, ok := s.data[tkey]; !ok {
+	for skey, sslots := range s.data {
+		if t.data[skey] != sslots {
 			return false
 		}
 	}
-
 	return true
 }
 
@@ -337,26 +467,17 @@ func (s *Set) Union(t Set) Set {
 		result = s.Copy()
 	} else {
 		result = t.Copy()
+		result.universe, result.pop_smallest = s.universe, s.pop_smallest
 	}
 
 	// Iterate over the smaller set, and add all it's items to `result`
 	if s_is_larger {
 		for tkey, tslots := range t.data {
-			// Get the key from s (if it exists)
-			if sslots, ok := result.data[tkey]; ok {
-				result.data[tkey] = sslots | tslots
-			} else {
-				result.data[tkey] = tslots
-			}
+			result.set_word(tkey, result.data[tkey]|tslots)
 		}
 	} else {
 		for skey, sslots := range s.data {
-			// Get the key from t (if it exists)
-			if tslots, ok := result.data[skey]; ok {
-				result.data[skey] = sslots | tslots
-			} else {
-				result.data[skey] = sslots
-			}
+			result.set_word(skey, result.data[skey]|sslots)
 		}
 	}
 
@@ -366,19 +487,71 @@ func (s *Set) Union(t Set) Set {
 // UnionInPlace will add all the items in set `t` to set `s`
 func (s *Set) UnionInPlace(t Set) {
 	for tkey, tslots := range t.data {
-		// Get the key from s (if it exists)
-		if sslots, ok := s.data[tkey]; ok {
-			s.data[tkey] = sslots | tslots
-		} else {
-			s.data[tkey] = tslots
+		s.set_word(tkey, s.data[tkey]|tslots)
+	}
+}
+
+// UnionOf will create a new Set holding every item of every one of `sets`. Each word is
+// ORed straight into the result, so unlike folding with `Union`, no intermediate sets are
+// built, and the items are only counted once at the end. The result takes the universe
+// and pop mode of the first set.
+func UnionOf(sets ...Set) Set {
+	largest := 0
+	for _, t := range sets {
+		largest = max(largest, len(t.data))
+	}
+
+	data := make(map[int64]uint64, largest)
+	for _, t := range sets {
+		for k, w := range t.data {
+			data[k] |= w
 		}
 	}
+
+	length := 0
+	for _, w := range data {
+		length += bits.OnesCount64(w)
+	}
+	result := Set{data: data, length: length}
+	if len(sets) > 0 {
+		result.universe, result.pop_smallest = sets[0].universe, sets[0].pop_smallest
+	}
+	return result
+}
+
+// IntersectionOf will create a new Set holding the items found in all of `sets`. Only
+// the words of the set with the fewest are visited, each ANDed with the same word of
+// every other set, stopping as soon as it is zero. With no sets, the result is empty.
+// The result takes the universe and pop mode of the first set.
+func IntersectionOf(sets ...Set) Set {
+	result := NewSet([]int{})
+	if len(sets) == 0 {
+		return result
+	}
+	result.universe, result.pop_smallest = sets[0].universe, sets[0].pop_smallest
+
+	smallest := 0
+	for i, t := range sets {
+		if len(t.data) < len(sets[smallest].data) {
+			smallest = i
+		}
+	}
+	for k, w := range sets[smallest].data {
+		for i := 0; i < len(sets) && w != 0; i++ {
+			if i != smallest {
+				w &= sets[i].data[k]
+			}
+		}
+		result.set_word(k, w)
+	}
+	return result
 }
 
 // Intersection will create a new Set, and fill it with the intersection of `s` and `t`
 func (s *Set) Intersection(t Set) Set {
 	// Create an empty set result
-	data := make(map[key]uint64)
+	data := make(map[int64]uint64)
+	length := 0
 
 	// Iterate over the smaller of the two sets, and add the item to `result` if it is
 	// in the larger of the two sets
@@ -388,6 +561,7 @@ func (s *Set) Intersection(t Set) Set {
 			if tslots, ok := t.data[skey]; ok {
 				if (sslots & tslots) != 0 {
 					data[skey] = sslots & tslots
+					length += bits.OnesCount64(sslots & tslots)
 				}
 			}
 		}
@@ -397,146 +571,78 @@ func (s *Set) Intersection(t Set) Set {
 			if sslots, ok := s.data[tkey]; ok {
 				if (sslots & tslots) != 0 {
 					data[tkey] = sslots & tslots
+					length += bits.OnesCount64(sslots & tslots)
 				}
 			}
 		}
 	}
 
-	return Set{data: data}
+	return Set{data: data, length: length, universe: s.universe, pop_smallest: s.pop_smallest}
 }
 
 // IntersectionInPlace will remove any items from `s` that are not in `t`
 func (s *Set) IntersectionInPlace(t Set) {
-	// For each key in `s`, check if it is in `t`
-	// If it is, perform the intersection, else remove it
-	// If the intersection is 0, remove the key
+	// Keep only the bits of each word in `s` that are also in `t`. Words that end up
+	// empty, including those `t` doesn't have at all, are dropped.
 	for skey, sslots := range s.data {
-		// Get the key from t (if it exists)
-		if tslots, ok := t.data[skey]; ok {
-			if (sslots & tslots) == 0 {
-				delete(s.data, skey)
-			} else {
-				s.data[skey] = sslots & tslots
-			}
-		} else {
-			// The key does not exist in `t`, so remove it from `s`
-			delete(s.data, skey)
+		s.set_word(skey, sslots&t.data[skey])
+	}
+}
+
+// Intersects returns true if `s` and `t` have at least one element in common. The
+// smaller of the two word maps is walked, and it returns as soon as a word shares a bit
+// with the same word in the other set, without building the intersection.
+func (s *Set) Intersects(t Set) bool {
+	small, large := s.data, t.data
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	for key, slots := range small {
+		if slots&large[key] != 0 {
+			return true
 		}
 	}
+	return false
 }
 
 // IsDisjoint will return true if the set has no elements in common with `t`. Sets are
 // disjoint if and only if their intersection is the empty set
 func (s *Set) IsDisjoint(t Set) bool {
-	// Iterate over the smaller of the two sets. If we find an item in one that is in
-	// the other, return false
-	if len(s.data) < len(t.data) {
-		for skey, sslots := range s.data {
-			// Get the key from t (if it exists)
-			if tslots, ok := t.data[skey]; ok {
-				if (sslots & tslots) != 0 {
-					return false
-				}
-			}
-		}
-	} else {
-		for tkey, tslots := range t.data {
-			// Get the key from s (if it exists)
-			if sslots, ok := s.data[tkey]; ok {
-				if (sslots & tslots) != 0 {
-					return false
-				}
-			}
-
-		}
-	}
-	return true
+	return !s.Intersects(t)
 }
 
-// IsSubsetOf tests whether every element in `s` is in `t`
+// IsSubsetOf tests whether every element in `s` is in `t`. It stops at the first word of
+// `s` with a bit that isn't set in `t`.
 func (s *Set) IsSubsetOf(t Set) bool {
-	// Iterate over `s`. If we find an item in `s` that is not in `t`, return false
-	for skey, sslots := range s.data {
-		// Get the key from t (if it exists)
-		if tslots, ok := t.data[skey]; ok {
-			if (sslots & tslots) != sslots {
-				return false
-			}
-		} else {
-			// The key does not exist in `t`, so return false
-			return false
-		}
-	}
-	return true
+	return !s.has_bits_outside(t)
 }
 
 // IsProperSubsetOf tests whether every element in `s` is in `t`, but that
 // `s.Equals(t) == false`
 func (s *Set) IsProperSubsetOf(t Set) bool {
-
-	// Iterate over `s`. If we find an item in `s` that is not in `t`, return false
-	for skey, sslots := range s.data {
-		// Get the key from t (if it exists)
-		if tslots, ok := t.data[skey]; ok {
-			if (sslots & tslots) != sslots {
-				return false
-			}
-		} else {
-			// The key does not exist in `t`, so return false
-			return false
-		}
-	}
-
-	// If the lengths are equal, we have just verified that the two sets are equal.
-	if s.Len() == t.Len() {
-		return false
-	} else {
-		return true
-	}
-
+	return s.IsSubsetOf(t) && t.has_bits_outside(*s)
 }
 
 // IsSuperSetOf tests whether every element in `t` is in `s`
 func (s *Set) IsSuperSetOf(t Set) bool {
-	// Iterate over `t`. If we find an item in `t` that is not in `s`, return false
-	for tkey, tslots := range t.data {
-		// Get the key from s (if it exists)
-		if sslots, ok := s.data[tkey]; ok {
-			if (sslots & tslots) != tslots {
-				return false
-			}
-		} else {
-			// The key does not exist in `s`, so return false
-			return false
-		}
-	}
-	return true
+	return t.IsSubsetOf(*s)
 }
 
 // IsProperSuperSetOf tests whether every element in `t` is in `s`, but that
 // `s.Equals(t) == false`
 func (s *Set) IsProperSuperSetOf(t Set) bool {
+	return t.IsSubsetOf(*s) && s.has_bits_outside(t)
+}
 
-	// Iterate over `t`. If we find an item in `t` that is not in `s`, return false
-	for tkey, tslots := range t.data {
-		// Get the key from s (if it exists)
-		if sslots, ok := s.data[tkey]; ok {
-			if (sslots & tslots) != tslots {
-				return false
-			}
-		} else {
-			// The key does not exist in `s`, so return false
-			return false
+// has_bits_outside returns true if `s` has an element that is not in `t`, checking
+// `s &^ t` a word at a time
+func (s *Set) has_bits_outside(t Set) bool {
+	for skey, sslots := range s.data {
+		if sslots&^t.data[skey] != 0 {
+			return true
 		}
 	}
-
-	// If the lengths are equal, we have just verified that the two sets are equal.
-	if s.Len() == t.Len() {
-		return false
-	} else {
-		return true
-	}
-
+	return false
 }
 
 // Difference returns a new set with elements in `s` that are not in `t`
@@ -546,14 +652,8 @@ func (s *Set) Difference(t Set) Set {
 
 	// Iterate over `t`. If we find an item in `result`, remove it from `result`
 	for tkey, tslots := range t.data {
-		// Get the key from result (if it exists)
 		if sslots, ok := result.data[tkey]; ok {
-			// Make sslots the intersection of sslots and tslots
-			if sslots^tslots == 0 {
-				delete(result.data, tkey)
-			} else {
-				result.data[tkey] = sslots &^ tslots
-			}
+			result.set_word(tkey, sslots&^tslots)
 		}
 	}
 
@@ -564,22 +664,52 @@ func (s *Set) Difference(t Set) Set {
 func (s *Set) DifferenceInPlace(t Set) {
 	// Iterate over `t`. If we find an item in `s`, remove it from `s`
 	for tkey, tslots := range t.data {
-		// Get the key from s (if it exists)
 		if sslots, ok := s.data[tkey]; ok {
-			// Make sslots the intersection of sslots and tslots
-			if sslots^tslots == 0 {
-				delete(s.data, tkey)
-			} else {
-				s.data[tkey] = sslots &^ tslots
+			s.set_word(tkey, sslots&^tslots)
+		}
+	}
+}
+
+// DifferenceWithSlice returns a new set with elements in `s` that are not in `items`,
+// without making a Set out of `items` first
+func (s *Set) DifferenceWithSlice(items []int) Set {
+	result := s.Copy()
+	result.DifferenceInPlaceWithSlice(items)
+	return result
+}
+
+// DifferenceInPlaceWithSlice removes any elements in `s` that are in `items`. As with
+// `AddSlice`, items that land in the same word one after another are gathered up and
+// cleared from the word together.
+func (s *Set) DifferenceInPlaceWithSlice(items []int) {
+	if len(items) == 0 {
+		return
+	}
+
+	var pending int64
+	var pending_bits uint64
+	for idx, v := range items {
+		k, slot := word_of(v)
+
+		if idx > 0 && k != pending {
+			if w, ok := s.data[pending]; ok {
+				s.set_word(pending, w&^pending_bits)
 			}
+			pending_bits = 0
 		}
+		pending = k
+		pending_bits |= slot
+	}
+	if w, ok := s.data[pending]; ok {
+		s.set_word(pending, w&^pending_bits)
 	}
 }
 
 // SymmetricDifference returns a new set with elements in either `s` or `t`, but not both
 func (s *Set) SymmetricDifference(t Set) Set {
 	// Make an empty set to populate
-	data := make(map[key]uint64)
+	data := make(map[int64]uint64)
+	length := 0
 
 	// Iterate over `s`, and add the item if it does not exist in `t`
 	for skey, sslots := range s.data {
@@ -590,10 +720,12 @@ func (s *Set) SymmetricDifference(t Set) Set {
 				continue
 			} else {
 				data[skey] = sslots ^ tslots
+				length += bits.OnesCount64(sslots ^ tslots)
 			}
 		} else {
 			// The key does not exist in `t`, so add it to the result
 			data[skey] = sslots
+			length += bits.OnesCount64(sslots)
 		}
 	}
 
@@ -610,29 +742,105 @@ func (s *Set) SymmetricDifference(t Set) Set {
 		} else {
 			// The key does not exist in `s`, so add it to the result
 			data[tkey] = tslots
+			length += bits.OnesCount64(tslots)
 		}
 	}
 
-	return Set{data: data}
+	return Set{data: data, length: length, universe: s.universe, pop_smallest: s.pop_smallest}
 }
 
 // SymmerticDifferenceInPlace removes any elements in `s` that are in `t`, and adds any
 // elements in `t` that are not in `s`
 func (s *Set) SymmetricDifferenceInPlace(t Set) {
-	// Iterate over `t`. If we find an item in `s`, remove it from `s`, otherwise add it
+	// Iterate over `t`. Items in both are removed from `s`, and the rest are added
 	for tkey, tslots := range t.data {
-		// Get the key from s (if it exists)
-		if sslots, ok := s.data[tkey]; ok {
-			// Make sslots the intersection of sslots and tslots
-			if sslots^tslots == 0 {
-				delete(s.data, tkey)
-			} else {
-				s.data[tkey] = sslots ^ tslots
-			}
-		} else {
-			// The key does not exist in `s`, so add it to the result
-			s.data[tkey] = tslots
+		s.set_word(tkey, s.data[tkey]^tslots)
+	}
+}
+
+// IntersectionLen returns the number of items in both `s` and `t`, without building the
+// intersection. Only the smaller of the two word maps is walked.
+func (s *Set) IntersectionLen(t Set) int {
+	small, large := s.data, t.data
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	result := 0
+	for key, slots := range small {
+		result += bits.OnesCount64(slots & large[key])
+	}
+	return result
+}
+
+// UnionLen returns the number of items in either `s` or `t`, without building the union
+func (s *Set) UnionLen(t Set) int {
+	return s.Len() + t.Len() - s.IntersectionLen(t)
+}
+
+// DifferenceLen returns the number of items in `s` that are not in `t`, without building
+// the difference
+func (s *Set) DifferenceLen(t Set) int {
+	result := 0
+	for key, slots := range s.data {
+		result += bits.OnesCount64(slots &^ t.data[key])
+	}
+	return result
+}
+
+// Of will return a Set holding the items passed in
+func Of(items ...int) Set {
+	return NewSet(items)
+}
+
+// GoString formats the set as Go code that rebuilds it, such as `bitset.Of(1, 2, 3)`,
+// so `%#v` output from a failing test can be pasted straight into a new test case. The
+// items are in ascending order.
+func (s Set) GoString() string {
+	items := s.Slice()
+
+	var b strings.Builder
+	b.WriteString("bitset.Of(")
+	for idx, v := range items {
+		if idx > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(strconv.Itoa(v))
+	}
+	b.WriteString(")")
+
+	return b.String()
+}
+
+// Min returns the smallest item in the set. The bool is false if the set is empty. Only
+// the words are scanned, so nothing is allocated.
+func (s *Set) Min() (int, bool) {
+	found := false
+	var result int
+	for key, w := range s.data {
+		if w == 0 {
+			continue
+		}
+		if v := 64*int(key) + bits.TrailingZeros64(w); !found || v < result {
+			result = v
+			found = true
 		}
 	}
+	return result, found
+}
 
+// Max returns the largest item in the set. The bool is false if the set is empty. Only
+// the words are scanned, so nothing is allocated.
+func (s *Set) Max() (int, bool) {
+	found := false
+	var result int
+	for key, w := range s.data {
+		if w == 0 {
+			continue
+		}
+		if v := 64*int(key) + 63 - bits.LeadingZeros64(w); !found || v > result {
+			result = v
+			found = true
+		}
+	}
+	return result, found
 }
@@ -1,7 +1,9 @@
 package bitset
 
 import (
+	"errors"
 	"fmt"
+	"math/bits"
 	"math/rand"
 	"strings"
 	"testing"
@@ -60,6 +62,11 @@ func TestSlots_from_uint64(t *testing.T) {
 		in   uint64
 		want []int
 	}{
+		{
+			desc: "empty",
+			in:   0,
+			want: []int{},
+		},
 		{
 			desc: "0",
 			in:   1,
@@ -608,7 +615,7 @@ func TestRemove(t *testing.T) {
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
 			err := tC.s.Remove(tC.v)
-			if err != tC.want_err_value {
+			if !errors.Is(err, tC.want_err_value) {
 				t.Errorf("got error %v, want %v", err, tC.want_err_value)
 			}
 			if !tC.s.Equals(tC.want_set) {
@@ -746,6 +753,43 @@ func BenchmarkDiscard(b *testing.B) {
 	}
 }
 
+func TestDiscard(t *testing.T) {
+	testCases := []struct {
+		desc string
+		s    Set
+		v    int
+		want Set
+	}{
+		{
+			desc: "item in the set",
+			s:    NewSet([]int{1, 2, 3}),
+			v:    2,
+			want: NewSet([]int{1, 3}),
+		},
+		{
+			// Discard used to flip the bit, which added the item
+			desc: "missing item in a stored word",
+			s:    NewSet([]int{1, 3}),
+			v:    2,
+			want: NewSet([]int{1, 3}),
+		},
+		{
+			desc: "missing item in a missing word",
+			s:    NewSet([]int{1, 3}),
+			v:    1000,
+			want: NewSet([]int{1, 3}),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			tC.s.Discard(tC.v)
+			if !tC.s.Equals(tC.want) || tC.s.Contains(tC.v) {
+				t.Errorf("got %v, want %v", tC.s, tC.want)
+			}
+		})
+	}
+}
+
 func TestPop(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -757,6 +801,11 @@ func TestPop(t *testing.T) {
 			s:        NewSet([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}),
 			want_err: nil,
 		},
+		{
+			desc:     "pop outside the first word",
+			s:        NewSet([]int{-200, 1000}),
+			want_err: nil,
+		},
 		{
 			desc:     "invalid pop",
 			s:        NewSet([]int{}),
@@ -765,11 +814,16 @@ func TestPop(t *testing.T) {
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
+			original := tC.s.Copy()
 			original_len := tC.s.Len()
-			_, err := tC.s.Pop()
+			item, err := tC.s.Pop()
 			if err != tC.want_err {
 				t.Errorf("got error %v, want %v", err, tC.want_err)
 			}
+			// The popped item should have been in the set, and not be in it anymore
+			if err == nil && (!original.Contains(item) || tC.s.Contains(item)) {
+				t.Errorf("popped %d, which was not taken from %v", item, original)
+			}
 			// if the error is nil, check that the length is one less than before
 			if err == nil && tC.s.Len() != original_len-1 {
 				t.Errorf("got %v, want %v", tC.s.Len(), original_len-1)
@@ -778,6 +832,44 @@ func TestPop(t *testing.T) {
 	}
 }
 
+func TestPopSmallest(t *testing.T) {
+	want := []int{-1000, -1, 0, 63, 64, 5000}
+	s := NewSetPopSmallest([]int{64, 5000, -1, 0, -1000, 63})
+	c := s.Copy()
+	for _, set := range []*Set{&s, &c} {
+		got := []int{}
+		for !set.IsEmpty() {
+			v, err := set.Pop()
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			got = append(got, v)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v; want %v", got, want)
+		}
+	}
+
+	// It can be turned off again
+	s = NewSet([]int{1, 2})
+	s.SetPopSmallest(true)
+	s.SetPopSmallest(false)
+	if _, err := s.Pop(); err != nil || s.Len() != 1 {
+		t.Errorf("got error %v and length %d; want nil and 1", err, s.Len())
+	}
+
+	// A union takes the pop mode of the receiver, whichever set is larger
+	ordered := NewSetPopSmallest([]int{0, 100, 200, 300})
+	plain := NewSet([]int{1000})
+	if u := plain.Union(ordered); u.pop_smallest {
+		t.Errorf("a union of a plain set should not take the pop mode of the other")
+	}
+	u := ordered.Union(plain)
+	if got, _ := u.Pop(); got != 0 {
+		t.Errorf("got %v; want 0", got)
+	}
+}
+
 func TestClear(t *testing.T) {
 	s := NewSet([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
 	s.Clear()
@@ -986,84 +1078,78 @@ func FuzzUnionInPlace(f *testing.F) {
 	})
 }
 
-func TestNumber_to_bitset_representation(t *testing.T) {
+func TestWord_of(t *testing.T) {
 	testCases := []struct {
-		desc             string
-		in               int
-		want_is_positive bool
-		want_multiplier  uint64
-		want_rem_slot    uint64
+		desc      string
+		in        int
+		want_key  int64
+		want_slot uint64
 	}{
 		{
-			desc:             "0",
-			in:               0,
-			want_is_positive: true,
-			want_multiplier:  0,
-			want_rem_slot:    1,
+			desc:      "0",
+			in:        0,
+			want_key:  0,
+			want_slot: 1,
+		},
+		{
+			desc:      "-1",
+			in:        -1,
+			want_key:  -1,
+			want_slot: 9223372036854775808,
 		},
 		{
-			desc:             "-1",
-			in:               -1,
-			want_is_positive: false,
-			want_multiplier:  0,
-			want_rem_slot:    2,
+			desc:      "-10",
+			in:        -10,
+			want_key:  -1,
+			want_slot: 18014398509481984,
 		},
 		{
-			desc:             "-10",
-			in:               -10,
-			want_is_positive: false,
-			want_multiplier:  0,
-			want_rem_slot:    1024,
+			desc:      "63",
+			in:        63,
+			want_key:  0,
+			want_slot: 9223372036854775808,
 		},
 		{
-			desc:             "63",
-			in:               63,
-			want_is_positive: true,
-			want_multiplier:  0,
-			want_rem_slot:    9223372036854775808,
+			desc:      "64",
+			in:        64,
+			want_key:  1,
+			want_slot: 1,
 		},
 		{
-			desc:             "64",
-			in:               64,
-			want_is_positive: true,
-			want_multiplier:  1,
-			want_rem_slot:    1,
+			desc:      "-64",
+			in:        -64,
+			want_key:  -1,
+			want_slot: 1,
 		},
 		{
-			desc:             "-64",
-			in:               -64,
-			want_is_positive: false,
-			want_multiplier:  1,
-			want_rem_slot:    1,
+			desc:      "-65",
+			in:        -65,
+			want_key:  -2,
+			want_slot: 9223372036854775808,
 		},
 		{
-			desc:             "155",
-			in:               155,
-			want_is_positive: true,
-			want_multiplier:  2,
-			want_rem_slot:    134217728,
+			desc:      "155",
+			in:        155,
+			want_key:  2,
+			want_slot: 134217728,
 		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
-			is_positive, multiplier, rem_slot := number_to_bitset_representation(tC.in)
+			key, slot := word_of(tC.in)
 
-			if is_positive != tC.want_is_positive {
-				t.Errorf("Sign is wrong way: got %v; want %v", is_positive, tC.want_is_positive)
+			if key != tC.want_key {
+				t.Errorf("Word index is incorrect: got %v; want %v", key, tC.want_key)
 			}
 
-			if multiplier != tC.want_multiplier {
-				t.Errorf("Multiplier is incorrect: got %v; want %v", multiplier, tC.want_multiplier)
-			}
-
-			if rem_slot != tC.want_rem_slot {
-				t.Errorf("Remainder slot is incorrect: got %v; want %v", rem_slot, tC.want_rem_slot)
+			if slot != tC.want_slot {
+				t.Errorf("Slot is incorrect: got %v; want %v", slot, tC.want_slot)
 			}
 		})
 	}
 }
 
-func BenchmarkNumber_to_bitset_representation(b *testing.B) {
+func BenchmarkWord_of(b *testing.B) {
 	benchCases := []struct {
 		desc string
 		in   int
@@ -1108,7 +1194,7 @@ func BenchmarkNumber_to_bitset_representation(b *testing.B) {
 	for _, bC := range benchCases {
 		b.Run(bC.desc, func(b *testing.B) {
 			for i := 0; i < b.N; i++ {
-				number_to_bitset_representation(bC.in)
+				word_of(bC.in)
 			}
 		})
 	}
@@ -2272,3 +2358,624 @@ func FuzzSymmetricDifferenceInPlace(f *testing.F) {
 		}
 	})
 }
+
+func TestGoString(t *testing.T) {
+	testCases := []struct {
+		desc string
+		s    Set
+		want string
+	}{
+		{desc: "empty", s: Of(), want: "bitset.Of()"},
+		{desc: "sorted", s: Of(100, -3, 0, 64), want: "bitset.Of(-3, 0, 64, 100)"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := fmt.Sprintf("%#v", tC.s); got != tC.want {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+}
+
+func TestNotFoundError(t *testing.T) {
+	s := NewSet([]int{1})
+	err := s.Remove(-70)
+
+	var not_found *NotFoundError
+	if !errors.As(err, &not_found) || not_found.Item != -70 {
+		t.Errorf("got error %v, want a *NotFoundError for -70", err)
+	}
+	if !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("got error %v, want it to match %v", err, ErrElementNotFound)
+	}
+}
+
+func TestTryRemove(t *testing.T) {
+	s := NewSet([]int{-5, 64})
+	testCases := []struct {
+		desc string
+		v    int
+		want bool
+	}{
+		{desc: "negative", v: -5, want: true},
+		{desc: "already removed", v: -5, want: false},
+		{desc: "same word, not there", v: 65, want: false},
+		{desc: "no word", v: 1000, want: false},
+		{desc: "positive", v: 64, want: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := s.TryRemove(tC.v); got != tC.want {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+
+	if !s.IsEmpty() {
+		t.Errorf("got %v; want an empty set", s)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		s         Set
+		want_min  int
+		want_max  int
+		want_some bool
+	}{
+		{desc: "empty", s: NewSet([]int{}), want_some: false},
+		{desc: "zero", s: NewSet([]int{0}), want_min: 0, want_max: 0, want_some: true},
+		{desc: "positive", s: NewSet([]int{5, 64, 700}), want_min: 5, want_max: 700, want_some: true},
+		{desc: "negative", s: NewSet([]int{-1, -64, -700}), want_min: -700, want_max: -1, want_some: true},
+		{desc: "both", s: NewSet([]int{-65, 3, 128}), want_min: -65, want_max: 128, want_some: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			min, ok := tC.s.Min()
+			if ok != tC.want_some || (ok && min != tC.want_min) {
+				t.Errorf("got %v, %v; want %v, %v", min, ok, tC.want_min, tC.want_some)
+			}
+			max, ok := tC.s.Max()
+			if ok != tC.want_some || (ok && max != tC.want_max) {
+				t.Errorf("got %v, %v; want %v, %v", max, ok, tC.want_max, tC.want_some)
+			}
+		})
+	}
+
+	// Words emptied by removing items are skipped
+	s := NewSet([]int{1, 1000})
+	s.Discard(1000)
+	if max, ok := s.Max(); !ok || max != 1 {
+		t.Errorf("got %v, %v; want 1, true", max, ok)
+	}
+}
+
+func TestSliceSorted(t *testing.T) {
+	want := []int{-1 << 40, -1000, -65, -64, -63, -1, 0, 1, 63, 64, 65, 1000, 1 << 40}
+	// Build it in a jumbled order, with a word emptied out along the way
+	s := NewSet([]int{65, -1, 1 << 40, 0, -64, 1000, 5000, -1 << 40, 63, -63, 1, -65, 64, -1000})
+	s.Discard(5000)
+
+	if got := s.Slice(); !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestAppendTo(t *testing.T) {
+	s := NewSet([]int{64, -1, 3})
+	buf := []int{100}
+	buf = s.AppendTo(buf)
+	if want := []int{100, -1, 3, 64}; !slices.Equal(buf, want) {
+		t.Errorf("got %v; want %v", buf, want)
+	}
+
+	// Reusing the buffer only allocates the sorted word indexes
+	allocs := testing.AllocsPerRun(10, func() {
+		buf = s.AppendTo(buf[:0])
+	})
+	if allocs > 1 {
+		t.Errorf("got %v allocations; want at most 1", allocs)
+	}
+	if want := []int{-1, 3, 64}; !slices.Equal(buf, want) {
+		t.Errorf("got %v; want %v", buf, want)
+	}
+}
+
+func TestStringExact(t *testing.T) {
+	emptied := NewSet([]int{5, 500})
+	emptied.Discard(500)
+
+	testCases := []struct {
+		desc string
+		s    Set
+		want string
+	}{
+		{desc: "empty", s: NewSet([]int{}), want: "{}"},
+		{desc: "one", s: NewSet([]int{-7}), want: "{-7}"},
+		{desc: "ascending", s: NewSet([]int{200, -65, 3, -1, 0}), want: "{-65, -1, 0, 3, 200}"},
+		{desc: "emptied word", s: emptied, want: "{5}"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := tC.s.String(); got != tC.want {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+}
+
+func TestFlip(t *testing.T) {
+	s := NewSet([]int{1})
+	testCases := []struct {
+		desc string
+		v    int
+		want bool
+	}{
+		{desc: "remove present", v: 1, want: false},
+		{desc: "add back", v: 1, want: true},
+		{desc: "add negative", v: -64, want: true},
+		{desc: "remove negative", v: -64, want: false},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := s.Flip(tC.v); got != tC.want {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+			if s.Contains(tC.v) != tC.want {
+				t.Errorf("Contains(%d) disagrees with Flip", tC.v)
+			}
+		})
+	}
+
+	var zero Set
+	if !zero.Flip(3) {
+		t.Errorf("flipping into a zero value set should add the item")
+	}
+}
+
+func TestForEach(t *testing.T) {
+	s := NewSet([]int{300, -70, 2, 1})
+
+	var got []int
+	s.ForEach(func(item int) bool {
+		got = append(got, item)
+		return true
+	})
+	if want := []int{-70, 1, 2, 300}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	got = nil
+	s.ForEach(func(item int) bool {
+		got = append(got, item)
+		return item < 1
+	})
+	if want := []int{-70, 1}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestAddSlice(t *testing.T) {
+	testCases := []struct {
+		desc string
+		data []int
+	}{
+		{desc: "empty", data: []int{}},
+		{desc: "sorted", data: []int{0, 1, 2, 63, 64, 65}},
+		{desc: "jumbled", data: []int{64, -1, 0, -64, 1, 63, -1}},
+		{desc: "one word", data: []int{5, 5, 6, 7}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := NewSet([]int{1000})
+			s.AddSlice(tC.data)
+
+			want := NewSet([]int{1000})
+			for _, v := range tC.data {
+				want.Add(v)
+			}
+			if !s.Equals(want) {
+				t.Errorf("got %v; want %v", s, want)
+			}
+		})
+	}
+}
+
+func BenchmarkAddSliceSorted(b *testing.B) {
+	data := make([]int, 1_000_000)
+	for i := range data {
+		data[i] = i
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := NewSet([]int{})
+		s.AddSlice(data)
+	}
+}
+
+func TestNewSetWithCapacity(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		data           []int
+		expected_range int
+	}{
+		{desc: "empty", data: []int{}, expected_range: 1_000_000},
+		{desc: "some data", data: []int{-5, 0, 100}, expected_range: 200},
+		{desc: "negative range", data: []int{1}, expected_range: -1},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got := NewSetWithCapacity(tC.data, tC.expected_range)
+			want := NewSet(tC.data)
+			if !got.Equals(want) {
+				t.Errorf("got %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkNewSetWithCapacity(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		s := NewSetWithCapacity([]int{}, 1_000_000)
+		for v := 0; v < 1_000_000; v += 7 {
+			s.Add(v)
+		}
+	}
+}
+
+func TestComparisonsIgnoreEmptyWords(t *testing.T) {
+	// Discarding 200 empties one of the words in `s1`
+	s1 := NewSet([]int{1, 200})
+	s1.Discard(200)
+	s2 := NewSet([]int{1})
+
+	if !s1.Equals(s2) || !s2.Equals(s1) {
+		t.Errorf("%v and %v should be equal", s1, s2)
+	}
+	if !s1.IsSubsetOf(s2) || !s2.IsSubsetOf(s1) {
+		t.Errorf("%v and %v should be subsets of each other", s1, s2)
+	}
+	if !s1.IsSuperSetOf(s2) || !s2.IsSuperSetOf(s1) {
+		t.Errorf("%v and %v should be supersets of each other", s1, s2)
+	}
+	if s1.IsProperSubsetOf(s2) || s1.IsProperSuperSetOf(s2) {
+		t.Errorf("%v and %v are equal, so neither is a proper subset or superset", s1, s2)
+	}
+	if s1.IsDisjoint(s2) {
+		t.Errorf("%v and %v should not be disjoint", s1, s2)
+	}
+}
+
+func TestCombinedLen(t *testing.T) {
+	testCases := []struct {
+		desc string
+		s1   Set
+		s2   Set
+	}{
+		{desc: "both empty", s1: NewSet([]int{}), s2: NewSet([]int{})},
+		{desc: "one empty", s1: NewSet([]int{1, 2, 3}), s2: NewSet([]int{})},
+		{desc: "equal", s1: NewSet([]int{-70, 1, 500}), s2: NewSet([]int{500, 1, -70})},
+		{desc: "disjoint", s1: NewSet([]int{1, 2, 3}), s2: NewSet([]int{-1, -2, 300})},
+		{
+			desc: "some overlap",
+			s1:   NewSet([]int{-130, -64, -1, 0, 5, 63, 64, 1000}),
+			s2:   NewSet([]int{-64, 0, 6, 63, 65, 1000, 2000}),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			intersection := tC.s1.Intersection(tC.s2)
+			if got, want := tC.s1.IntersectionLen(tC.s2), intersection.Len(); got != want {
+				t.Errorf("IntersectionLen: got %v; want %v", got, want)
+			}
+			union := tC.s1.Union(tC.s2)
+			if got, want := tC.s1.UnionLen(tC.s2), union.Len(); got != want {
+				t.Errorf("UnionLen: got %v; want %v", got, want)
+			}
+			difference := tC.s1.Difference(tC.s2)
+			if got, want := tC.s1.DifferenceLen(tC.s2), difference.Len(); got != want {
+				t.Errorf("DifferenceLen: got %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkIntersectionLen(b *testing.B) {
+	s1 := NewRange(0, 10_000)
+	s2 := NewRange(5_000, 15_000)
+
+	b.Run("IntersectionLen", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s1.IntersectionLen(s2)
+		}
+	})
+	b.Run("Intersection", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			result := s1.Intersection(s2)
+			result.Len()
+		}
+	})
+}
+
+func TestIntersects(t *testing.T) {
+	testCases := []struct {
+		desc string
+		s1   Set
+		s2   Set
+		want bool
+	}{
+		{desc: "both empty", s1: NewSet([]int{}), s2: NewSet([]int{}), want: false},
+		{desc: "one empty", s1: NewSet([]int{1, 2}), s2: NewSet([]int{}), want: false},
+		{desc: "same word, no shared bits", s1: NewSet([]int{1, 3}), s2: NewSet([]int{2, 4}), want: false},
+		{desc: "different words", s1: NewSet([]int{-1, 1}), s2: NewSet([]int{-65, 65}), want: false},
+		{desc: "one shared item", s1: NewSet([]int{1, 2, 3, 1000}), s2: NewSet([]int{1000}), want: true},
+		{desc: "negative shared item", s1: NewSet([]int{-64}), s2: NewSet([]int{-64, 64}), want: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := tC.s1.Intersects(tC.s2); got != tC.want {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+			if got := tC.s2.Intersects(tC.s1); got != tC.want {
+				t.Errorf("reversed: got %v; want %v", got, tC.want)
+			}
+		})
+	}
+}
+
+func TestLenKeptUpToDate(t *testing.T) {
+	// popcount counts the items the slow way, to check the cached length against
+	popcount := func(s Set) int {
+		result := 0
+		for _, w := range s.data {
+			result += bits.OnesCount64(w)
+		}
+		return result
+	}
+	other := NewSet([]int{-70, 3, 5, 64, 500})
+
+	testCases := []struct {
+		desc string
+		f    func(s *Set)
+	}{
+		{desc: "Add", f: func(s *Set) { s.Add(7); s.Add(7); s.Add(-1000) }},
+		{desc: "AddSlice", f: func(s *Set) { s.AddSlice([]int{1, 2, 3, 3, 900}) }},
+		{desc: "Remove", f: func(s *Set) { s.Remove(3); s.Remove(3) }},
+		{desc: "TryRemove", f: func(s *Set) { s.TryRemove(-64); s.TryRemove(-64) }},
+		{desc: "Discard", f: func(s *Set) { s.Discard(0); s.Discard(0); s.Discard(12345) }},
+		{desc: "Flip", f: func(s *Set) { s.Flip(1); s.Flip(2); s.Flip(2) }},
+		{desc: "Pop", f: func(s *Set) { s.Pop(); s.Pop() }},
+		{desc: "Clear", f: func(s *Set) { s.Clear() }},
+		{desc: "UnionInPlace", f: func(s *Set) { s.UnionInPlace(other) }},
+		{desc: "IntersectionInPlace", f: func(s *Set) { s.IntersectionInPlace(other) }},
+		{desc: "DifferenceInPlace", f: func(s *Set) { s.DifferenceInPlace(other) }},
+		{desc: "SymmetricDifferenceInPlace", f: func(s *Set) { s.SymmetricDifferenceInPlace(other) }},
+		{desc: "AddRange", f: func(s *Set) { s.AddRange(-10, 200) }},
+		{desc: "DiscardRange", f: func(s *Set) { s.DiscardRange(-100, 4) }},
+		{desc: "FlipRange", f: func(s *Set) { s.FlipRange(-3, 70) }},
+		{desc: "ShiftAll", f: func(s *Set) { s.ShiftAll(-37) }},
+		{desc: "Union", f: func(s *Set) { *s = s.Union(other) }},
+		{desc: "Intersection", f: func(s *Set) { *s = s.Intersection(other) }},
+		{desc: "Difference", f: func(s *Set) { *s = s.Difference(other) }},
+		{desc: "SymmetricDifference", f: func(s *Set) { *s = s.SymmetricDifference(other) }},
+		{desc: "Complement", f: func(s *Set) { *s = s.Complement(-200, 200) }},
+		{desc: "Copy", f: func(s *Set) { *s = s.Copy() }},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := NewSet([]int{-130, -64, -1, 0, 3, 5, 63, 64, 1000})
+			tC.f(&s)
+			if got, want := s.Len(), popcount(s); got != want {
+				t.Errorf("got %v; want %v", got, want)
+			}
+			if got, want := s.IsEmpty(), popcount(s) == 0; got != want {
+				t.Errorf("IsEmpty: got %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestEmptyWordsDropped(t *testing.T) {
+	other := NewSet([]int{-70, 3, 5, 64, 500})
+
+	testCases := []struct {
+		desc string
+		f    func(s *Set)
+	}{
+		{desc: "Remove", f: func(s *Set) { s.Remove(3); s.Remove(5) }},
+		{desc: "TryRemove", f: func(s *Set) { s.TryRemove(3); s.TryRemove(5) }},
+		{desc: "Discard", f: func(s *Set) { s.Discard(3); s.Discard(5) }},
+		{desc: "Flip", f: func(s *Set) { s.Flip(3); s.Flip(5); s.Flip(1000); s.Flip(1000) }},
+		{desc: "Pop", f: func(s *Set) { s.Pop(); s.Pop() }},
+		{desc: "IntersectionInPlace", f: func(s *Set) { s.IntersectionInPlace(NewSet([]int{1})) }},
+		{desc: "DifferenceInPlace", f: func(s *Set) { s.DifferenceInPlace(other) }},
+		{desc: "Difference", f: func(s *Set) { *s = s.Difference(other) }},
+		{desc: "SymmetricDifferenceInPlace", f: func(s *Set) { s.SymmetricDifferenceInPlace(NewSet([]int{3, 5})) }},
+		{desc: "DiscardRange", f: func(s *Set) { s.DiscardRange(0, 10) }},
+		{desc: "FlipRange", f: func(s *Set) { s.FlipRange(3, 5); s.FlipRange(4, 4) }},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			// Both items are in word 0, so each case should leave the map empty
+			s := NewSet([]int{3, 5})
+			tC.f(&s)
+			for k, w := range s.data {
+				if w == 0 {
+					t.Errorf("word %d was left in the map with no bits set", k)
+				}
+			}
+			if empty := NewSet([]int{}); s.IsEmpty() && !s.Equals(empty) {
+				t.Errorf("%v should equal an empty set", s)
+			}
+		})
+	}
+}
+
+func TestNewSetFromSorted(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		data       []int
+		want_words int
+	}{
+		{desc: "empty", data: []int{}, want_words: 0},
+		{desc: "one word", data: []int{0, 1, 1, 63}, want_words: 1},
+		{desc: "across zero", data: []int{-130, -64, -1, 0, 1, 63, 64, 1000}, want_words: 5},
+		{desc: "not sorted", data: []int{1000, -1, 5, -1}, want_words: 3},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got := NewSetFromSorted(tC.data)
+			want := NewSet(tC.data)
+			if !got.Equals(want) {
+				t.Errorf("got %v; want %v", got, want)
+			}
+			if got.Len() != want.Len() {
+				t.Errorf("got length %d; want %d", got.Len(), want.Len())
+			}
+			if len(got.data) != tC.want_words {
+				t.Errorf("got %d words; want %d", len(got.data), tC.want_words)
+			}
+		})
+	}
+}
+
+func TestContainsAllOf(t *testing.T) {
+	s := NewSet([]int{-130, -64, -1, 0, 1, 63, 64, 1000})
+	testCases := []struct {
+		desc  string
+		items []int
+		want  bool
+	}{
+		{desc: "no items", items: []int{}, want: true},
+		{desc: "all in one word", items: []int{0, 1, 63, 1, 0}, want: true},
+		{desc: "all across words", items: []int{1000, -130, 64, -1}, want: true},
+		{desc: "last is missing", items: []int{0, 1, 2}, want: false},
+		{desc: "missing word", items: []int{0, 5000}, want: false},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := s.ContainsAllOf(tC.items); got != tC.want {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+}
+
+func TestContainsMask(t *testing.T) {
+	s := NewSet([]int{-130, -64, -1, 0, 1, 63, 64, 1000})
+	items := []int{0, 2, 1, 63, 64, 65, -1, -2, 5000, 1000}
+	want := []bool{true, false, true, true, true, false, true, false, false, true}
+	if got := s.ContainsMask(items); !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	if got := s.ContainsMask(nil); len(got) != 0 {
+		t.Errorf("got %v; want an empty slice", got)
+	}
+}
+
+func TestDifferenceWithSlice(t *testing.T) {
+	items := []int{-130, -64, -1, 0, 1, 63, 64, 1000}
+	testCases := []struct {
+		desc   string
+		remove []int
+	}{
+		{desc: "nothing", remove: []int{}},
+		{desc: "one word", remove: []int{0, 1, 63, 2}},
+		{desc: "across words", remove: []int{1000, -1, 64, -130, 5000}},
+		{desc: "everything", remove: items},
+		{desc: "none in the set", remove: []int{2, 3, -2, 99}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := NewSet(items)
+			other := NewSet(tC.remove)
+			want := s.Difference(other)
+
+			got := s.DifferenceWithSlice(tC.remove)
+			if !got.Equals(want) {
+				t.Errorf("got %v; want %v", got, want)
+			}
+			if s.Len() != len(items) {
+				t.Errorf("DifferenceWithSlice should leave `s` alone, got %v", s)
+			}
+
+			s.DifferenceInPlaceWithSlice(tC.remove)
+			if !s.Equals(want) || s.Len() != want.Len() {
+				t.Errorf("got %v; want %v", s, want)
+			}
+		})
+	}
+}
+
+func TestUnionOfIntersectionOf(t *testing.T) {
+	a := NewSet([]int{-65, -1, 0, 1, 64, 1000})
+	b := NewSet([]int{-1, 0, 2, 64, 5000})
+	c := NewSet([]int{-65, -1, 64, 65, 1000})
+	ab := a.Union(b)
+
+	testCases := []struct {
+		desc              string
+		sets              []Set
+		want_union        Set
+		want_intersection Set
+	}{
+		{desc: "none", sets: []Set{}, want_union: NewSet([]int{}), want_intersection: NewSet([]int{})},
+		{desc: "one", sets: []Set{a}, want_union: a, want_intersection: a},
+		{
+			desc:              "three",
+			sets:              []Set{a, b, c},
+			want_union:        ab.Union(c),
+			want_intersection: NewSet([]int{-1, 64}),
+		},
+		{
+			desc:              "with an empty set",
+			sets:              []Set{a, NewSet([]int{}), c},
+			want_union:        a.Union(c),
+			want_intersection: NewSet([]int{}),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			union := UnionOf(tC.sets...)
+			if !union.Equals(tC.want_union) {
+				t.Errorf("UnionOf: got %v; want %v", union, tC.want_union)
+			}
+			intersection := IntersectionOf(tC.sets...)
+			if !intersection.Equals(tC.want_intersection) {
+				t.Errorf("IntersectionOf: got %v; want %v", intersection, tC.want_intersection)
+			}
+		})
+	}
+
+	// The inputs are left alone
+	if a.Len() != 6 || !a.Contains(1000) {
+		t.Errorf("a should not change, got %v", a)
+	}
+}
+
+func BenchmarkUnionOf(b *testing.B) {
+	sets := make([]Set, 16)
+	for i := range sets {
+		items := make([]int, 10_000)
+		for j := range items {
+			items[j] = rand.Intn(1_000_000)
+		}
+		sets[i] = NewSet(items)
+	}
+
+	b.Run("fold", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			result := sets[0].Copy()
+			for _, s := range sets[1:] {
+				result = result.Union(s)
+			}
+		}
+	})
+	b.Run("UnionOf", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			UnionOf(sets...)
+		}
+	})
+}
@@ -0,0 +1,22 @@
+package bitset
+
+// ShiftAll adds `delta` to every item in the set. Whole words are shifted, with the
+// bits that fall off the top of one word carried into the next, rather than moving one
+// item at a time. Items must not be shifted past the range of an int.
+func (s *Set) ShiftAll(delta int) {
+	word_delta := int64(delta >> 6)
+	bit_delta := uint(delta & 63)
+
+	shifted := make(map[int64]uint64, len(s.data)+1)
+	for k, w := range s.data {
+		if lo := w << bit_delta; lo != 0 {
+			shifted[k+word_delta] |= lo
+		}
+		if bit_delta != 0 {
+			if hi := w >> (64 - bit_delta); hi != 0 {
+				shifted[k+word_delta+1] |= hi
+			}
+		}
+	}
+	s.data = shifted
+}
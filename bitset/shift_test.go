@@ -0,0 +1,34 @@
+package bitset
+
+import (
+	"testing"
+)
+
+func TestShiftAll(t *testing.T) {
+	items := []int{-130, -64, -1, 0, 5, 63, 64, 1000}
+	testCases := []struct {
+		desc  string
+		delta int
+	}{
+		{desc: "zero", delta: 0},
+		{desc: "small", delta: 3},
+		{desc: "whole word", delta: 64},
+		{desc: "negative", delta: -1},
+		{desc: "large negative", delta: -1000},
+		{desc: "odd", delta: 129},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			want := NewSet([]int{})
+			for _, v := range items {
+				want.Add(v + tC.delta)
+			}
+
+			got := NewSet(items)
+			got.ShiftAll(tC.delta)
+			if !got.Equals(want) {
+				t.Errorf("got %v; want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package bitset
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// map_bytes_per_word is roughly how much memory the word map uses for each word: the 8
+// byte index and 8 byte word, plus the map's own bookkeeping and the room it keeps free.
+// Go doesn't report the size of a map, so this is an estimate.
+const map_bytes_per_word = 24
+
+// Stats describes how much memory a bitset is using, to help decide whether another
+// kind of set would suit the data better
+type Stats struct {
+	// Words is the number of 64 bit words stored
+	Words int
+	// Items is the number of items in the set
+	Items int
+	// Density is the average fraction of the 64 bits in each word that are set, from 0
+	// to 1. A low density means a plain hash set may be smaller.
+	Density float64
+	// Bytes is an estimate of the memory used by the set
+	Bytes int
+}
+
+// String prints the stats on one line, such as
+// "12 items in 1 words (18.8% dense), about 56 bytes"
+func (st Stats) String() string {
+	return fmt.Sprintf(
+		"%d items in %d words (%.1f%% dense), about %d bytes",
+		st.Items, st.Words, 100*st.Density, st.Bytes,
+	)
+}
+
+// new_stats fills in the density from the number of words and items
+func new_stats(words, items, bytes int) Stats {
+	result := Stats{Words: words, Items: items, Bytes: bytes}
+	if words > 0 {
+		result.Density = float64(items) / float64(64*words)
+	}
+	return result
+}
+
+// Stats will report how many words the set is using, how full they are, and roughly how
+// much memory they take up
+func (s *Set) Stats() Stats {
+	return new_stats(
+		len(s.data),
+		s.length,
+		int(unsafe.Sizeof(*s))+map_bytes_per_word*len(s.data),
+	)
+}
+
+// Stats will report how many words the set is using, how full they are, and how much
+// memory they take up. Every word between the smallest and largest is counted, even if
+// it is empty.
+func (d *Dense) Stats() Stats {
+	return new_stats(
+		len(d.words),
+		d.Len(),
+		int(unsafe.Sizeof(*d))+8*cap(d.words),
+	)
+}
+
+// RunStats describes how the items of a set cluster into runs of consecutive numbers.
+// A few long runs suit `MarshalRLE`; many short ones are better stored as raw words.
+type RunStats struct {
+	// Runs is the number of runs of consecutive numbers
+	Runs int
+	// Gaps is the number of stretches of missing numbers between runs
+	Gaps int
+	// LargestRun is the length of the longest run, or 0 for an empty set
+	LargestRun int
+	// Lengths maps each run length to how many runs have that length
+	Lengths map[int]int
+}
+
+// String prints the run stats on one line, such as
+// "3 runs, 2 gaps, largest run 10"
+func (rs RunStats) String() string {
+	return fmt.Sprintf("%d runs, %d gaps, largest run %d", rs.Runs, rs.Gaps, rs.LargestRun)
+}
+
+// RunStats will find the runs of consecutive numbers in the set, and report how many
+// there are, how long they are, and how many gaps lie between them
+func (s *Set) RunStats() RunStats {
+	runs := s.Runs()
+	result := RunStats{Runs: len(runs), Lengths: make(map[int]int)}
+	if len(runs) > 0 {
+		result.Gaps = len(runs) - 1
+	}
+	for _, r := range runs {
+		length := r[1] - r[0] + 1
+		result.Lengths[length]++
+		result.LargestRun = max(result.LargestRun, length)
+	}
+	return result
+}
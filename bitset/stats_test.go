@@ -0,0 +1,104 @@
+package bitset
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		data         []int
+		want_words   int
+		want_density float64
+	}{
+		{desc: "empty", data: []int{}, want_words: 0, want_density: 0},
+		{desc: "one item", data: []int{5}, want_words: 1, want_density: 1.0 / 64},
+		{desc: "two sparse words", data: []int{0, 1000}, want_words: 2, want_density: 2.0 / 128},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := NewSet(tC.data)
+			got := s.Stats()
+			if got.Words != tC.want_words {
+				t.Errorf("Words: got %v; want %v", got.Words, tC.want_words)
+			}
+			if got.Items != len(tC.data) {
+				t.Errorf("Items: got %v; want %v", got.Items, len(tC.data))
+			}
+			if math.Abs(got.Density-tC.want_density) > 1e-12 {
+				t.Errorf("Density: got %v; want %v", got.Density, tC.want_density)
+			}
+			if got.Bytes <= 0 {
+				t.Errorf("Bytes: got %v; want a positive estimate", got.Bytes)
+			}
+		})
+	}
+
+	full := NewRange(0, 6399)
+	if got := full.Stats(); got.Words != 100 || got.Density != 1 {
+		t.Errorf("got %v; want 100 full words", got)
+	}
+}
+
+func TestDenseStats(t *testing.T) {
+	d := NewDense([]int{0, 1000})
+	got := d.Stats()
+	// Every word from 0 through 1000 is allocated, even the empty ones between them
+	if got.Words != 16 || got.Items != 2 {
+		t.Errorf("got %v; want 2 items in 16 words", got)
+	}
+	if got.Bytes < 16*8 {
+		t.Errorf("got %d bytes; want at least %d", got.Bytes, 16*8)
+	}
+}
+
+func TestStatsString(t *testing.T) {
+	st := Stats{Words: 1, Items: 12, Density: 12.0 / 64, Bytes: 56}
+	want := "12 items in 1 words (18.8% dense), about 56 bytes"
+	if got := st.String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
+
+func TestRunStats(t *testing.T) {
+	testCases := []struct {
+		desc string
+		data []int
+		want RunStats
+	}{
+		{
+			desc: "empty",
+			data: []int{},
+			want: RunStats{Lengths: map[int]int{}},
+		},
+		{
+			desc: "one run across words",
+			data: []int{62, 63, 64, 65},
+			want: RunStats{Runs: 1, LargestRun: 4, Lengths: map[int]int{4: 1}},
+		},
+		{
+			desc: "mixed",
+			data: []int{-3, -2, -1, 5, 7, 100, 101, 102, 103},
+			want: RunStats{Runs: 4, Gaps: 3, LargestRun: 4, Lengths: map[int]int{1: 2, 3: 1, 4: 1}},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := NewSet(tC.data)
+			got := s.RunStats()
+			if !reflect.DeepEqual(got, tC.want) {
+				t.Errorf("got %+v; want %+v", got, tC.want)
+			}
+		})
+	}
+}
+
+func TestRunStatsString(t *testing.T) {
+	rs := RunStats{Runs: 3, Gaps: 2, LargestRun: 10}
+	want := "3 runs, 2 gaps, largest run 10"
+	if got := rs.String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
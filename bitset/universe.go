@@ -0,0 +1,75 @@
+package bitset
+
+import (
+	"errors"
+)
+
+var (
+	// This error is returned by `Not` when the set has no universe to take the
+	// complement within
+	ErrNoUniverse = errors.New("bitset: set has no universe")
+)
+
+// universe is either a range of integers, or the words of another set. It is never
+// changed once made, so sets can share it.
+type universe struct {
+	lo, hi int
+	// words is nil for a range universe
+	words map[int64]uint64
+}
+
+// SetUniverseRange makes every integer from `lo` to `hi` inclusive the universe of `s`,
+// which `Not` takes the complement within. Setting it is cheap, as the range is not
+// built, but `Not` stores a word for every 64 integers of the range that aren't all in
+// `s`, so keep it to what the complement can fit in memory. Copies keep the universe,
+// and so do the results of combining `s` with another set, which always take the
+// universe of the receiver, or of `a` for the Into functions.
+func (s *Set) SetUniverseRange(lo, hi int) {
+	s.universe = &universe{lo: lo, hi: hi}
+}
+
+// SetUniverse makes the items of `u` the universe of `s`, which `Not` takes the
+// complement within. `u` is copied, so later changes to it don't change the universe.
+func (s *Set) SetUniverse(u Set) {
+	words := make(map[int64]uint64, len(u.data))
+	for k, w := range u.data {
+		words[k] = w
+	}
+	s.universe = &universe{words: words}
+}
+
+// ClearUniverse removes the universe of `s`
+func (s *Set) ClearUniverse() {
+	s.universe = nil
+}
+
+// HasUniverse returns true if a universe has been set with `SetUniverseRange` or
+// `SetUniverse`
+func (s *Set) HasUniverse() bool {
+	return s.universe != nil
+}
+
+// Not will create a new Set holding every item of the universe of `s` that is not in
+// `s`. Items of `s` outside the universe are ignored. The result has the same universe,
+// and pop mode, so `Not` can be called on it to get back the part of `s` inside the
+// universe. It is built a word at a time, without listing the universe out, but holds
+// every word of the universe not filled by `s`. Returns ErrNoUniverse if no universe
+// has been set.
+func (s *Set) Not() (Set, error) {
+	u := s.universe
+	if u == nil {
+		return Set{}, ErrNoUniverse
+	}
+
+	var result Set
+	if u.words == nil {
+		result = s.Complement(u.lo, u.hi)
+	} else {
+		result = NewSet([]int{})
+		for k, w := range u.words {
+			result.set_word(k, w&^s.data[k])
+		}
+	}
+	result.universe, result.pop_smallest = u, s.pop_smallest
+	return result, nil
+}
@@ -0,0 +1,144 @@
+package bitset
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNot(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		items []int
+		set   func(s *Set)
+		want  []int
+	}{
+		{
+			desc:  "range",
+			items: []int{-1, 2, 4},
+			set:   func(s *Set) { s.SetUniverseRange(-2, 5) },
+			want:  []int{-2, 0, 1, 3, 5},
+		},
+		{
+			desc:  "range ignores items outside it",
+			items: []int{-100, 1, 100},
+			set:   func(s *Set) { s.SetUniverseRange(0, 2) },
+			want:  []int{0, 2},
+		},
+		{
+			desc:  "set",
+			items: []int{-64, 3, 1000},
+			set:   func(s *Set) { s.SetUniverse(NewSet([]int{-64, -1, 3, 64, 1000, 2000})) },
+			want:  []int{-1, 64, 2000},
+		},
+		{
+			desc:  "everything in the universe",
+			items: []int{1, 2, 3},
+			set:   func(s *Set) { s.SetUniverseRange(1, 3) },
+			want:  []int{},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := NewSet(tC.items)
+			tC.set(&s)
+			if !s.HasUniverse() {
+				t.Fatalf("the universe should have been set")
+			}
+
+			got, err := s.Not()
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if want := NewSet(tC.want); !got.Equals(want) || got.Len() != want.Len() {
+				t.Errorf("got %v; want %v", got, want)
+			}
+
+			// Taking the complement again gives back the part of `s` in the universe
+			back, err := got.Not()
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if !back.IsSubsetOf(s) || back.Intersects(got) {
+				t.Errorf("got %v from complementing twice, started with %v", back, s)
+			}
+		})
+	}
+}
+
+func TestNotWithoutUniverse(t *testing.T) {
+	s := NewSet([]int{1, 2})
+	if _, err := s.Not(); !errors.Is(err, ErrNoUniverse) {
+		t.Errorf("got error %v, want %v", err, ErrNoUniverse)
+	}
+
+	s.SetUniverseRange(0, 10)
+	s.ClearUniverse()
+	if _, err := s.Not(); !errors.Is(err, ErrNoUniverse) {
+		t.Errorf("got error %v, want %v", err, ErrNoUniverse)
+	}
+}
+
+func TestUniverseIsCopied(t *testing.T) {
+	u := NewSet([]int{1, 2, 3})
+	s := NewSet([]int{1})
+	s.SetUniverse(u)
+	u.Add(4)
+
+	got, _ := s.Not()
+	if want := NewSet([]int{2, 3}); !got.Equals(want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	// Copies keep the universe
+	c := s.Copy()
+	if !c.HasUniverse() {
+		t.Errorf("a copy should keep the universe")
+	}
+}
+
+func TestUniverseOfResults(t *testing.T) {
+	// `a` has the universe and pop mode, and is the smaller set, so a result that took
+	// its options from the larger set would lose them
+	a := NewSetPopSmallest([]int{1, 2})
+	a.SetUniverseRange(0, 10)
+	b := NewSet([]int{2, 3, 500, 1000, 5000})
+	// The same as `a`, but with the items of `b` as its universe
+	c := a.Copy()
+	c.SetUniverse(b)
+
+	testCases := []struct {
+		desc   string
+		result func() Set
+	}{
+		{desc: "Union", result: func() Set { return a.Union(b) }},
+		{desc: "Intersection", result: func() Set { return a.Intersection(b) }},
+		{desc: "Difference", result: func() Set { return a.Difference(b) }},
+		{desc: "SymmetricDifference", result: func() Set { return a.SymmetricDifference(b) }},
+		{desc: "UnionOf", result: func() Set { return UnionOf(a, b) }},
+		{desc: "IntersectionOf", result: func() Set { return IntersectionOf(a, b) }},
+		{desc: "UnionInto", result: func() Set { var dst Set; UnionInto(&dst, a, b); return dst }},
+		{desc: "IntersectionInto", result: func() Set { var dst Set; IntersectionInto(&dst, a, b); return dst }},
+		{desc: "DifferenceInto", result: func() Set { var dst Set; DifferenceInto(&dst, a, b); return dst }},
+		{desc: "SymmetricDifferenceInto", result: func() Set { var dst Set; SymmetricDifferenceInto(&dst, a, b); return dst }},
+		{desc: "Not", result: func() Set { got, _ := a.Not(); return got }},
+		{desc: "Not within a set", result: func() Set { got, _ := c.Not(); return got }},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got := tC.result()
+			if !got.HasUniverse() || !got.pop_smallest {
+				t.Errorf("got universe %v and pop smallest %v; want both from the receiver", got.HasUniverse(), got.pop_smallest)
+			}
+		})
+	}
+
+	// With the operands swapped, the receiver has neither
+	if got := b.Union(a); got.HasUniverse() || got.pop_smallest {
+		t.Errorf("got universe %v and pop smallest %v; want neither", got.HasUniverse(), got.pop_smallest)
+	}
+	var dst Set
+	IntersectionInto(&dst, b, a)
+	if dst.HasUniverse() || dst.pop_smallest {
+		t.Errorf("got universe %v and pop smallest %v; want neither", dst.HasUniverse(), dst.pop_smallest)
+	}
+}
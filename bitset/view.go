@@ -0,0 +1,187 @@
+package bitset
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+	"sort"
+)
+
+var (
+	// This error is returned by `NewView` when the bytes are not in the view layout
+	ErrInvalidView = errors.New("bitset: invalid view")
+)
+
+// The layout version written by `AppendView`
+const view_version = 1
+
+var view_magic = [4]byte{'G', 'B', 'V', 'W'}
+
+// view_header_len is the magic number, version, 3 bytes of padding, and the word count
+const view_header_len = 16
+
+// View is a read-only set that works directly on the bytes written by `AppendView` or
+// `WriteView`, without decoding them into a Set first. The bytes can come from a file
+// that has been memory mapped (see `OpenView`), so a very large set can be shared
+// between processes with no load time.
+//
+// The layout is a 16 byte header, followed by the word indexes as little endian int64s
+// in ascending order, and then the words as little endian uint64s in the same order.
+// Word `k` holds the numbers 64*k through 64*k + 63, as in `MarshalCBOR`. Fixed width
+// entries let `Contains` binary search the indexes in place.
+type View struct {
+	indexes []byte
+	words   []byte
+	n       int
+}
+
+// AppendView appends the set to `dst` in the layout read by `NewView`
+func (s *Set) AppendView(dst []byte) []byte {
+	keys := sorted_keys(s.data)
+
+	dst = append(dst, view_magic[:]...)
+	dst = append(dst, view_version, 0, 0, 0)
+	dst = binary.LittleEndian.AppendUint64(dst, uint64(len(keys)))
+	for _, k := range keys {
+		dst = binary.LittleEndian.AppendUint64(dst, uint64(k))
+	}
+	for _, k := range keys {
+		dst = binary.LittleEndian.AppendUint64(dst, s.data[k])
+	}
+	return dst
+}
+
+// WriteView writes the set to `w` in the layout read by `NewView`
+func (s *Set) WriteView(w io.Writer) error {
+	_, err := w.Write(s.AppendView(nil))
+	return err
+}
+
+// NewView will return a View over `data`, which must be in the layout written by
+// `AppendView`. Only the header and length are checked, not that the indexes are
+// sorted, so this takes the same time however large the set is. The View keeps using
+// `data`, which must not be changed while the View is in use.
+func NewView(data []byte) (View, error) {
+	if len(data) < view_header_len || [4]byte(data[:4]) != view_magic {
+		return View{}, ErrInvalidView
+	}
+	if data[4] != view_version {
+		return View{}, ErrInvalidView
+	}
+	n := binary.LittleEndian.Uint64(data[8:16])
+	body := data[view_header_len:]
+	if len(body)%16 != 0 || uint64(len(body)/16) != n {
+		return View{}, ErrInvalidView
+	}
+
+	return View{
+		indexes: body[:8*n],
+		words:   body[8*n:],
+		n:       int(n),
+	}, nil
+}
+
+func (v *View) index(i int) int64 {
+	return int64(binary.LittleEndian.Uint64(v.indexes[8*i:]))
+}
+
+func (v *View) word(i int) uint64 {
+	return binary.LittleEndian.Uint64(v.words[8*i:])
+}
+
+// find returns the position of word index `k`, or -1 if it is not in the view
+func (v *View) find(k int64) int {
+	i := sort.Search(v.n, func(i int) bool { return v.index(i) >= k })
+	if i < v.n && v.index(i) == k {
+		return i
+	}
+	return -1
+}
+
+// Contains will return true if the view contains the item
+func (v *View) Contains(item int) bool {
+	i := v.find(int64(item >> 6))
+	return i >= 0 && v.word(i)&(1<<uint(item&63)) != 0
+}
+
+// Len returns the number of items in the view. It has to count every word.
+func (v *View) Len() int {
+	result := 0
+	for i := 0; i < v.n; i++ {
+		result += bits.OnesCount64(v.word(i))
+	}
+	return result
+}
+
+// IsEmpty returns true if the view holds no items
+func (v *View) IsEmpty() bool {
+	for i := 0; i < v.n; i++ {
+		if v.word(i) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Iterate calls `f` on every item in ascending order. It stops early if `f` returns
+// false.
+func (v *View) Iterate(f func(item int) bool) {
+	for i := 0; i < v.n; i++ {
+		base := int(v.index(i)) * 64
+		for w := v.word(i); w != 0; w &= w - 1 {
+			if !f(base + bits.TrailingZeros64(w)) {
+				return
+			}
+		}
+	}
+}
+
+// Slice will return all the items in the view as a slice, in ascending order
+func (v *View) Slice() []int {
+	result := make([]int, 0)
+	v.Iterate(func(item int) bool {
+		result = append(result, item)
+		return true
+	})
+	return result
+}
+
+// ToSet will decode the view into a new Set that can be changed
+func (v *View) ToSet() Set {
+	return NewSet(v.Slice())
+}
+
+// Intersection will create a new Set, and fill it with the items in both `v` and `t`.
+// The sorted indexes of the two views are walked together, so neither is decoded, and
+// each pair of matching words is ANDed into the result whole.
+func (v *View) Intersection(t View) Set {
+	result := NewSet([]int{})
+	i, j := 0, 0
+	for i < v.n && j < t.n {
+		vk, tk := v.index(i), t.index(j)
+		switch {
+		case vk < tk:
+			i += 1
+		case vk > tk:
+			j += 1
+		default:
+			result.set_word(vk, v.word(i)&t.word(j))
+			i += 1
+			j += 1
+		}
+	}
+	return result
+}
+
+// IntersectionSet will create a new Set, and fill it with the items in both `v` and `t`
+func (v *View) IntersectionSet(t Set) Set {
+	result := NewSet([]int{})
+	t.Iterate(func(item int) bool {
+		if v.Contains(item) {
+			result.Add(item)
+		}
+		return true
+	})
+	return result
+}
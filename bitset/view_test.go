@@ -0,0 +1,86 @@
+package bitset
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestView(t *testing.T) {
+	items := []int{-1000, -65, -64, -1, 0, 1, 63, 64, 500, 1 << 40}
+	s := NewSet(items)
+	v, err := NewView(s.AppendView(nil))
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	for _, item := range items {
+		if !v.Contains(item) {
+			t.Errorf("view should contain %d", item)
+		}
+	}
+	for _, item := range []int{-2, 2, 65, 1<<40 + 1} {
+		if v.Contains(item) {
+			t.Errorf("view should not contain %d", item)
+		}
+	}
+
+	if v.Len() != len(items) {
+		t.Errorf("got length %d, want %d", v.Len(), len(items))
+	}
+	if got := v.Slice(); !slices.Equal(got, items) {
+		t.Errorf("got %v; want %v", got, items)
+	}
+	if got := v.ToSet(); !got.Equals(s) {
+		t.Errorf("got %v; want %v", got, s)
+	}
+}
+
+func TestViewIntersection(t *testing.T) {
+	s1 := NewSet([]int{-70, 1, 2, 100, 1000})
+	// 65 shares a word with 100 but no bits, so that word must not be kept
+	s2 := NewSet([]int{-70, 2, 3, 65, 1000, 5000})
+	want := s1.Intersection(s2)
+
+	v1, err := NewView(s1.AppendView(nil))
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	v2, err := NewView(s2.AppendView(nil))
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	got := v1.Intersection(v2)
+	if !got.Equals(want) || got.Len() != want.Len() {
+		t.Errorf("got %v; want %v", got, want)
+	}
+	if got.Stats().Words != want.Stats().Words {
+		t.Errorf("got %d words; want %d", got.Stats().Words, want.Stats().Words)
+	}
+	if got := v1.IntersectionSet(s2); !got.Equals(want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestNewViewErrors(t *testing.T) {
+	s := NewSet([]int{1, 2, 3})
+	good := s.AppendView(nil)
+
+	testCases := []struct {
+		desc string
+		data []byte
+	}{
+		{desc: "empty", data: nil},
+		{desc: "bad magic", data: append([]byte("NOPE"), good[4:]...)},
+		{desc: "truncated", data: good[:len(good)-1]},
+		{desc: "too long", data: append(slices.Clone(good), 0)},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if _, err := NewView(tC.data); err != ErrInvalidView {
+				t.Errorf("got error %v, want %v", err, ErrInvalidView)
+			}
+		})
+	}
+}
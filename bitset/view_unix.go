@@ -0,0 +1,39 @@
+//go:build unix
+
+package bitset
+
+import (
+	"os"
+	"syscall"
+)
+
+// OpenView memory maps the file at `path`, which must hold a set written by
+// `WriteView`, and returns a View over it. The pages are shared with every other
+// process mapping the same file. Call the returned function to unmap the file once the
+// View is no longer needed.
+func OpenView(path string) (View, func() error, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return View{}, nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return View{}, nil, err
+	}
+	if info.Size() == 0 {
+		return View{}, nil, ErrInvalidView
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(info.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return View{}, nil, err
+	}
+	v, err := NewView(data)
+	if err != nil {
+		syscall.Munmap(data)
+		return View{}, nil, err
+	}
+	return v, func() error { return syscall.Munmap(data) }, nil
+}
@@ -0,0 +1,32 @@
+//go:build unix
+
+package bitset
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenView(t *testing.T) {
+	s := NewSet([]int{-5, 3, 4000})
+	path := filepath.Join(t.TempDir(), "set.view")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.WriteView(f); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	v, unmap, err := OpenView(path)
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	defer unmap()
+
+	if got := v.ToSet(); !got.Equals(s) {
+		t.Errorf("got %v; want %v", got, s)
+	}
+}
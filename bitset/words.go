@@ -0,0 +1,35 @@
+//go:build go1.23
+
+// Words and FromWords use range-over-func iterators, which need go1.23 or later. The
+// rest of the package still builds with go1.21.
+
+package bitset
+
+import (
+	"iter"
+)
+
+// Words returns an iterator over the raw words of the set, as pairs of word index and
+// word. Word `k` holds the numbers 64*k through 64*k + 63, and bit `i` is set if 64*k + i
+// is in the set. The words are in ascending order of index, and empty words are left
+// out. Use it to hand the set to another bitmap library without listing every item.
+func (s *Set) Words() iter.Seq2[int64, uint64] {
+	return func(yield func(int64, uint64) bool) {
+		for _, k := range sorted_keys(s.data) {
+			if !yield(k, s.data[k]) {
+				return
+			}
+		}
+	}
+}
+
+// FromWords will return a Set holding the words given by `words`, in the form returned
+// by `Words`. They can be in any order. If a word index is repeated, the words are
+// combined.
+func FromWords(words iter.Seq2[int64, uint64]) Set {
+	result := NewSet([]int{})
+	for k, w := range words {
+		result.set_word(k, result.data[k]|w)
+	}
+	return result
+}
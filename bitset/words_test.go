@@ -0,0 +1,80 @@
+//go:build go1.23
+
+package bitset
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestWords(t *testing.T) {
+	type pair struct {
+		k int64
+		w uint64
+	}
+	s := NewSet([]int{-65, -1, 0, 3, 64, 1000})
+	want := []pair{
+		{k: -2, w: 1 << 63},
+		{k: -1, w: 1 << 63},
+		{k: 0, w: 0b1001},
+		{k: 1, w: 1},
+		{k: 15, w: 1 << 40},
+	}
+
+	got := []pair{}
+	for k, w := range s.Words() {
+		got = append(got, pair{k: k, w: w})
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	// Stops early
+	calls := 0
+	for range s.Words() {
+		calls += 1
+		break
+	}
+	if calls != 1 {
+		t.Errorf("Words kept going after yield returned false, called %d times", calls)
+	}
+}
+
+func TestFromWords(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		items []int
+	}{
+		{desc: "empty", items: []int{}},
+		{desc: "one word", items: []int{0, 1, 63}},
+		{desc: "across zero", items: []int{-130, -64, -1, 0, 1, 63, 64, 1000}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			want := NewSet(tC.items)
+			got := FromWords(want.Words())
+			if !got.Equals(want) {
+				t.Errorf("got %v; want %v", got, want)
+			}
+			if got.Len() != want.Len() {
+				t.Errorf("got length %d; want %d", got.Len(), want.Len())
+			}
+		})
+	}
+}
+
+func TestFromWordsCombines(t *testing.T) {
+	pairs := [][2]uint64{{0, 0b01}, {0, 0b10}, {3, 0}, {1, 1}}
+	got := FromWords(func(yield func(int64, uint64) bool) {
+		for _, p := range pairs {
+			if !yield(int64(p[0]), p[1]) {
+				return
+			}
+		}
+	})
+	want := NewSet([]int{0, 1, 64})
+	if !got.Equals(want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
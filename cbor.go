@@ -0,0 +1,58 @@
+package set
+
+import (
+	"fmt"
+
+	"github.com/natemcintosh/set/internal/cbor"
+)
+
+// MarshalCBOR encodes the set as a CBOR array of its items, in no particular order. The
+// item type must have a boolean, integer, float, or string as its underlying type.
+func (s Set[T]) MarshalCBOR() ([]byte, error) {
+	result := cbor.AppendHead(nil, cbor.MajorArray, uint64(s.Len()))
+	w := scalar_writer{
+		Bool:   func(v bool) { result = cbor.AppendBool(result, v) },
+		Int:    func(v int64) { result = cbor.AppendInt(result, v) },
+		Uint:   func(v uint64) { result = cbor.AppendUint(result, v) },
+		Float:  func(v float64) { result = cbor.AppendFloat(result, v) },
+		String: func(v string) { result = cbor.AppendText(result, v) },
+	}
+	for v := range s.data {
+		if !write_scalar(v, w) {
+			return nil, fmt.Errorf("set: cannot encode %T as CBOR", v)
+		}
+	}
+	return result, nil
+}
+
+// UnmarshalCBOR replaces the contents of the set with the items of a CBOR array. See
+// `MarshalCBOR` for the supported item types.
+func (s *Set[T]) UnmarshalCBOR(data []byte) error {
+	d := cbor.NewDecoder(data)
+	n, err := d.ReadArrayHeader()
+	if err != nil {
+		return err
+	}
+
+	r := scalar_reader{
+		Bool:   d.ReadBool,
+		Int:    func(int) (int64, error) { return d.ReadInt() },
+		Uint:   func(int) (uint64, error) { return d.ReadUint() },
+		Float:  func(int) (float64, error) { return d.ReadFloat() },
+		String: d.ReadText,
+	}
+	result := make(map[T]struct{}, n)
+	for i := 0; i < n; i++ {
+		item, err := read_scalar[T](r, "CBOR")
+		if err != nil {
+			return err
+		}
+		result[item] = struct{}{}
+	}
+	if !d.Done() {
+		return cbor.ErrMalformed
+	}
+
+	s.data = result
+	return nil
+}
@@ -0,0 +1,109 @@
+package set
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCBORRoundTrip(t *testing.T) {
+	ints := NewSet([]int{-1000, -1, 0, 1, 23, 24, 255, 256, 1 << 40})
+	data, err := ints.MarshalCBOR()
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	var got_ints Set[int]
+	if err := got_ints.UnmarshalCBOR(data); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if !got_ints.Equals(ints) {
+		t.Errorf("got %v; want %v", got_ints, ints)
+	}
+
+	strs := NewSet([]string{"", "a", "hello, world"})
+	data, err = strs.MarshalCBOR()
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	var got_strs Set[string]
+	if err := got_strs.UnmarshalCBOR(data); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if !got_strs.Equals(strs) {
+		t.Errorf("got %v; want %v", got_strs, strs)
+	}
+
+	floats := NewSet([]float64{-1.5, 0, 3.14})
+	data, err = floats.MarshalCBOR()
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	var got_floats Set[float64]
+	if err := got_floats.UnmarshalCBOR(data); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if !got_floats.Equals(floats) {
+		t.Errorf("got %v; want %v", got_floats, floats)
+	}
+}
+
+func TestCBOREncoding(t *testing.T) {
+	// A single item set has only one possible encoding
+	testCases := []struct {
+		desc string
+		got  func() ([]byte, error)
+		want []byte
+	}{
+		{
+			desc: "empty",
+			got:  NewSet([]int{}).MarshalCBOR,
+			want: []byte{0x80},
+		},
+		{
+			desc: "small int",
+			got:  NewSet([]int{10}).MarshalCBOR,
+			want: []byte{0x81, 0x0a},
+		},
+		{
+			desc: "negative int",
+			got:  NewSet([]int{-500}).MarshalCBOR,
+			want: []byte{0x81, 0x39, 0x01, 0xf3},
+		},
+		{
+			desc: "string",
+			got:  NewSet([]string{"IETF"}).MarshalCBOR,
+			want: []byte{0x81, 0x64, 0x49, 0x45, 0x54, 0x46},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := tC.got()
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if !bytes.Equal(got, tC.want) {
+				t.Errorf("got %x; want %x", got, tC.want)
+			}
+		})
+	}
+}
+
+func TestCBORErrors(t *testing.T) {
+	type point struct{ x, y int }
+	s := NewSet([]point{{1, 2}})
+	if _, err := s.MarshalCBOR(); err == nil {
+		t.Errorf("encoding a struct should fail")
+	}
+
+	var small Set[int8]
+	if err := small.UnmarshalCBOR([]byte{0x81, 0x19, 0x01, 0x00}); err == nil {
+		t.Errorf("256 should overflow an int8")
+	}
+
+	var ints Set[int]
+	if err := ints.UnmarshalCBOR([]byte{0x82, 0x01}); err == nil {
+		t.Errorf("truncated input should fail")
+	}
+	if err := ints.UnmarshalCBOR([]byte{0x81, 0x61, 0x61}); err == nil {
+		t.Errorf("a string should not decode into an int")
+	}
+}
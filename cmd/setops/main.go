@@ -0,0 +1,264 @@
+// setops treats files of newline-delimited items as sets, and prints the result of a set
+// operation on them, one item per line.
+//
+//	setops union FILE...
+//	setops intersect FILE...
+//	setops diff FILE...
+//	setops symdiff FILE...
+//	setops contains SETFILE [ITEM...]
+//
+// Leading and trailing whitespace is trimmed from each line, and blank lines are
+// skipped. A FILE of "-" reads from standard input. Items are printed in the order they
+// are first seen, and each is printed once.
+//
+// `union` streams every file and prints each item the first time it appears. `diff`
+// prints the items of the first file that are in none of the others, and `intersect` the
+// items of the first file that are in all of the others. Only the other files are held
+// in memory, so put the largest file first. `symdiff` prints the items that are in an
+// odd number of the files.
+//
+// `contains` prints each ITEM that is in SETFILE, reading the items from standard input
+// if none are given, and exits with status 1 if any of them was not.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/natemcintosh/set"
+)
+
+const usage = `usage:
+	setops union FILE...
+	setops intersect FILE...
+	setops diff FILE...
+	setops symdiff FILE...
+	setops contains SETFILE [ITEM...]`
+
+// err_some_missing is returned by `contains` when at least one item was not in the set.
+// Nothing is printed for it, only the exit status changes.
+var err_some_missing = errors.New("some items are not in the set")
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout)
+	switch {
+	case errors.Is(err, err_some_missing):
+		os.Exit(1)
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "setops:", err)
+		os.Exit(2)
+	}
+}
+
+// run carries out the subcommand in `args`, reading "-" from `stdin`, and writing the
+// result to `stdout`
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) < 2 {
+		return errors.New(usage)
+	}
+
+	out := bufio.NewWriter(stdout)
+	o := ops{stdin: stdin, out: out}
+	var err error
+	switch cmd, files := args[0], args[1:]; cmd {
+	case "union":
+		err = o.union(files)
+	case "intersect":
+		err = o.intersect(files)
+	case "diff":
+		err = o.diff(files)
+	case "symdiff":
+		err = o.symdiff(files)
+	case "contains":
+		err = o.contains(files[0], files[1:])
+	default:
+		return fmt.Errorf("unknown command %q\n%s", cmd, usage)
+	}
+
+	if flush_err := out.Flush(); err == nil {
+		err = flush_err
+	}
+	return err
+}
+
+type ops struct {
+	stdin io.Reader
+	out   *bufio.Writer
+	// stdin_used is set once "-" has been read, as it can only be read once
+	stdin_used bool
+}
+
+// open returns a reader for `name`, with "-" meaning standard input
+func (o *ops) open(name string) (io.ReadCloser, error) {
+	if name != "-" {
+		return os.Open(name)
+	}
+	if o.stdin_used {
+		return nil, errors.New("standard input can only be read once")
+	}
+	o.stdin_used = true
+	return io.NopCloser(o.stdin), nil
+}
+
+// each_line calls `f` on every non-blank line of `name`, trimmed, as it is read
+func (o *ops) each_line(name string, f func(line string)) error {
+	r, err := o.open(name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			f(line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// read returns the lines of `name` as a set
+func (o *ops) read(name string) (set.Set[string], error) {
+	r, err := o.open(name)
+	if err != nil {
+		return set.Set[string]{}, err
+	}
+	defer r.Close()
+
+	result, err := set.ReadLines(r)
+	if err != nil {
+		return set.Set[string]{}, fmt.Errorf("%s: %w", name, err)
+	}
+	return result, nil
+}
+
+// stream prints each line of `name` that `keep` returns true for, the first time it
+// appears
+func (o *ops) stream(name string, printed *set.Set[string], keep func(line string) bool) error {
+	return o.each_line(name, func(line string) {
+		if !printed.Contains(line) && keep(line) {
+			printed.Add(line)
+			fmt.Fprintln(o.out, line)
+		}
+	})
+}
+
+func (o *ops) union(files []string) error {
+	printed := set.NewSet([]string{})
+	for _, name := range files {
+		if err := o.stream(name, &printed, func(string) bool { return true }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *ops) intersect(files []string) error {
+	// Intersect the other files, starting from the smallest, then stream the first
+	var others []set.Set[string]
+	for _, name := range files[1:] {
+		s, err := o.read(name)
+		if err != nil {
+			return err
+		}
+		others = append(others, s)
+	}
+	keep := func(string) bool { return true }
+	if len(others) > 0 {
+		smallest := 0
+		for i, s := range others {
+			if s.Len() < others[smallest].Len() {
+				smallest = i
+			}
+		}
+		common := others[smallest].Copy()
+		for _, s := range others {
+			common.IntersectionInPlace(s)
+		}
+		keep = common.Contains
+	}
+
+	printed := set.NewSet([]string{})
+	return o.stream(files[0], &printed, keep)
+}
+
+func (o *ops) diff(files []string) error {
+	removed := set.NewSet([]string{})
+	for _, name := range files[1:] {
+		s, err := o.read(name)
+		if err != nil {
+			return err
+		}
+		removed.UnionInPlace(s)
+	}
+
+	printed := set.NewSet([]string{})
+	return o.stream(files[0], &printed, func(line string) bool {
+		return !removed.Contains(line)
+	})
+}
+
+func (o *ops) symdiff(files []string) error {
+	// Toggle each file's items in and out of `odd`, remembering the order they were
+	// first seen in
+	odd := set.NewSet([]string{})
+	seen := set.NewSet([]string{})
+	var order []string
+	for _, name := range files {
+		file := set.NewSet([]string{})
+		err := o.each_line(name, func(line string) {
+			file.Add(line)
+			if !seen.Contains(line) {
+				seen.Add(line)
+				order = append(order, line)
+			}
+		})
+		if err != nil {
+			return err
+		}
+		odd.SymmetricDifferenceInPlace(file)
+	}
+
+	for _, item := range order {
+		if odd.Contains(item) {
+			fmt.Fprintln(o.out, item)
+		}
+	}
+	return nil
+}
+
+func (o *ops) contains(set_file string, items []string) error {
+	s, err := o.read(set_file)
+	if err != nil {
+		return err
+	}
+
+	missing := false
+	check := func(item string) {
+		if s.Contains(item) {
+			fmt.Fprintln(o.out, item)
+		} else {
+			missing = true
+		}
+	}
+	if len(items) == 0 {
+		if err := o.each_line("-", check); err != nil {
+			return err
+		}
+	}
+	for _, item := range items {
+		check(item)
+	}
+
+	if missing {
+		return err_some_missing
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// write_files writes each of `contents` to its own file in a temporary directory, and
+// returns their paths
+func write_files(t *testing.T, contents ...string) []string {
+	dir := t.TempDir()
+	paths := make([]string, len(contents))
+	for i, c := range contents {
+		paths[i] = filepath.Join(dir, string(rune('a'+i))+".txt")
+		if err := os.WriteFile(paths[i], []byte(c), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return paths
+}
+
+func TestRun(t *testing.T) {
+	files := write_files(t,
+		"apple\nbanana\n\n  cherry  \napple\ndate\n",
+		"banana\ndate\nfig\n",
+		"date\nfig\ngrape\nbanana\n",
+	)
+	testCases := []struct {
+		desc string
+		args []string
+		want string
+	}{
+		{desc: "union", args: []string{"union", files[0], files[1]}, want: "apple\nbanana\ncherry\ndate\nfig\n"},
+		{desc: "intersect", args: []string{"intersect", files[0], files[1], files[2]}, want: "banana\ndate\n"},
+		{desc: "intersect one file", args: []string{"intersect", files[0]}, want: "apple\nbanana\ncherry\ndate\n"},
+		{desc: "diff", args: []string{"diff", files[0], files[1]}, want: "apple\ncherry\n"},
+		{desc: "diff many", args: []string{"diff", files[2], files[0], files[1]}, want: "grape\n"},
+		{desc: "symdiff", args: []string{"symdiff", files[0], files[1]}, want: "apple\ncherry\nfig\n"},
+		{desc: "symdiff three", args: []string{"symdiff", files[0], files[1], files[2]}, want: "apple\nbanana\ncherry\ndate\ngrape\n"},
+		{desc: "contains", args: []string{"contains", files[1], "fig", "banana"}, want: "fig\nbanana\n"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var out strings.Builder
+			if err := run(tC.args, strings.NewReader(""), &out); err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if got := out.String(); got != tC.want {
+				t.Errorf("got %q; want %q", got, tC.want)
+			}
+		})
+	}
+}
+
+func TestRunStdin(t *testing.T) {
+	files := write_files(t, "a\nb\nc\n")
+
+	var out strings.Builder
+	if err := run([]string{"diff", "-", files[0]}, strings.NewReader("c\nd\na\ne\n"), &out); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if got, want := out.String(), "d\ne\n"; got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+
+	// Reading stdin twice is an error
+	if err := run([]string{"union", "-", "-"}, strings.NewReader("a\n"), &out); err == nil {
+		t.Errorf("reading standard input twice should fail")
+	}
+}
+
+func TestRunContainsMissing(t *testing.T) {
+	files := write_files(t, "a\nb\n")
+
+	var out strings.Builder
+	err := run([]string{"contains", files[0]}, strings.NewReader("a\nz\nb\n"), &out)
+	if !errors.Is(err, err_some_missing) {
+		t.Errorf("got error %v, want %v", err, err_some_missing)
+	}
+	if got, want := out.String(), "a\nb\n"; got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	testCases := []struct {
+		desc string
+		args []string
+	}{
+		{desc: "no arguments", args: []string{}},
+		{desc: "no files", args: []string{"union"}},
+		{desc: "unknown command", args: []string{"join", "a.txt"}},
+		{desc: "missing file", args: []string{"union", filepath.Join(t.TempDir(), "missing.txt")}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var out strings.Builder
+			if err := run(tC.args, strings.NewReader(""), &out); err == nil {
+				t.Errorf("got nil error for %v", tC.args)
+			}
+		})
+	}
+}
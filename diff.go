@@ -0,0 +1,57 @@
+package set
+
+import (
+	"strings"
+)
+
+// DiffString describes how `got` differs from `want`, such as
+// "missing: {3, 4} unexpected: {9}", for test failure messages that stay readable when
+// the sets are large. Only the parts that differ are printed, and "" means the sets are
+// equal. Items are sorted the same way as `GoString` sorts them, and printed with the
+// formatter of the set they came from. Each part prints at most `DefaultStringLimit`
+// items, summing up the rest as "… and N more".
+func DiffString[T comparable](got, want Set[T]) string {
+	missing := want.Difference(got)
+	unexpected := got.Difference(want)
+
+	var parts []string
+	if !missing.IsEmpty() {
+		parts = append(parts, "missing: "+render_sorted(missing))
+	}
+	if !unexpected.IsEmpty() {
+		parts = append(parts, "unexpected: "+render_sorted(unexpected))
+	}
+	return strings.Join(parts, " ")
+}
+
+// render_sorted prints the items of `s` in order, the same way as `String`
+func render_sorted[T comparable](s Set[T]) string {
+	return render_items(s, sorted_any(s), DefaultStringLimit)
+}
+
+// render_items prints `items`, which are those of `s` in the order to print them, with
+// the formatter of `s`. At most `limit` are printed, and the rest are summed up as
+// "… and N more". A limit of 0 or less prints every item.
+func render_items[T comparable](s Set[T], items []T, limit int) string {
+	if limit <= 0 || limit > len(items) {
+		limit = len(items)
+	}
+
+	var b strings.Builder
+	b.WriteString("{")
+	for idx, v := range items[:limit] {
+		if idx > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(s.format_item(v))
+	}
+	if remaining := len(items) - limit; remaining > 0 {
+		if limit > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("… and " + with_commas(remaining) + " more")
+	}
+	b.WriteString("}")
+
+	return b.String()
+}
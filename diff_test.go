@@ -0,0 +1,52 @@
+package set
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestDiffString(t *testing.T) {
+	testCases := []struct {
+		desc string
+		got  Set[int]
+		want Set[int]
+		str  string
+	}{
+		{desc: "equal", got: Of(1, 2), want: Of(2, 1), str: ""},
+		{desc: "both empty", got: Of[int](), want: Of[int](), str: ""},
+		{desc: "missing", got: Of(1), want: Of(4, 1, 3), str: "missing: {3, 4}"},
+		{desc: "unexpected", got: Of(10, 1, -5), want: Of(1), str: "unexpected: {-5, 10}"},
+		{desc: "both", got: Of(1, 2, 9), want: Of(1, 2, 3, 4), str: "missing: {3, 4} unexpected: {9}"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := DiffString(tC.got, tC.want); got != tC.str {
+				t.Errorf("got %q; want %q", got, tC.str)
+			}
+		})
+	}
+}
+
+func TestDiffStringLimit(t *testing.T) {
+	old_limit := DefaultStringLimit
+	defer func() { DefaultStringLimit = old_limit }()
+	DefaultStringLimit = 3
+
+	items := make([]int, 8)
+	for i := range items {
+		items[i] = i
+	}
+	got := DiffString(Of[int](), NewSet(items))
+
+	want := "missing: {0, 1, 2, … and 5 more}"
+	if got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+
+	// The formatter of the set each item came from is used
+	want_set := Of(7)
+	want_set.SetFormatter(func(i int) string { return "#" + strconv.Itoa(i) })
+	if got, want := DiffString(Of[int](), want_set), "missing: {#7}"; got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
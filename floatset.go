@@ -0,0 +1,207 @@
+package set
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	// This error is returned when adding NaN to a FloatSet using `RejectNaN`
+	ErrNaN = errors.New("NaN is not allowed in this set")
+)
+
+// NaNPolicy decides how a FloatSet handles NaN. NaN is not equal to itself, so in a
+// plain Set[float64] every NaN added becomes a new item that `Contains` can never find,
+// and a set holding one is not `Equals` to itself.
+type NaNPolicy int
+
+const (
+	// RejectNaN makes `Add` return ErrNaN instead of adding NaN
+	RejectNaN NaNPolicy = iota
+
+	// CanonicalNaN treats every NaN as the same single item
+	CanonicalNaN
+)
+
+// FloatSet is a set of floats with a chosen policy for NaN. It behaves like Set for
+// every other number.
+type FloatSet[T ~float32 | ~float64] struct {
+	set     Set[T]
+	policy  NaNPolicy
+	has_nan bool
+}
+
+// NewFloatSet will return a FloatSet from an input slice. With `RejectNaN`, an error is
+// returned if `data` holds NaN.
+func NewFloatSet[T ~float32 | ~float64, S ~[]T](data S, policy NaNPolicy) (FloatSet[T], error) {
+	result := FloatSet[T]{set: NewSetWithCapacity([]T{}, len(data)), policy: policy}
+	for _, v := range data {
+		if err := result.Add(v); err != nil {
+			return FloatSet[T]{}, err
+		}
+	}
+	return result, nil
+}
+
+func is_nan[T ~float32 | ~float64](v T) bool {
+	return v != v
+}
+
+// empty_like returns an empty set with the same policy as `s`
+func (s *FloatSet[T]) empty_like(size int) FloatSet[T] {
+	return FloatSet[T]{set: NewSetWithCapacity([]T{}, size), policy: s.policy}
+}
+
+func (s FloatSet[T]) String() string {
+	if !s.has_nan {
+		return s.set.String()
+	}
+	with_nan := s.set.Copy()
+	with_nan.Add(T(math.NaN()))
+	return with_nan.String()
+}
+
+// Policy returns how the set handles NaN
+func (s *FloatSet[T]) Policy() NaNPolicy {
+	return s.policy
+}
+
+// Slice will return all the items in the set as a slice. They are not guaranteed in any
+// particular order. With `CanonicalNaN`, a NaN in the set appears once.
+func (s *FloatSet[T]) Slice() []T {
+	result := s.set.Slice()
+	if s.has_nan {
+		result = append(result, T(math.NaN()))
+	}
+	return result
+}
+
+// Iterate calls `f` on every item in the set, in no particular order. It stops early if
+// `f` returns false.
+func (s *FloatSet[T]) Iterate(f func(item T) bool) {
+	keep_going := true
+	s.set.Iterate(func(v T) bool {
+		keep_going = f(v)
+		return keep_going
+	})
+	if keep_going && s.has_nan {
+		f(T(math.NaN()))
+	}
+}
+
+// Contains will return true if the set contains the item. With `CanonicalNaN`, any NaN
+// is found if a NaN was added.
+func (s *FloatSet[T]) Contains(item T) bool {
+	if is_nan(item) {
+		return s.has_nan
+	}
+	return s.set.Contains(item)
+}
+
+// Len returns the length of the set
+func (s *FloatSet[T]) Len() int {
+	if s.has_nan {
+		return s.set.Len() + 1
+	}
+	return s.set.Len()
+}
+
+// IsEmpty returns true if the set is empty
+func (s *FloatSet[T]) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+// Add will add a new item to `s`. If it already exists, it is ignored. Returns ErrNaN
+// if the item is NaN and the policy is `RejectNaN`.
+func (s *FloatSet[T]) Add(item T) error {
+	if is_nan(item) {
+		if s.policy == RejectNaN {
+			return ErrNaN
+		}
+		s.has_nan = true
+		return nil
+	}
+	s.set.Add(item)
+	return nil
+}
+
+// Remove removes an item from the set. Returns a *NotFoundError if the item doesn't
+// exist.
+func (s *FloatSet[T]) Remove(item T) error {
+	if !s.Contains(item) {
+		return &NotFoundError[T]{Item: item}
+	}
+	s.Discard(item)
+	return nil
+}
+
+// Discard removes an item from the set. If it doesn't exist, it is ignored
+func (s *FloatSet[T]) Discard(item T) {
+	if is_nan(item) {
+		s.has_nan = false
+		return
+	}
+	s.set.Discard(item)
+}
+
+// Clear will remove all items from the set
+func (s *FloatSet[T]) Clear() {
+	s.set.Clear()
+	s.has_nan = false
+}
+
+// Copy makes a deep copy, with the same policy
+func (s *FloatSet[T]) Copy() FloatSet[T] {
+	return FloatSet[T]{set: s.set.Copy(), policy: s.policy, has_nan: s.has_nan}
+}
+
+// Equals will return true if `s` and `t` contain the same items. With `CanonicalNaN`,
+// a set holding NaN is equal to itself.
+func (s *FloatSet[T]) Equals(t FloatSet[T]) bool {
+	return s.has_nan == t.has_nan && s.set.Equals(t.set)
+}
+
+// Union will create a new FloatSet, and fill it with the union of `s` and `t`. The
+// result has the policy of `s`, so NaN from `t` is left out if it is `RejectNaN`.
+func (s *FloatSet[T]) Union(t FloatSet[T]) FloatSet[T] {
+	result := s.empty_like(0)
+	result.set = s.set.Union(t.set)
+	result.has_nan = s.policy == CanonicalNaN && (s.has_nan || t.has_nan)
+	return result
+}
+
+// Intersection will create a new FloatSet, and fill it with the intersection of `s`
+// and `t`
+func (s *FloatSet[T]) Intersection(t FloatSet[T]) FloatSet[T] {
+	result := s.empty_like(0)
+	result.set = s.set.Intersection(t.set)
+	result.has_nan = s.has_nan && t.has_nan
+	return result
+}
+
+// Difference returns a new set with elements in `s` that are not in `t`
+func (s *FloatSet[T]) Difference(t FloatSet[T]) FloatSet[T] {
+	result := s.empty_like(0)
+	result.set = s.set.Difference(t.set)
+	result.has_nan = s.has_nan && !t.has_nan
+	return result
+}
+
+// SymmetricDifference returns a new set with elements in either `s` or `t`, but not
+// both. The result has the policy of `s`.
+func (s *FloatSet[T]) SymmetricDifference(t FloatSet[T]) FloatSet[T] {
+	result := s.empty_like(0)
+	result.set = s.set.SymmetricDifference(t.set)
+	result.has_nan = s.policy == CanonicalNaN && s.has_nan != t.has_nan
+	return result
+}
+
+// IsSubsetOf tests whether every element in `s` is in `t`
+func (s *FloatSet[T]) IsSubsetOf(t FloatSet[T]) bool {
+	return (!s.has_nan || t.has_nan) && s.set.IsSubsetOf(t.set)
+}
+
+// IsSuperSetOf tests whether every element in `t` is in `s`
+func (s *FloatSet[T]) IsSuperSetOf(t FloatSet[T]) bool {
+	return t.IsSubsetOf(*s)
+}
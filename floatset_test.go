@@ -0,0 +1,70 @@
+package set
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFloatSetRejectNaN(t *testing.T) {
+	if _, err := NewFloatSet([]float64{1, math.NaN()}, RejectNaN); !errors.Is(err, ErrNaN) {
+		t.Errorf("got error %v, want %v", err, ErrNaN)
+	}
+
+	s, err := NewFloatSet([]float64{1, 2}, RejectNaN)
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if err := s.Add(math.NaN()); !errors.Is(err, ErrNaN) {
+		t.Errorf("got error %v, want %v", err, ErrNaN)
+	}
+	if s.Len() != 2 || s.Contains(math.NaN()) {
+		t.Errorf("got %v; NaN should not have been added", s)
+	}
+}
+
+func TestFloatSetCanonicalNaN(t *testing.T) {
+	s, err := NewFloatSet([]float64{1, math.NaN(), math.NaN()}, CanonicalNaN)
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	if s.Len() != 2 {
+		t.Errorf("got length %d, want 2", s.Len())
+	}
+	if !s.Contains(math.NaN()) {
+		t.Errorf("%v should contain NaN", s)
+	}
+	if !s.Equals(s.Copy()) {
+		t.Errorf("%v should equal itself", s)
+	}
+
+	other, _ := NewFloatSet([]float64{1}, CanonicalNaN)
+	testCases := []struct {
+		desc string
+		got  FloatSet[float64]
+		want int
+	}{
+		{desc: "union", got: s.Union(other), want: 2},
+		{desc: "intersection", got: s.Intersection(other), want: 1},
+		{desc: "difference", got: s.Difference(other), want: 1},
+		{desc: "symmetric difference", got: s.SymmetricDifference(other), want: 1},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if tC.got.Len() != tC.want {
+				t.Errorf("got %v; want %d items", tC.got, tC.want)
+			}
+		})
+	}
+	if !other.IsSubsetOf(s) || s.IsSubsetOf(other) {
+		t.Errorf("%v should be a proper subset of %v", other, s)
+	}
+
+	if err := s.Remove(math.NaN()); err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+	if s.Contains(math.NaN()) || !s.Equals(other) {
+		t.Errorf("got %v; want %v", s, other)
+	}
+}
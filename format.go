@@ -0,0 +1,59 @@
+package set
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format lets the set be printed with the fmt verbs
+//   - %v and %s print the same as `String`
+//   - %q quotes each item, so string items containing ", " can't be confused with the
+//     separator
+//   - %d prints each item as a number
+//   - %#v prints the same as `GoString`
+//
+// The precision is the most items to print, overriding `SetStringLimit`, so "%.5v"
+// prints at most 5 items. The width pads the output with spaces, on the left, or on the
+// right with the '-' flag.
+func (s Set[T]) Format(f fmt.State, verb rune) {
+	limit := s.display.limit
+	if limit == 0 {
+		limit = DefaultStringLimit
+	}
+	if limit == 0 {
+		limit = -1
+	}
+	if p, ok := f.Precision(); ok {
+		limit = p
+	}
+
+	var out string
+	switch verb {
+	case 'v':
+		if f.Flag('#') {
+			out = s.GoString()
+		} else {
+			out = s.render(limit, s.format_item)
+		}
+	case 's':
+		out = s.render(limit, s.format_item)
+	case 'q', 'd':
+		// Format each item with the same verb
+		item_format := "%" + string(verb)
+		out = s.render(limit, func(v T) string { return fmt.Sprintf(item_format, v) })
+	default:
+		out = fmt.Sprintf("%%!%c(set.Set=%s)", verb, s.render(limit, s.format_item))
+	}
+
+	if w, ok := f.Width(); ok {
+		if pad := w - len([]rune(out)); pad > 0 {
+			if f.Flag('-') {
+				out += strings.Repeat(" ", pad)
+			} else {
+				out = strings.Repeat(" ", pad) + out
+			}
+		}
+	}
+	io.WriteString(f, out)
+}
@@ -0,0 +1,50 @@
+package set
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFormatVerbs(t *testing.T) {
+	strs := Of("a, b")
+	nums := Of(42)
+
+	testCases := []struct {
+		desc   string
+		format string
+		arg    any
+		want   string
+	}{
+		{desc: "v", format: "%v", arg: nums, want: "{42}"},
+		{desc: "s", format: "%s", arg: strs, want: "{a, b}"},
+		{desc: "q keeps commas apart", format: "%q", arg: strs, want: `{"a, b"}`},
+		{desc: "d", format: "%d", arg: nums, want: "{42}"},
+		{desc: "go syntax", format: "%#v", arg: nums, want: "set.Of(42)"},
+		{desc: "width", format: "%6v", arg: nums, want: "  {42}"},
+		{desc: "left aligned", format: "%-6v|", arg: nums, want: "{42}  |"},
+		{desc: "no items", format: "%.0v", arg: nums, want: "{… and 1 more}"},
+		{desc: "unknown verb", format: "%x", arg: nums, want: "%!x(set.Set={42})"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := fmt.Sprintf(tC.format, tC.arg); got != tC.want {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+}
+
+func TestFormatPrecision(t *testing.T) {
+	s := Of(1, 2, 3, 4, 5)
+	got := fmt.Sprintf("%.2v", s)
+	if !strings.HasSuffix(got, ", … and 3 more}") || strings.Count(got, ", ") != 2 {
+		t.Errorf("got %v; want 2 items and then … and 3 more", got)
+	}
+
+	// The precision wins over the limit set on the set
+	s.SetStringLimit(1)
+	if got := fmt.Sprintf("%.5v", s); strings.Contains(got, "more") {
+		t.Errorf("got %v; want every item", got)
+	}
+}
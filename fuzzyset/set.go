@@ -0,0 +1,263 @@
+// fuzzyset is a set where every element carries a membership degree in [0, 1]. Union
+// takes the larger of the two degrees, and intersection takes the smaller. Use `Cut` to
+// get back a plain `github.com/natemcintosh/set` of the elements that clear a threshold.
+package fuzzyset
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/natemcintosh/set"
+)
+
+var (
+	// This error is returned when you try to remove an item from a set that doesn't
+	// exist. It is set.ErrElementNotFound, so errors.Is works the same for every set.
+	ErrElementNotFound = set.ErrElementNotFound
+
+	// This error is returned when a membership degree is outside of [0, 1]
+	ErrInvalidWeight = errors.New("weight must be between 0 and 1")
+)
+
+type Set[T comparable] struct {
+	// Only elements with a weight greater than 0 are stored
+	data map[T]float64
+}
+
+// NewSet will return a Set from a map of items to their membership degrees. Items with
+// a weight of 0 are not stored. Returns an error if any weight is outside of [0, 1]
+func NewSet[T comparable, M ~map[T]float64](data M) (Set[T], error) {
+	result := make(map[T]float64, len(data))
+
+	for v, w := range data {
+		if !valid_weight(w) {
+			return Set[T]{}, ErrInvalidWeight
+		}
+		if w > 0 {
+			result[v] = w
+		}
+	}
+
+	return Set[T]{data: result}, nil
+}
+
+// FromSet will return a Set where every item of `s` has a weight of 1
+func FromSet[T comparable](s set.Set[T]) Set[T] {
+	result := make(map[T]float64, s.Len())
+	for _, v := range s.Slice() {
+		result[v] = 1
+	}
+	return Set[T]{data: result}
+}
+
+func valid_weight(w float64) bool {
+	// Written this way so that NaN is rejected too
+	return w >= 0 && w <= 1
+}
+
+func (s Set[T]) String() string {
+	var b strings.Builder
+	last_index := s.Len() - 1
+	index := -1
+	b.WriteString("{")
+	for v, w := range s.data {
+		index += 1
+
+		if index < last_index {
+			b.WriteString(fmt.Sprintf("%v: %v, ", v, w))
+		} else {
+			b.WriteString(fmt.Sprintf("%v: %v", v, w))
+		}
+	}
+	b.WriteString("}")
+
+	return b.String()
+}
+
+// Weight will return the membership degree of the item. Items not in the set have a
+// weight of 0
+func (s *Set[T]) Weight(item T) float64 {
+	return s.data[item]
+}
+
+// Contains will return true if the item has a weight greater than 0
+func (s *Set[T]) Contains(item T) bool {
+	_, ok := s.data[item]
+	return ok
+}
+
+// Len returns the number of items with a weight greater than 0
+func (s *Set[T]) Len() int {
+	return len(s.data)
+}
+
+// IsEmpty returns true if the set is empty
+func (s *Set[T]) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+// Cardinality returns the sum of all the weights, sometimes called the sigma-count
+func (s *Set[T]) Cardinality() float64 {
+	total := 0.0
+	for _, w := range s.data {
+		total += w
+	}
+	return total
+}
+
+// Add will set the weight of an item, replacing any weight it already had. A weight of
+// 0 removes the item. Returns an error if the weight is outside of [0, 1]
+func (s *Set[T]) Add(item T, weight float64) error {
+	if !valid_weight(weight) {
+		return ErrInvalidWeight
+	}
+	if weight == 0 {
+		delete(s.data, item)
+		return nil
+	}
+	s.data[item] = weight
+	return nil
+}
+
+// Remove removes an item from the set. Returns an error if the item doesn't exist.
+// See `Discard` for method that does not return an error
+func (s *Set[T]) Remove(item T) error {
+	if !s.Contains(item) {
+		return ErrElementNotFound
+	}
+
+	delete(s.data, item)
+	return nil
+}
+
+// Discard removes an item from the set. If it doesn't exist, it is ignored
+func (s *Set[T]) Discard(item T) {
+	delete(s.data, item)
+}
+
+// Clear will remove all items from the set
+func (s *Set[T]) Clear() {
+	s.data = make(map[T]float64)
+}
+
+// Copy makes a deep copy as quickly as possible
+func (s *Set[T]) Copy() Set[T] {
+	copy := make(map[T]float64, len(s.data))
+
+	for v, w := range s.data {
+		copy[v] = w
+	}
+
+	return Set[T]{data: copy}
+}
+
+// Equals will return true if `s` and `t` hold the same items with the same weights
+func (s *Set[T]) Equals(t Set[T]) bool {
+	if s.Len() != t.Len() {
+		return false
+	}
+
+	for v, w := range s.data {
+		if tw, ok := t.data[v]; !ok || tw != w {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Union will create a new Set where each item has the larger of its weights in `s` and
+// `t`
+func (s *Set[T]) Union(t Set[T]) Set[T] {
+	result := s.Copy()
+	result.UnionInPlace(t)
+	return result
+}
+
+// UnionInPlace will raise the weight of each item in `s` to its weight in `t`, if that
+// is larger
+func (s *Set[T]) UnionInPlace(t Set[T]) {
+	for v, tw := range t.data {
+		if tw > s.data[v] {
+			s.data[v] = tw
+		}
+	}
+}
+
+// Intersection will create a new Set where each item has the smaller of its weights in
+// `s` and `t`
+func (s *Set[T]) Intersection(t Set[T]) Set[T] {
+	result := make(map[T]float64)
+
+	// Iterate over the smaller of the two sets. Anything missing from the other has a
+	// weight of 0, and so is not in the result
+	small, large := s.data, t.data
+	if len(t.data) < len(s.data) {
+		small, large = t.data, s.data
+	}
+	for v, sw := range small {
+		if lw, ok := large[v]; ok {
+			if lw < sw {
+				result[v] = lw
+			} else {
+				result[v] = sw
+			}
+		}
+	}
+
+	return Set[T]{data: result}
+}
+
+// IntersectionInPlace will lower the weight of each item in `s` to its weight in `t`,
+// if that is smaller
+func (s *Set[T]) IntersectionInPlace(t Set[T]) {
+	for v, sw := range s.data {
+		tw, ok := t.data[v]
+		if !ok {
+			delete(s.data, v)
+		} else if tw < sw {
+			s.data[v] = tw
+		}
+	}
+}
+
+// Complement will create a new Set where each item of `s` has a weight of 1 minus its
+// weight in `s`. Items with a weight of 1 are dropped, as their complement is 0
+func (s *Set[T]) Complement() Set[T] {
+	result := make(map[T]float64, len(s.data))
+	for v, w := range s.data {
+		if w < 1 {
+			result[v] = 1 - w
+		}
+	}
+	return Set[T]{data: result}
+}
+
+// Cut will return the crisp set of items with a weight of at least `threshold`
+func (s *Set[T]) Cut(threshold float64) set.Set[T] {
+	result := set.NewSet([]T{})
+	for v, w := range s.data {
+		if w >= threshold {
+			result.Add(v)
+		}
+	}
+	return result
+}
+
+// StrictCut will return the crisp set of items with a weight strictly greater than
+// `threshold`
+func (s *Set[T]) StrictCut(threshold float64) set.Set[T] {
+	result := set.NewSet([]T{})
+	for v, w := range s.data {
+		if w > threshold {
+			result.Add(v)
+		}
+	}
+	return result
+}
+
+// Support will return the crisp set of every item with a weight greater than 0
+func (s *Set[T]) Support() set.Set[T] {
+	return s.StrictCut(0)
+}
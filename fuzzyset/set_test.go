@@ -0,0 +1,134 @@
+package fuzzyset
+
+import (
+	"math"
+	"testing"
+
+	"github.com/natemcintosh/set"
+)
+
+func must[T comparable](t *testing.T, data map[T]float64) Set[T] {
+	t.Helper()
+	s, err := NewSet(data)
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	return s
+}
+
+func TestNewSet(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		in       map[string]float64
+		want_len int
+		want_err error
+	}{
+		{
+			desc:     "valid",
+			in:       map[string]float64{"a": 0.5, "b": 1},
+			want_len: 2,
+			want_err: nil,
+		},
+		{
+			desc:     "zero weights are dropped",
+			in:       map[string]float64{"a": 0.5, "b": 0},
+			want_len: 1,
+			want_err: nil,
+		},
+		{
+			desc:     "too large",
+			in:       map[string]float64{"a": 1.5},
+			want_err: ErrInvalidWeight,
+		},
+		{
+			desc:     "negative",
+			in:       map[string]float64{"a": -0.1},
+			want_err: ErrInvalidWeight,
+		},
+		{
+			desc:     "NaN",
+			in:       map[string]float64{"a": math.NaN()},
+			want_err: ErrInvalidWeight,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s, err := NewSet(tC.in)
+			if err != tC.want_err {
+				t.Errorf("got error %v, want %v", err, tC.want_err)
+			}
+			if err == nil && s.Len() != tC.want_len {
+				t.Errorf("got length %d, want %d", s.Len(), tC.want_len)
+			}
+		})
+	}
+}
+
+func TestUnionIntersection(t *testing.T) {
+	s1 := must(t, map[string]float64{"a": 0.2, "b": 0.9, "c": 0.5})
+	s2 := must(t, map[string]float64{"a": 0.7, "b": 0.4, "d": 1})
+
+	want_union := must(t, map[string]float64{"a": 0.7, "b": 0.9, "c": 0.5, "d": 1})
+	if got := s1.Union(s2); !got.Equals(want_union) {
+		t.Errorf("got %v; want %v", got, want_union)
+	}
+
+	want_intersection := must(t, map[string]float64{"a": 0.2, "b": 0.4})
+	if got := s1.Intersection(s2); !got.Equals(want_intersection) {
+		t.Errorf("got %v; want %v", got, want_intersection)
+	}
+
+	in_place := s1.Copy()
+	in_place.IntersectionInPlace(s2)
+	if !in_place.Equals(want_intersection) {
+		t.Errorf("got %v; want %v", in_place, want_intersection)
+	}
+}
+
+func TestCut(t *testing.T) {
+	s := must(t, map[string]float64{"a": 0.2, "b": 0.5, "c": 0.9})
+	testCases := []struct {
+		desc string
+		got  set.Set[string]
+		want set.Set[string]
+	}{
+		{
+			desc: "cut",
+			got:  s.Cut(0.5),
+			want: set.NewSet([]string{"b", "c"}),
+		},
+		{
+			desc: "strict cut",
+			got:  s.StrictCut(0.5),
+			want: set.NewSet([]string{"c"}),
+		},
+		{
+			desc: "support",
+			got:  s.Support(),
+			want: set.NewSet([]string{"a", "b", "c"}),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !tC.got.Equals(tC.want) {
+				t.Errorf("got %v; want %v", tC.got, tC.want)
+			}
+		})
+	}
+}
+
+func TestAddWeight(t *testing.T) {
+	s := FromSet(set.NewSet([]int{1, 2}))
+	if w := s.Weight(1); w != 1 {
+		t.Errorf("got weight %v, want 1", w)
+	}
+	if err := s.Add(3, 2); err != ErrInvalidWeight {
+		t.Errorf("got error %v, want %v", err, ErrInvalidWeight)
+	}
+	if err := s.Add(1, 0); err != nil || s.Contains(1) {
+		t.Errorf("a weight of 0 should remove the item")
+	}
+	if w := s.Weight(5); w != 0 {
+		t.Errorf("got weight %v, want 0", w)
+	}
+}
@@ -0,0 +1,138 @@
+package set
+
+// GenerationalSet is a set that can be emptied in O(1), for working sets that are
+// cleared over and over, such as once per frame of a game loop. Each item is stored
+// with the generation it was added in, and `ClearAll` just starts a new generation, so
+// every item from before no longer counts as being in the set. The stale entries stay
+// in the map, so an item added again in a later generation reuses its slot without
+// allocating. If the items change a lot between generations, call `Compact` now and
+// then to free the stale ones. The zero value is an empty set, ready to use.
+type GenerationalSet[T comparable] struct {
+	data map[T]uint32
+	// gen is the current generation. Only entries holding it are in the set.
+	gen uint32
+	// length is the number of entries holding the current generation
+	length int
+}
+
+// NewGenerationalSet will return a GenerationalSet holding the items of `data`
+func NewGenerationalSet[T comparable, S ~[]T](data S) GenerationalSet[T] {
+	result := GenerationalSet[T]{data: make(map[T]uint32, len(data))}
+	for _, v := range data {
+		result.Add(v)
+	}
+	return result
+}
+
+// ClearAll will remove every item from the set in O(1), by starting a new generation.
+// Only once every 2^32 calls does it have to clear the map, so that no entry from an
+// old generation can come to look current again.
+func (s *GenerationalSet[T]) ClearAll() {
+	s.gen++
+	if s.gen == 0 {
+		clear(s.data)
+	}
+	s.length = 0
+}
+
+// Clear is the same as `ClearAll`
+func (s *GenerationalSet[T]) Clear() {
+	s.ClearAll()
+}
+
+// Compact deletes the entries left behind by earlier generations, freeing the space
+// they use. It is O(n) in the number of entries, current and stale.
+func (s *GenerationalSet[T]) Compact() {
+	for v, gen := range s.data {
+		if gen != s.gen {
+			delete(s.data, v)
+		}
+	}
+}
+
+// Contains will return true if the item has been added since the last `ClearAll`
+func (s *GenerationalSet[T]) Contains(item T) bool {
+	gen, ok := s.data[item]
+	return ok && gen == s.gen
+}
+
+// Add will add an item to the set
+func (s *GenerationalSet[T]) Add(item T) {
+	if s.data == nil {
+		s.data = make(map[T]uint32)
+	}
+	if gen, ok := s.data[item]; !ok || gen != s.gen {
+		s.data[item] = s.gen
+		s.length++
+	}
+}
+
+// TryRemove removes an item from the set, and returns true if it was there
+func (s *GenerationalSet[T]) TryRemove(item T) bool {
+	if !s.Contains(item) {
+		return false
+	}
+	delete(s.data, item)
+	s.length--
+	return true
+}
+
+// Remove removes an item from the set. Returns a *NotFoundError if the item doesn't
+// exist.
+func (s *GenerationalSet[T]) Remove(item T) error {
+	if !s.TryRemove(item) {
+		return &NotFoundError[T]{Item: item}
+	}
+	return nil
+}
+
+// Discard removes an item from the set. If it doesn't exist, it is ignored
+func (s *GenerationalSet[T]) Discard(item T) {
+	s.TryRemove(item)
+}
+
+// Pop will remove and return an arbitrary item from the set. If the set is empty, it
+// will return an error
+func (s *GenerationalSet[T]) Pop() (item T, err error) {
+	if s.length == 0 {
+		return item, ErrElementNotFound
+	}
+	for v, gen := range s.data {
+		if gen == s.gen {
+			item = v
+			break
+		}
+	}
+	s.TryRemove(item)
+	return item, nil
+}
+
+// Len returns the number of items in the set
+func (s *GenerationalSet[T]) Len() int {
+	return s.length
+}
+
+// IsEmpty returns true if the set is empty
+func (s *GenerationalSet[T]) IsEmpty() bool {
+	return s.length == 0
+}
+
+// Iterate calls `f` on every item in the set, in no particular order. It stops early if
+// `f` returns false. Stale entries are skipped, but still take time to pass over.
+func (s *GenerationalSet[T]) Iterate(f func(item T) bool) {
+	for v, gen := range s.data {
+		if gen == s.gen && !f(v) {
+			return
+		}
+	}
+}
+
+// Slice will return all the items in the set as a slice, in no particular order
+func (s *GenerationalSet[T]) Slice() []T {
+	result := make([]T, 0, s.length)
+	s.Iterate(func(item T) bool {
+		result = append(result, item)
+		return true
+	})
+	return result
+}
@@ -0,0 +1,83 @@
+package set
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestGenerationalSet(t *testing.T) {
+	s := NewGenerationalSet([]int{1, 2, 3})
+	if s.Len() != 3 || !s.Contains(2) {
+		t.Errorf("got %v; want [1 2 3]", s.Slice())
+	}
+
+	s.ClearAll()
+	if !s.IsEmpty() || s.Contains(2) || len(s.Slice()) != 0 {
+		t.Errorf("got %v after ClearAll; want an empty set", s.Slice())
+	}
+	if _, err := s.Pop(); !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrElementNotFound)
+	}
+	if err := s.Remove(1); !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("removing a stale item should fail, got %v", err)
+	}
+
+	// Adding an item from an old generation brings it back
+	s.Add(2)
+	s.Add(4)
+	s.Add(4)
+	if s.Len() != 2 || !s.Contains(2) || s.Contains(1) {
+		t.Errorf("got %v; want [2 4]", s.Slice())
+	}
+
+	// Compact only frees the stale entries
+	s.Compact()
+	if len(s.data) != 2 || s.Len() != 2 {
+		t.Errorf("got %d entries after Compact; want 2", len(s.data))
+	}
+
+	popped, err := s.Pop()
+	if err != nil || (popped != 2 && popped != 4) || s.Len() != 1 {
+		t.Errorf("got %v, %v from Pop", popped, err)
+	}
+}
+
+func TestGenerationalSetWraps(t *testing.T) {
+	var s GenerationalSet[string]
+	s.Add("old")
+	// Jump to the last generation, so the next ClearAll wraps back to 0, which the
+	// stale entry was added in
+	s.gen = math.MaxUint32
+	s.Add("new")
+	s.ClearAll()
+	if s.Contains("old") || s.Contains("new") || s.Len() != 0 {
+		t.Errorf("got %v after wrapping; want an empty set", s.Slice())
+	}
+}
+
+func BenchmarkClearAll(b *testing.B) {
+	items := make([]int, 10_000)
+	for i := range items {
+		items[i] = i
+	}
+
+	b.Run("Set.Clear", func(b *testing.B) {
+		s := NewSet([]int{})
+		for i := 0; i < b.N; i++ {
+			for _, v := range items {
+				s.Add(v)
+			}
+			clear(s.data)
+		}
+	})
+	b.Run("GenerationalSet.ClearAll", func(b *testing.B) {
+		var s GenerationalSet[int]
+		for i := 0; i < b.N; i++ {
+			for _, v := range items {
+				s.Add(v)
+			}
+			s.ClearAll()
+		}
+	})
+}
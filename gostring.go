@@ -0,0 +1,75 @@
+package set
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Of will return a Set holding the items passed in
+func Of[T comparable](items ...T) Set[T] {
+	return NewSet(items)
+}
+
+// GoString formats the set as Go code that rebuilds it, such as `set.Of(1, 2, 3)`, so
+// `%#v` output from a failing test can be pasted straight into a new test case. The
+// items are sorted so the output is always the same. The type is spelled out, as in
+// `set.Of[int64](1, 2)`, unless it is int or string, which Go infers by itself.
+func (s Set[T]) GoString() string {
+	items := sorted_any(s)
+
+	// Go only infers the type from the items if there are some to infer it from
+	var zero T
+	inferred := false
+	switch any(zero).(type) {
+	case int, string:
+		inferred = len(items) > 0
+	}
+
+	var b strings.Builder
+	if inferred {
+		b.WriteString("set.Of(")
+	} else {
+		b.WriteString(fmt.Sprintf("set.Of[%T](", zero))
+	}
+	for idx, v := range items {
+		if idx > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(fmt.Sprintf("%#v", v))
+	}
+	b.WriteString(")")
+
+	return b.String()
+}
+
+// sorted_any returns the items of `s` sorted by `less_any`, for output that should be
+// the same every time whatever the item type
+func sorted_any[T comparable](s Set[T]) []T {
+	items := s.Slice()
+	sort.Slice(items, func(i, j int) bool {
+		return less_any(reflect.ValueOf(items[i]), reflect.ValueOf(items[j]))
+	})
+	return items
+}
+
+// less_any orders numbers, strings, and booleans by value, and anything else by its Go
+// syntax representation
+func less_any(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Bool:
+		return !a.Bool() && b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.String:
+		return a.String() < b.String()
+	default:
+		return fmt.Sprintf("%#v", a.Interface()) < fmt.Sprintf("%#v", b.Interface())
+	}
+}
@@ -0,0 +1,38 @@
+package set
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGoString(t *testing.T) {
+	type point struct{ X, Y int }
+
+	testCases := []struct {
+		desc string
+		s    fmt.GoStringer
+		want string
+	}{
+		{desc: "ints", s: Of(3, 1, 20), want: "set.Of(1, 3, 20)"},
+		{desc: "strings", s: Of("b", "a"), want: `set.Of("a", "b")`},
+		{desc: "empty", s: Of[int](), want: "set.Of[int]()"},
+		{desc: "int64", s: Of[int64](-5, 2), want: "set.Of[int64](-5, 2)"},
+		{desc: "float64", s: Of(1.5, 1.0), want: "set.Of[float64](1, 1.5)"},
+		{
+			desc: "structs",
+			s:    Of(point{X: 1, Y: 2}),
+			want: "set.Of[set.point](set.point{X:1, Y:2})",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := tC.s.GoString(); got != tC.want {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+
+	if got, want := fmt.Sprintf("%#v", Of(2, 1)), "set.Of(1, 2)"; got != want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
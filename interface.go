@@ -0,0 +1,43 @@
+package set
+
+// Interface is the group of methods shared by every set type in this module, such as
+// Set, SmallSet, and bitset.Set. Library code can accept an Interface[int] to work with
+// any of the int sets, whichever representation the caller picked. Methods that combine
+// two sets, like Union, take the concrete type so are not part of it. Every
+// implementation's Remove fails with an error matching ErrElementNotFound under
+// `errors.Is`.
+type Interface[T comparable] interface {
+	Contains(item T) bool
+	Add(item T)
+	Remove(item T) error
+	Discard(item T)
+	Pop() (T, error)
+	Clear()
+	Len() int
+	IsEmpty() bool
+	Slice() []T
+	Iterate(f func(item T) bool)
+}
+
+var (
+	_ Interface[int]    = (*Set[int])(nil)
+	_ Interface[int]    = (*SmallSet[int])(nil)
+	_ Interface[string] = (*NormalizedSet)(nil)
+	_ Interface[int]    = (*SyncSet[int])(nil)
+	_ Interface[int]    = (*GenerationalSet[int])(nil)
+)
+
+// Equivalent will return true if `a` and `b` hold the same items, even if they are
+// different types of set
+func Equivalent[T comparable](a, b Interface[T]) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+
+	result := true
+	a.Iterate(func(item T) bool {
+		result = b.Contains(item)
+		return result
+	})
+	return result
+}
@@ -0,0 +1,161 @@
+package set_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/natemcintosh/set"
+	"github.com/natemcintosh/set/bitset"
+	"github.com/natemcintosh/set/intset"
+	"github.com/natemcintosh/set/openset"
+	"github.com/natemcintosh/set/settest"
+	"github.com/natemcintosh/set/sortedset"
+	"github.com/natemcintosh/set/sparseset"
+)
+
+// check_interface runs the same sequence of operations against any int set, and
+// compares it to a plain map
+func check_interface(t *testing.T, s set.Interface[int], items []int) {
+	want := make(map[int]bool)
+	for _, v := range items {
+		s.Add(v)
+		want[v] = true
+	}
+	if s.Len() != len(want) {
+		t.Errorf("got length %d, want %d", s.Len(), len(want))
+	}
+
+	seen := 0
+	s.Iterate(func(item int) bool {
+		if !want[item] {
+			t.Errorf("iterated over %d, which was never added", item)
+		}
+		seen += 1
+		return true
+	})
+	if seen != len(want) {
+		t.Errorf("iterated over %d items, want %d", seen, len(want))
+	}
+
+	stopped_after := 0
+	s.Iterate(func(int) bool {
+		stopped_after += 1
+		return false
+	})
+	if stopped_after != 1 {
+		t.Errorf("Iterate kept going after f returned false, called %d times", stopped_after)
+	}
+
+	if err := s.Remove(items[0]); err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+	if err := s.Remove(items[0]); !errors.Is(err, set.ErrElementNotFound) {
+		t.Errorf("removing %d twice: got error %v, want %v", items[0], err, set.ErrElementNotFound)
+	}
+	delete(want, items[0])
+
+	s.Discard(items[1])
+	delete(want, items[1])
+	for v := range want {
+		if !s.Contains(v) {
+			t.Errorf("%d should still be in the set", v)
+		}
+	}
+
+	popped, err := s.Pop()
+	if err != nil || !want[popped] {
+		t.Errorf("got %d, %v from Pop", popped, err)
+	}
+
+	s.Clear()
+	if !s.IsEmpty() || len(s.Slice()) != 0 {
+		t.Errorf("set should be empty after Clear, got %v", s.Slice())
+	}
+	if _, err := s.Pop(); !errors.Is(err, set.ErrElementNotFound) {
+		t.Errorf("Pop on an empty set: got error %v, want %v", err, set.ErrElementNotFound)
+	}
+}
+
+func TestInterface(t *testing.T) {
+	items := []int{-130, -1, 0, 5, 63, 64, 200, 10_000}
+
+	hash := set.NewSet([]int{})
+	small := set.NewSmallSet([]int{})
+	bits := bitset.NewSet([]int{})
+	dense := bitset.NewDense([]int{})
+	facade := intset.NewSet([]int{})
+	open := openset.NewSet([]int{}, openset.HashInt[int])
+	sorted := sortedset.NewSet([]int{})
+	synced := set.NewSyncSet([]int{})
+	generational := set.NewGenerationalSet([]int{})
+
+	testCases := []struct {
+		desc string
+		s    set.Interface[int]
+	}{
+		{desc: "Set", s: &hash},
+		{desc: "SmallSet", s: &small},
+		{desc: "bitset.Set", s: &bits},
+		{desc: "bitset.Dense", s: &dense},
+		{desc: "intset.Set", s: &facade},
+		{desc: "openset.Set", s: &open},
+		{desc: "sortedset.Set", s: &sorted},
+		{desc: "SyncSet", s: synced},
+		{desc: "GenerationalSet", s: &generational},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			check_interface(t, tC.s, items)
+		})
+	}
+
+	// A sparse set only holds non-negative items
+	sparse := sparseset.NewSet([]int{})
+	check_interface(t, &sparse, []int{0, 5, 63, 64, 200, 10_000})
+}
+
+func TestEquivalent(t *testing.T) {
+	hash := set.NewSet([]int{1, 2, 300})
+	bits := bitset.NewSet([]int{300, 2, 1})
+	other := bitset.NewSet([]int{1, 2, 3})
+
+	if !set.Equivalent[int](&hash, &bits) {
+		t.Errorf("%v and %v should be equivalent", hash, bits)
+	}
+	if set.Equivalent[int](&hash, &other) {
+		t.Errorf("%v and %v should not be equivalent", hash, other)
+	}
+}
+
+func TestDifferential(t *testing.T) {
+	ref := func() set.Interface[int] {
+		s := set.NewSet([]int{})
+		return &s
+	}
+	testCases := []struct {
+		desc      string
+		candidate func() set.Interface[int]
+	}{
+		{desc: "SmallSet", candidate: func() set.Interface[int] { s := set.NewSmallSet([]int{}); return &s }},
+		{desc: "bitset.Set", candidate: func() set.Interface[int] { s := bitset.NewSet([]int{}); return &s }},
+		{desc: "bitset.Dense", candidate: func() set.Interface[int] { s := bitset.NewDense([]int{}); return &s }},
+		{desc: "intset.Set", candidate: func() set.Interface[int] { s := intset.NewSet([]int{}); return &s }},
+		{desc: "openset.Set", candidate: func() set.Interface[int] { s := openset.NewSet([]int{}, openset.HashInt[int]); return &s }},
+		{desc: "sortedset.Set", candidate: func() set.Interface[int] { s := sortedset.NewSet([]int{}); return &s }},
+		{desc: "SyncSet", candidate: func() set.Interface[int] { return set.NewSyncSet([]int{}) }},
+		{desc: "GenerationalSet", candidate: func() set.Interface[int] { s := set.NewGenerationalSet([]int{}); return &s }},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if err := settest.Differential(ref, tC.candidate).Run(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	sparse := settest.Differential(ref, func() set.Interface[int] { s := sparseset.NewSet([]int{}); return &s })
+	sparse.Values = settest.Range{Lo: 0, Hi: 400}
+	if err := sparse.Run(); err != nil {
+		t.Error(err)
+	}
+}
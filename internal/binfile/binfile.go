@@ -0,0 +1,225 @@
+// binfile reads and writes the framing shared by the set file formats: a 4 byte magic
+// number, a version byte, the body, and a CRC-32C checksum of everything before it.
+// Writes are buffered and streamed, so a file never has to fit in memory.
+package binfile
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"io"
+	"math"
+)
+
+var (
+	// ErrInvalidFile is returned when the input does not start with the expected magic
+	// number, or ends early
+	ErrInvalidFile = errors.New("binfile: not a saved set")
+
+	// ErrUnsupportedVersion is returned when the file was written by a newer version
+	ErrUnsupportedVersion = errors.New("binfile: unsupported version")
+
+	// ErrChecksum is returned when the checksum does not match the contents
+	ErrChecksum = errors.New("binfile: checksum mismatch")
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// Writer streams a file body. The first error is kept, and returned by `Close`, so the
+// individual writes don't need checking.
+type Writer struct {
+	w   *bufio.Writer
+	h   hash.Hash32
+	buf [binary.MaxVarintLen64]byte
+	err error
+}
+
+// NewWriter will return a Writer with the magic number and version already written
+func NewWriter(w io.Writer, magic [4]byte, version byte) *Writer {
+	result := &Writer{w: bufio.NewWriter(w), h: crc32.New(castagnoli)}
+	result.Bytes(magic[:])
+	result.Byte(version)
+	return result
+}
+
+// Bytes writes `b` as is
+func (w *Writer) Bytes(b []byte) {
+	if w.err != nil {
+		return
+	}
+	w.h.Write(b)
+	_, w.err = w.w.Write(b)
+}
+
+// Byte writes a single byte
+func (w *Writer) Byte(b byte) {
+	w.buf[0] = b
+	w.Bytes(w.buf[:1])
+}
+
+// Uvarint writes an unsigned varint
+func (w *Writer) Uvarint(v uint64) {
+	n := binary.PutUvarint(w.buf[:], v)
+	w.Bytes(w.buf[:n])
+}
+
+// Varint writes a zig-zag encoded signed varint
+func (w *Writer) Varint(v int64) {
+	n := binary.PutVarint(w.buf[:], v)
+	w.Bytes(w.buf[:n])
+}
+
+// Uint64 writes `v` as 8 little endian bytes
+func (w *Writer) Uint64(v uint64) {
+	binary.LittleEndian.PutUint64(w.buf[:8], v)
+	w.Bytes(w.buf[:8])
+}
+
+// Float64 writes the bits of `v` as 8 little endian bytes
+func (w *Writer) Float64(v float64) {
+	w.Uint64(math.Float64bits(v))
+}
+
+// String writes the length of `v` as a uvarint, followed by its bytes
+func (w *Writer) String(v string) {
+	w.Uvarint(uint64(len(v)))
+	w.Bytes([]byte(v))
+}
+
+// Err returns the first error from any write so far
+func (w *Writer) Err() error {
+	return w.err
+}
+
+// Close writes the checksum and flushes everything to the underlying writer. It
+// returns the first error from any write.
+func (w *Writer) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	binary.LittleEndian.PutUint32(w.buf[:4], w.h.Sum32())
+	if _, err := w.w.Write(w.buf[:4]); err != nil {
+		return err
+	}
+	return w.w.Flush()
+}
+
+// Reader reads a file body written by Writer, keeping a running checksum
+type Reader struct {
+	r       *bufio.Reader
+	h       hash.Hash32
+	version byte
+}
+
+// NewReader will check the magic number, and return a Reader positioned at the start
+// of the body. Files with a version newer than `max_version` are rejected.
+func NewReader(r io.Reader, magic [4]byte, max_version byte) (*Reader, error) {
+	result := &Reader{r: bufio.NewReader(r), h: crc32.New(castagnoli)}
+
+	var head [5]byte
+	if err := result.Bytes(head[:]); err != nil {
+		return nil, err
+	}
+	if [4]byte(head[:4]) != magic {
+		return nil, ErrInvalidFile
+	}
+	result.version = head[4]
+	if result.version == 0 || result.version > max_version {
+		return nil, ErrUnsupportedVersion
+	}
+	return result, nil
+}
+
+// Version returns the version the file was written with
+func (r *Reader) Version() byte {
+	return r.version
+}
+
+// ReadByte reads a single byte. It lets the Reader be used with `binary.ReadUvarint`.
+func (r *Reader) ReadByte() (byte, error) {
+	b, err := r.r.ReadByte()
+	if err != nil {
+		return 0, ErrInvalidFile
+	}
+	r.h.Write([]byte{b})
+	return b, nil
+}
+
+// Bytes fills `b` from the input
+func (r *Reader) Bytes(b []byte) error {
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		return ErrInvalidFile
+	}
+	r.h.Write(b)
+	return nil
+}
+
+// Uvarint reads an unsigned varint
+func (r *Reader) Uvarint() (uint64, error) {
+	v, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, ErrInvalidFile
+	}
+	return v, nil
+}
+
+// Varint reads a zig-zag encoded signed varint
+func (r *Reader) Varint() (int64, error) {
+	v, err := binary.ReadVarint(r)
+	if err != nil {
+		return 0, ErrInvalidFile
+	}
+	return v, nil
+}
+
+// Uint64 reads 8 little endian bytes
+func (r *Reader) Uint64() (uint64, error) {
+	var buf [8]byte
+	if err := r.Bytes(buf[:]); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(buf[:]), nil
+}
+
+// Float64 reads a float written by `Writer.Float64`
+func (r *Reader) Float64() (float64, error) {
+	v, err := r.Uint64()
+	return math.Float64frombits(v), err
+}
+
+// String reads a string written by `Writer.String`
+func (r *Reader) String() (string, error) {
+	n, err := r.Uvarint()
+	if err != nil {
+		return "", err
+	}
+	// Read in chunks, so a corrupt length can't cause a huge allocation up front
+	var result []byte
+	var chunk [4096]byte
+	for n > 0 {
+		size := uint64(len(chunk))
+		if n < size {
+			size = n
+		}
+		if err := r.Bytes(chunk[:size]); err != nil {
+			return "", err
+		}
+		result = append(result, chunk[:size]...)
+		n -= size
+	}
+	return string(result), nil
+}
+
+// Close reads the checksum, and checks it against the body that was read
+func (r *Reader) Close() error {
+	var buf [4]byte
+	if _, err := io.ReadFull(r.r, buf[:]); err != nil {
+		return ErrInvalidFile
+	}
+	if binary.LittleEndian.Uint32(buf[:]) != r.h.Sum32() {
+		return ErrChecksum
+	}
+	return nil
+}
@@ -0,0 +1,300 @@
+// cbor is a small encoder and decoder for the subset of CBOR (RFC 8949) that the set
+// types need: integers, floats, booleans, text and byte strings, and definite length
+// arrays and maps.
+package cbor
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+var (
+	// ErrMalformed is returned when the input is not valid CBOR, or ends early
+	ErrMalformed = errors.New("cbor: malformed input")
+
+	// ErrUnexpectedType is returned when the next item is not the type being read
+	ErrUnexpectedType = errors.New("cbor: unexpected type")
+
+	// ErrUnsupported is returned for valid CBOR that this package doesn't handle, such
+	// as indefinite length items
+	ErrUnsupported = errors.New("cbor: unsupported item")
+)
+
+// The CBOR major types
+const (
+	MajorUint   uint8 = 0
+	MajorNegInt uint8 = 1
+	MajorBytes  uint8 = 2
+	MajorText   uint8 = 3
+	MajorArray  uint8 = 4
+	MajorMap    uint8 = 5
+	MajorTag    uint8 = 6
+	MajorSimple uint8 = 7
+)
+
+const (
+	simple_false = 20
+	simple_true  = 21
+	info_float16 = 25
+	info_float32 = 26
+	info_float64 = 27
+)
+
+// AppendHead appends the initial bytes of an item with the major type and argument
+func AppendHead(dst []byte, major uint8, n uint64) []byte {
+	m := major << 5
+	switch {
+	case n < 24:
+		return append(dst, m|uint8(n))
+	case n <= math.MaxUint8:
+		return append(dst, m|24, uint8(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, m|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(dst, m|26), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(dst, m|27), n)
+	}
+}
+
+// AppendUint appends an unsigned integer
+func AppendUint(dst []byte, v uint64) []byte {
+	return AppendHead(dst, MajorUint, v)
+}
+
+// AppendInt appends a signed integer
+func AppendInt(dst []byte, v int64) []byte {
+	if v < 0 {
+		// Negative integers are stored as -1 - n
+		return AppendHead(dst, MajorNegInt, uint64(-(v + 1)))
+	}
+	return AppendHead(dst, MajorUint, uint64(v))
+}
+
+// AppendFloat appends a double precision float
+func AppendFloat(dst []byte, v float64) []byte {
+	return binary.BigEndian.AppendUint64(append(dst, MajorSimple<<5|info_float64), math.Float64bits(v))
+}
+
+// AppendBool appends a boolean
+func AppendBool(dst []byte, v bool) []byte {
+	if v {
+		return append(dst, MajorSimple<<5|simple_true)
+	}
+	return append(dst, MajorSimple<<5|simple_false)
+}
+
+// AppendText appends a UTF-8 text string
+func AppendText(dst []byte, v string) []byte {
+	return append(AppendHead(dst, MajorText, uint64(len(v))), v...)
+}
+
+// AppendBytes appends a byte string
+func AppendBytes(dst []byte, v []byte) []byte {
+	return append(AppendHead(dst, MajorBytes, uint64(len(v))), v...)
+}
+
+// Decoder reads items one at a time from a byte slice
+type Decoder struct {
+	data []byte
+	pos  int
+}
+
+// NewDecoder returns a Decoder reading from `data`
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{data: data}
+}
+
+// Done returns true once every byte has been read
+func (d *Decoder) Done() bool {
+	return d.pos >= len(d.data)
+}
+
+// PeekMajor returns the major type of the next item without reading it
+func (d *Decoder) PeekMajor() (uint8, error) {
+	if d.pos >= len(d.data) {
+		return 0, ErrMalformed
+	}
+	return d.data[d.pos] >> 5, nil
+}
+
+// ReadHead reads the initial bytes of an item, returning its major type, the low five
+// bits of the first byte, and its argument
+func (d *Decoder) ReadHead() (major uint8, info uint8, arg uint64, err error) {
+	if d.pos >= len(d.data) {
+		return 0, 0, 0, ErrMalformed
+	}
+	b := d.data[d.pos]
+	d.pos += 1
+	major, info = b>>5, b&0x1F
+
+	var size int
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, 0, 0, ErrUnsupported
+	}
+
+	if d.pos+size > len(d.data) {
+		return 0, 0, 0, ErrMalformed
+	}
+	for _, c := range d.data[d.pos : d.pos+size] {
+		arg = arg<<8 | uint64(c)
+	}
+	d.pos += size
+	return major, info, arg, nil
+}
+
+// read_length reads the head of an item that must have the major type `want`, and
+// returns its argument
+func (d *Decoder) read_length(want uint8) (uint64, error) {
+	major, _, arg, err := d.ReadHead()
+	if err != nil {
+		return 0, err
+	}
+	if major != want {
+		return 0, ErrUnexpectedType
+	}
+	return arg, nil
+}
+
+// ReadInt reads a signed or unsigned integer that fits in an int64
+func (d *Decoder) ReadInt() (int64, error) {
+	major, _, arg, err := d.ReadHead()
+	if err != nil {
+		return 0, err
+	}
+	if arg > math.MaxInt64 {
+		return 0, ErrUnsupported
+	}
+	switch major {
+	case MajorUint:
+		return int64(arg), nil
+	case MajorNegInt:
+		return -1 - int64(arg), nil
+	}
+	return 0, ErrUnexpectedType
+}
+
+// ReadUint reads an unsigned integer
+func (d *Decoder) ReadUint() (uint64, error) {
+	return d.read_length(MajorUint)
+}
+
+// ReadFloat reads a half, single, or double precision float
+func (d *Decoder) ReadFloat() (float64, error) {
+	major, info, arg, err := d.ReadHead()
+	if err != nil {
+		return 0, err
+	}
+	if major != MajorSimple {
+		return 0, ErrUnexpectedType
+	}
+	switch info {
+	case info_float16:
+		return half_to_float(uint16(arg)), nil
+	case info_float32:
+		return float64(math.Float32frombits(uint32(arg))), nil
+	case info_float64:
+		return math.Float64frombits(arg), nil
+	}
+	return 0, ErrUnexpectedType
+}
+
+// half_to_float converts an IEEE 754 half precision float
+func half_to_float(h uint16) float64 {
+	exp := int(h>>10) & 0x1F
+	mant := float64(h & 0x3FF)
+	var v float64
+	switch exp {
+	case 0:
+		v = math.Ldexp(mant, -24)
+	case 31:
+		if mant == 0 {
+			v = math.Inf(1)
+		} else {
+			v = math.NaN()
+		}
+	default:
+		v = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		return -v
+	}
+	return v
+}
+
+// ReadBool reads a boolean
+func (d *Decoder) ReadBool() (bool, error) {
+	major, info, _, err := d.ReadHead()
+	if err != nil {
+		return false, err
+	}
+	if major == MajorSimple && info == simple_true {
+		return true, nil
+	}
+	if major == MajorSimple && info == simple_false {
+		return false, nil
+	}
+	return false, ErrUnexpectedType
+}
+
+// read_string_bytes reads the contents of a text or byte string
+func (d *Decoder) read_string_bytes(major uint8) ([]byte, error) {
+	n, err := d.read_length(major)
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(d.data)-d.pos) {
+		return nil, ErrMalformed
+	}
+	b := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return b, nil
+}
+
+// ReadText reads a text string
+func (d *Decoder) ReadText() (string, error) {
+	b, err := d.read_string_bytes(MajorText)
+	return string(b), err
+}
+
+// ReadBytes reads a byte string. The result points into the decoder's input.
+func (d *Decoder) ReadBytes() ([]byte, error) {
+	return d.read_string_bytes(MajorBytes)
+}
+
+// ReadArrayHeader reads the head of an array, and returns how many items it holds
+func (d *Decoder) ReadArrayHeader() (int, error) {
+	n, err := d.read_length(MajorArray)
+	if err != nil {
+		return 0, err
+	}
+	// Every item takes at least one byte, which guards against huge bogus lengths
+	if n > uint64(len(d.data)-d.pos) {
+		return 0, ErrMalformed
+	}
+	return int(n), nil
+}
+
+// ReadMapHeader reads the head of a map, and returns how many key/value pairs it holds
+func (d *Decoder) ReadMapHeader() (int, error) {
+	n, err := d.read_length(MajorMap)
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(len(d.data)-d.pos)/2 {
+		return 0, ErrMalformed
+	}
+	return int(n), nil
+}
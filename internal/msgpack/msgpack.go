@@ -0,0 +1,332 @@
+// msgpack is a small encoder and decoder for the subset of MessagePack that the set
+// types need: integers, floats, booleans, strings, binary, arrays, and maps.
+package msgpack
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+var (
+	// ErrMalformed is returned when the input is not valid MessagePack, or ends early
+	ErrMalformed = errors.New("msgpack: malformed input")
+
+	// ErrUnexpectedType is returned when the next item is not the type being read
+	ErrUnexpectedType = errors.New("msgpack: unexpected type")
+)
+
+// AppendInt appends a signed integer in the smallest form that holds it
+func AppendInt(dst []byte, v int64) []byte {
+	switch {
+	case v >= 0:
+		return AppendUint(dst, uint64(v))
+	case v >= -32:
+		return append(dst, byte(v))
+	case v >= math.MinInt8:
+		return append(dst, 0xd0, byte(v))
+	case v >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(dst, 0xd1), uint16(v))
+	case v >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(dst, 0xd2), uint32(v))
+	default:
+		return binary.BigEndian.AppendUint64(append(dst, 0xd3), uint64(v))
+	}
+}
+
+// AppendUint appends an unsigned integer in the smallest form that holds it
+func AppendUint(dst []byte, v uint64) []byte {
+	switch {
+	case v <= 0x7f:
+		return append(dst, byte(v))
+	case v <= math.MaxUint8:
+		return append(dst, 0xcc, byte(v))
+	case v <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, 0xcd), uint16(v))
+	case v <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(dst, 0xce), uint32(v))
+	default:
+		return binary.BigEndian.AppendUint64(append(dst, 0xcf), v)
+	}
+}
+
+// AppendFloat appends a float 64
+func AppendFloat(dst []byte, v float64) []byte {
+	return binary.BigEndian.AppendUint64(append(dst, 0xcb), math.Float64bits(v))
+}
+
+// AppendBool appends a boolean
+func AppendBool(dst []byte, v bool) []byte {
+	if v {
+		return append(dst, 0xc3)
+	}
+	return append(dst, 0xc2)
+}
+
+// AppendString appends a string
+func AppendString(dst []byte, v string) []byte {
+	n := len(v)
+	switch {
+	case n <= 31:
+		dst = append(dst, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		dst = append(dst, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		dst = binary.BigEndian.AppendUint16(append(dst, 0xda), uint16(n))
+	default:
+		dst = binary.BigEndian.AppendUint32(append(dst, 0xdb), uint32(n))
+	}
+	return append(dst, v...)
+}
+
+// AppendBinary appends a byte slice
+func AppendBinary(dst []byte, v []byte) []byte {
+	n := len(v)
+	switch {
+	case n <= math.MaxUint8:
+		dst = append(dst, 0xc4, byte(n))
+	case n <= math.MaxUint16:
+		dst = binary.BigEndian.AppendUint16(append(dst, 0xc5), uint16(n))
+	default:
+		dst = binary.BigEndian.AppendUint32(append(dst, 0xc6), uint32(n))
+	}
+	return append(dst, v...)
+}
+
+// AppendArrayHeader appends the head of an array holding `n` items
+func AppendArrayHeader(dst []byte, n int) []byte {
+	switch {
+	case n <= 15:
+		return append(dst, 0x90|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, 0xdc), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(dst, 0xdd), uint32(n))
+	}
+}
+
+// AppendMapHeader appends the head of a map holding `n` key/value pairs
+func AppendMapHeader(dst []byte, n int) []byte {
+	switch {
+	case n <= 15:
+		return append(dst, 0x80|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, 0xde), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(dst, 0xdf), uint32(n))
+	}
+}
+
+// Decoder reads items one at a time from a byte slice
+type Decoder struct {
+	data []byte
+	pos  int
+}
+
+// NewDecoder returns a Decoder reading from `data`
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{data: data}
+}
+
+// Done returns true once every byte has been read
+func (d *Decoder) Done() bool {
+	return d.pos >= len(d.data)
+}
+
+func (d *Decoder) read_byte() (byte, error) {
+	if d.pos >= len(d.data) {
+		return 0, ErrMalformed
+	}
+	b := d.data[d.pos]
+	d.pos += 1
+	return b, nil
+}
+
+// read_n reads a big endian unsigned integer of `size` bytes
+func (d *Decoder) read_n(size int) (uint64, error) {
+	if d.pos+size > len(d.data) {
+		return 0, ErrMalformed
+	}
+	var result uint64
+	for _, c := range d.data[d.pos : d.pos+size] {
+		result = result<<8 | uint64(c)
+	}
+	d.pos += size
+	return result, nil
+}
+
+// ReadInt reads any integer that fits in an int64
+func (d *Decoder) ReadInt() (int64, error) {
+	b, err := d.read_byte()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case b <= 0x7f:
+		return int64(b), nil
+	case b >= 0xe0:
+		return int64(int8(b)), nil
+	}
+
+	switch b {
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		v, err := d.read_n(1 << (b - 0xcc))
+		if err != nil {
+			return 0, err
+		}
+		if v > math.MaxInt64 {
+			return 0, ErrUnexpectedType
+		}
+		return int64(v), nil
+	case 0xd0:
+		v, err := d.read_n(1)
+		return int64(int8(v)), err
+	case 0xd1:
+		v, err := d.read_n(2)
+		return int64(int16(v)), err
+	case 0xd2:
+		v, err := d.read_n(4)
+		return int64(int32(v)), err
+	case 0xd3:
+		v, err := d.read_n(8)
+		return int64(v), err
+	}
+	return 0, ErrUnexpectedType
+}
+
+// ReadUint reads any non-negative integer
+func (d *Decoder) ReadUint() (uint64, error) {
+	if d.pos < len(d.data) && d.data[d.pos] == 0xcf {
+		d.pos += 1
+		return d.read_n(8)
+	}
+	v, err := d.ReadInt()
+	if err != nil {
+		return 0, err
+	}
+	if v < 0 {
+		return 0, ErrUnexpectedType
+	}
+	return uint64(v), nil
+}
+
+// ReadFloat reads a float 32 or float 64
+func (d *Decoder) ReadFloat() (float64, error) {
+	b, err := d.read_byte()
+	if err != nil {
+		return 0, err
+	}
+	switch b {
+	case 0xca:
+		v, err := d.read_n(4)
+		return float64(math.Float32frombits(uint32(v))), err
+	case 0xcb:
+		v, err := d.read_n(8)
+		return math.Float64frombits(v), err
+	}
+	return 0, ErrUnexpectedType
+}
+
+// ReadBool reads a boolean
+func (d *Decoder) ReadBool() (bool, error) {
+	b, err := d.read_byte()
+	if err != nil {
+		return false, err
+	}
+	switch b {
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	}
+	return false, ErrUnexpectedType
+}
+
+// read_bytes reads `n` raw bytes. The result points into the decoder's input.
+func (d *Decoder) read_bytes(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.pos) {
+		return nil, ErrMalformed
+	}
+	b := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return b, nil
+}
+
+// ReadString reads a string
+func (d *Decoder) ReadString() (string, error) {
+	b, err := d.read_byte()
+	if err != nil {
+		return "", err
+	}
+	var n uint64
+	switch {
+	case b&0xe0 == 0xa0:
+		n = uint64(b & 0x1f)
+	case b == 0xd9:
+		n, err = d.read_n(1)
+	case b == 0xda:
+		n, err = d.read_n(2)
+	case b == 0xdb:
+		n, err = d.read_n(4)
+	default:
+		return "", ErrUnexpectedType
+	}
+	if err != nil {
+		return "", err
+	}
+	s, err := d.read_bytes(n)
+	return string(s), err
+}
+
+// ReadBinary reads a byte slice. The result points into the decoder's input.
+func (d *Decoder) ReadBinary() ([]byte, error) {
+	b, err := d.read_byte()
+	if err != nil {
+		return nil, err
+	}
+	if b < 0xc4 || b > 0xc6 {
+		return nil, ErrUnexpectedType
+	}
+	n, err := d.read_n(1 << (b - 0xc4))
+	if err != nil {
+		return nil, err
+	}
+	return d.read_bytes(n)
+}
+
+// read_header reads the head of an array or map
+func (d *Decoder) read_header(fix byte, fix16 byte) (int, error) {
+	b, err := d.read_byte()
+	if err != nil {
+		return 0, err
+	}
+	var n uint64
+	switch {
+	case b&0xf0 == fix:
+		n = uint64(b & 0x0f)
+	case b == fix16:
+		n, err = d.read_n(2)
+	case b == fix16+1:
+		n, err = d.read_n(4)
+	default:
+		return 0, ErrUnexpectedType
+	}
+	if err != nil {
+		return 0, err
+	}
+	// Every item takes at least one byte, which guards against huge bogus lengths
+	if n > uint64(len(d.data)-d.pos) {
+		return 0, ErrMalformed
+	}
+	return int(n), nil
+}
+
+// ReadArrayHeader reads the head of an array, and returns how many items it holds
+func (d *Decoder) ReadArrayHeader() (int, error) {
+	return d.read_header(0x90, 0xdc)
+}
+
+// ReadMapHeader reads the head of a map, and returns how many key/value pairs it holds
+func (d *Decoder) ReadMapHeader() (int, error) {
+	return d.read_header(0x80, 0xde)
+}
@@ -0,0 +1,80 @@
+package set
+
+// These functions write their result into a set that already exists, instead of making
+// a new one. The destination is emptied first, but its map keeps its memory, so a loop
+// that needs a fresh result on every pass can reuse the same set rather than allocating.
+// The destination must not be one of the inputs; use the InPlace methods for that.
+
+// reset empties `s` ready to take a result, keeping the memory of its map, and gives it
+// the display options and pop order of `from`
+func (s *Set[T]) reset(from Set[T]) {
+	if s.data == nil {
+		s.data = make(map[T]struct{})
+	} else {
+		clear(s.data)
+	}
+	s.take_options(from)
+}
+
+// CopyInto replaces the contents of `dst` with the items of `s`
+func (s *Set[T]) CopyInto(dst *Set[T]) {
+	dst.reset(*s)
+	for v := range s.data {
+		dst.data[v] = struct{}{}
+	}
+}
+
+// UnionInto replaces the contents of `dst` with the union of `a` and `b`
+func UnionInto[T comparable](dst *Set[T], a, b Set[T]) {
+	dst.reset(a)
+	for v := range a.data {
+		dst.data[v] = struct{}{}
+	}
+	for v := range b.data {
+		dst.data[v] = struct{}{}
+	}
+}
+
+// IntersectionInto replaces the contents of `dst` with the intersection of `a` and `b`
+func IntersectionInto[T comparable](dst *Set[T], a, b Set[T]) {
+	dst.reset(a)
+
+	// Iterate over the smaller of the two sets, and add the item to `dst` if it is in
+	// the larger of the two sets
+	small, large := a, b
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	for v := range small.data {
+		if large.Contains(v) {
+			dst.data[v] = struct{}{}
+		}
+	}
+}
+
+// DifferenceInto replaces the contents of `dst` with the elements in `a` that are not
+// in `b`
+func DifferenceInto[T comparable](dst *Set[T], a, b Set[T]) {
+	dst.reset(a)
+	for v := range a.data {
+		if !b.Contains(v) {
+			dst.data[v] = struct{}{}
+		}
+	}
+}
+
+// SymmetricDifferenceInto replaces the contents of `dst` with the elements in either
+// `a` or `b`, but not both
+func SymmetricDifferenceInto[T comparable](dst *Set[T], a, b Set[T]) {
+	dst.reset(a)
+	for v := range a.data {
+		if !b.Contains(v) {
+			dst.data[v] = struct{}{}
+		}
+	}
+	for v := range b.data {
+		if !a.Contains(v) {
+			dst.data[v] = struct{}{}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestInto(t *testing.T) {
+	s1 := NewSet([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+	s2 := NewSet([]int{5, 6, 7, 8, 9, 10, 11, 12, 13, 14})
+
+	testCases := []struct {
+		desc string
+		into func(dst *Set[int], a, b Set[int])
+		want Set[int]
+	}{
+		{desc: "union", into: UnionInto[int], want: s1.Union(s2)},
+		{desc: "intersection", into: IntersectionInto[int], want: s1.Intersection(s2)},
+		{desc: "difference", into: DifferenceInto[int], want: s1.Difference(s2)},
+		{desc: "symmetric difference", into: SymmetricDifferenceInto[int], want: s1.SymmetricDifference(s2)},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			// Start with items that must all be cleared out, and reuse it a few times
+			dst := NewSet([]int{100, 200, 300})
+			for i := 0; i < 3; i++ {
+				tC.into(&dst, s1, s2)
+				if !dst.Equals(tC.want) {
+					t.Errorf("got %v, want %v", dst, tC.want)
+				}
+			}
+
+			// The zero value works as a destination too
+			var empty Set[int]
+			tC.into(&empty, s1, s2)
+			if !empty.Equals(tC.want) {
+				t.Errorf("got %v, want %v", empty, tC.want)
+			}
+		})
+	}
+}
+
+func TestCopyInto(t *testing.T) {
+	s := NewSet([]int{1, 2, 3})
+	s.SetFormatter(func(i int) string { return "#" })
+
+	dst := NewSet([]int{4, 5})
+	s.CopyInto(&dst)
+	if !dst.Equals(s) {
+		t.Errorf("got %v, want %v", dst, s)
+	}
+	if got, want := dst.String(), s.String(); got != want {
+		t.Errorf("the display options should be copied too, got %q, want %q", got, want)
+	}
+
+	// Changing the copy doesn't change the original
+	dst.Add(10)
+	if s.Contains(10) {
+		t.Errorf("CopyInto should not share the map with the original")
+	}
+}
+
+func BenchmarkUnionInto(b *testing.B) {
+	in1 := make([]int, 1000)
+	in2 := make([]int, 1000)
+	for i := range in1 {
+		in1[i] = i
+		in2[i] = i + 500
+	}
+	s1, s2 := NewSet(in1), NewSet(in2)
+
+	b.Run("Union", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s1.Union(s2)
+		}
+	})
+	b.Run("UnionInto", func(b *testing.B) {
+		b.ReportAllocs()
+		var dst Set[int]
+		for i := 0; i < b.N; i++ {
+			UnionInto(&dst, s1, s2)
+		}
+	})
+}
@@ -0,0 +1,371 @@
+// intset is a set of ints that picks its own representation. It starts out backed by
+// `github.com/natemcintosh/set`, and moves to `github.com/natemcintosh/set/bitset` once
+// the members are packed closely enough together that the bitset is the better fit. If
+// the members spread back out, it moves back to the hash set.
+package intset
+
+import (
+	"github.com/natemcintosh/set"
+	"github.com/natemcintosh/set/bitset"
+)
+
+var (
+	// This error is returned when you try to remove an item from a set that doesn't
+	// exist. It is set.ErrElementNotFound, so errors.Is works the same for every set.
+	ErrElementNotFound = set.ErrElementNotFound
+)
+
+const (
+	// Move to the bitset once the average 64 bit word would hold at least this many
+	// members
+	dense_threshold = 8
+
+	// Move back to the hash set once the average word holds fewer than this many
+	// members. Kept well below `dense_threshold` so that a set sitting near the
+	// boundary doesn't keep flipping back and forth
+	sparse_threshold = 2
+
+	// Sets smaller than this always use the hash set
+	min_dense_len = 64
+)
+
+type Set struct {
+	hash     set.Set[int]
+	bits     bitset.Set
+	is_dense bool
+
+	// length is tracked here so that checking it is cheap for both representations
+	length int
+
+	// The representation is only re-checked when the length moves outside of
+	// [shrink_check, grow_check], which keeps the cost of checking amortized O(1)
+	grow_check   int
+	shrink_check int
+}
+
+// NewSet will return a Set object from an input slice, or anything that has a slice as
+// the underlying data type
+func NewSet[S ~[]int](data S) Set {
+	s := Set{hash: set.NewSet(data)}
+	s.length = s.hash.Len()
+	s.rebalance()
+	return s
+}
+
+// word_count returns how many distinct 64 bit words the items fall into
+func word_count(items []int) int {
+	words := make(map[int]struct{}, len(items)/64+1)
+	for _, v := range items {
+		// Floor division, so that -1 and 0 land in different words
+		w := v >> 6
+		words[w] = struct{}{}
+	}
+	return len(words)
+}
+
+// rebalance moves the set to the other representation if the density has crossed the
+// relevant threshold
+func (s *Set) rebalance() {
+	if s.length < s.grow_check && s.length > s.shrink_check {
+		return
+	}
+	s.grow_check = 2 * s.length
+	if s.grow_check < min_dense_len {
+		s.grow_check = min_dense_len
+	}
+	s.shrink_check = s.length / 2
+
+	items := s.Slice()
+	words := word_count(items)
+	if !s.is_dense {
+		if s.length >= min_dense_len && s.length >= dense_threshold*words {
+			s.bits = bitset.NewSet(items)
+			s.hash = set.Set[int]{}
+			s.is_dense = true
+		}
+	} else {
+		if s.length < min_dense_len || s.length < sparse_threshold*words {
+			s.hash = set.NewSet(items)
+			s.bits = bitset.Set{}
+			s.is_dense = false
+		}
+	}
+}
+
+// IsDense returns true if the set is currently backed by a bitset
+func (s *Set) IsDense() bool {
+	return s.is_dense
+}
+
+func (s Set) String() string {
+	if s.is_dense {
+		return s.bits.String()
+	}
+	return s.hash.String()
+}
+
+// Slice will return all the items in the set as a slice. They are not guaranteed in any
+// particular order.
+func (s *Set) Slice() []int {
+	if s.is_dense {
+		return s.bits.Slice()
+	}
+	return s.hash.Slice()
+}
+
+// Iterate calls `f` on every item in the set, in no particular order. It stops early if
+// `f` returns false.
+func (s *Set) Iterate(f func(item int) bool) {
+	if s.is_dense {
+		s.bits.Iterate(f)
+		return
+	}
+	s.hash.Iterate(f)
+}
+
+// Contains will return true if the set contains the item. If the set is empty, returns
+// false
+func (s *Set) Contains(item int) bool {
+	if s.is_dense {
+		return s.bits.Contains(item)
+	}
+	return s.hash.Contains(item)
+}
+
+// Len returns the length of the Set
+func (s *Set) Len() int {
+	return s.length
+}
+
+// IsEmpty returns true if the set is empty
+func (s *Set) IsEmpty() bool {
+	return s.length == 0
+}
+
+// Add will add a new item to `s`. If it already exists, it is ignored
+func (s *Set) Add(item int) {
+	if s.Contains(item) {
+		return
+	}
+	if s.is_dense {
+		s.bits.Add(item)
+	} else {
+		s.hash.Add(item)
+	}
+	s.length += 1
+	s.rebalance()
+}
+
+// Remove removes an item from the set. Returns an error if the item doesn't exist.
+// See `Discard` for method that does not return an error
+func (s *Set) Remove(item int) error {
+	if !s.Contains(item) {
+		return ErrElementNotFound
+	}
+	s.Discard(item)
+	return nil
+}
+
+// Discard removes an item from the set. If it doesn't exist, it is ignored
+func (s *Set) Discard(item int) {
+	if !s.Contains(item) {
+		return
+	}
+	if s.is_dense {
+		s.bits.Discard(item)
+	} else {
+		s.hash.Discard(item)
+	}
+	s.length -= 1
+	s.rebalance()
+}
+
+// Pop will remove and return an arbitrary item from the set. If the set is empty,
+// it will return an error
+func (s *Set) Pop() (item int, err error) {
+	if s.IsEmpty() {
+		return item, ErrElementNotFound
+	}
+	if s.is_dense {
+		item, err = s.bits.Pop()
+	} else {
+		item, err = s.hash.Pop()
+	}
+	if err != nil {
+		return item, ErrElementNotFound
+	}
+	s.length -= 1
+	s.rebalance()
+	return item, nil
+}
+
+// Clear will remove all items from the set
+func (s *Set) Clear() {
+	*s = NewSet([]int{})
+}
+
+// Copy makes a deep copy of the set, in the same representation
+func (s *Set) Copy() Set {
+	result := *s
+	if s.is_dense {
+		result.bits = s.bits.Copy()
+	} else {
+		result.hash = s.hash.Copy()
+	}
+	return result
+}
+
+// as_hash returns `s` as a hash set, converting if needed
+func (s *Set) as_hash() set.Set[int] {
+	if s.is_dense {
+		return set.NewSet(s.bits.Slice())
+	}
+	return s.hash
+}
+
+// as_bits returns `s` as a bitset, converting if needed
+func (s *Set) as_bits() bitset.Set {
+	if s.is_dense {
+		return s.bits
+	}
+	return bitset.NewSet(s.hash.Slice())
+}
+
+// from_hash wraps a hash set result, and picks the representation for it
+func from_hash(h set.Set[int]) Set {
+	result := Set{hash: h, length: h.Len()}
+	result.rebalance()
+	return result
+}
+
+// from_bits wraps a bitset result, and picks the representation for it
+func from_bits(b bitset.Set) Set {
+	result := Set{bits: b, is_dense: true, length: b.Len()}
+	result.rebalance()
+	return result
+}
+
+// Equals will return true if `s` and `t` are
+// - the same length
+// - contain the same elements
+func (s *Set) Equals(t Set) bool {
+	if s.length != t.length {
+		return false
+	}
+	return s.IsSubsetOf(t)
+}
+
+// Union will create a new Set, and fill it with the union of `s` and `t`
+func (s *Set) Union(t Set) Set {
+	if s.is_dense {
+		return from_bits(s.bits.Union(t.as_bits()))
+	}
+	return from_hash(s.hash.Union(t.as_hash()))
+}
+
+// UnionInPlace will add all the items in set `t` to set `s`
+func (s *Set) UnionInPlace(t Set) {
+	if s.is_dense {
+		s.bits.UnionInPlace(t.as_bits())
+		s.length = s.bits.Len()
+	} else {
+		s.hash.UnionInPlace(t.as_hash())
+		s.length = s.hash.Len()
+	}
+	s.rebalance()
+}
+
+// Intersection will create a new Set, and fill it with the intersection of `s` and `t`
+func (s *Set) Intersection(t Set) Set {
+	if s.is_dense {
+		return from_bits(s.bits.Intersection(t.as_bits()))
+	}
+	return from_hash(s.hash.Intersection(t.as_hash()))
+}
+
+// IntersectionInPlace will remove any items from `s` that are not in `t`
+func (s *Set) IntersectionInPlace(t Set) {
+	if s.is_dense {
+		s.bits.IntersectionInPlace(t.as_bits())
+		s.length = s.bits.Len()
+	} else {
+		s.hash.IntersectionInPlace(t.as_hash())
+		s.length = s.hash.Len()
+	}
+	s.rebalance()
+}
+
+// IsDisjoint will return true if the set has no elements in common with `t`. Sets are
+// disjoint if and only if their intersection is the empty set
+func (s *Set) IsDisjoint(t Set) bool {
+	if s.is_dense {
+		return s.bits.IsDisjoint(t.as_bits())
+	}
+	return s.hash.IsDisjoint(t.as_hash())
+}
+
+// IsSubsetOf tests whether every element in `s` is in `t`
+func (s *Set) IsSubsetOf(t Set) bool {
+	if s.is_dense {
+		return s.bits.IsSubsetOf(t.as_bits())
+	}
+	return s.hash.IsSubsetOf(t.as_hash())
+}
+
+// IsProperSubsetOf tests whether every element in `s` is in `t`, but that
+// `s.Equals(t) == false`
+func (s *Set) IsProperSubsetOf(t Set) bool {
+	return s.length < t.length && s.IsSubsetOf(t)
+}
+
+// IsSuperSetOf tests whether every element in `t` is in `s`
+func (s *Set) IsSuperSetOf(t Set) bool {
+	return t.IsSubsetOf(*s)
+}
+
+// IsProperSuperSetOf tests whether every element in `t` is in `s`, but that
+// `s.Equals(t) == false`
+func (s *Set) IsProperSuperSetOf(t Set) bool {
+	return s.length > t.length && t.IsSubsetOf(*s)
+}
+
+// Difference returns a new set with elements in `s` that are not in `t`
+func (s *Set) Difference(t Set) Set {
+	if s.is_dense {
+		return from_bits(s.bits.Difference(t.as_bits()))
+	}
+	return from_hash(s.hash.Difference(t.as_hash()))
+}
+
+// DifferenceInPlace removes any elements in `s` that are in `t`
+func (s *Set) DifferenceInPlace(t Set) {
+	if s.is_dense {
+		s.bits.DifferenceInPlace(t.as_bits())
+		s.length = s.bits.Len()
+	} else {
+		s.hash.DifferenceInPlace(t.as_hash())
+		s.length = s.hash.Len()
+	}
+	s.rebalance()
+}
+
+// SymmetricDifference returns a new set with elements in either `s` or `t`, but not both
+func (s *Set) SymmetricDifference(t Set) Set {
+	if s.is_dense {
+		return from_bits(s.bits.SymmetricDifference(t.as_bits()))
+	}
+	return from_hash(s.hash.SymmetricDifference(t.as_hash()))
+}
+
+// SymmetricDifferenceInPlace removes any elements in `s` that are in `t`, and adds any
+// elements in `t` that are not in `s`
+func (s *Set) SymmetricDifferenceInPlace(t Set) {
+	if s.is_dense {
+		s.bits.SymmetricDifferenceInPlace(t.as_bits())
+		s.length = s.bits.Len()
+	} else {
+		s.hash.SymmetricDifferenceInPlace(t.as_hash())
+		s.length = s.hash.Len()
+	}
+	s.rebalance()
+}
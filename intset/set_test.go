@@ -0,0 +1,169 @@
+package intset
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/natemcintosh/set"
+	"golang.org/x/exp/slices"
+)
+
+func equal(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if v != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sorted(s Set) []int {
+	result := s.Slice()
+	slices.Sort(result)
+	return result
+}
+
+func TestRepresentation(t *testing.T) {
+	dense := make([]int, 0, 1000)
+	sparse := make([]int, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		dense = append(dense, i-500)
+		sparse = append(sparse, i*1000)
+	}
+
+	testCases := []struct {
+		desc string
+		in   []int
+		want bool
+	}{
+		{
+			desc: "small",
+			in:   []int{1, 2, 3},
+			want: false,
+		},
+		{
+			desc: "dense",
+			in:   dense,
+			want: true,
+		},
+		{
+			desc: "sparse",
+			in:   sparse,
+			want: false,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := NewSet(tC.in)
+			if got := s.IsDense(); got != tC.want {
+				t.Errorf("got dense %v, want %v", got, tC.want)
+			}
+			if s.Len() != len(tC.in) {
+				t.Errorf("got length %d, want %d", s.Len(), len(tC.in))
+			}
+		})
+	}
+}
+
+func TestMigration(t *testing.T) {
+	s := NewSet([]int{})
+	for i := 0; i < 1000; i++ {
+		s.Add(i)
+	}
+	if !s.IsDense() {
+		t.Errorf("adding a contiguous run should move to the bitset")
+	}
+
+	// Spread it back out by removing most of the run, and adding far away values
+	for i := 0; i < 1000; i++ {
+		if i%100 != 0 {
+			s.Discard(i)
+		}
+	}
+	for i := 1; i < 100; i++ {
+		s.Add(i * 1_000_000)
+	}
+	if s.IsDense() {
+		t.Errorf("a spread out set should move back to the hash set")
+	}
+	if s.Len() != 109 {
+		t.Errorf("got length %d, want 109", s.Len())
+	}
+}
+
+func TestMatchesHashSet(t *testing.T) {
+	// Run the same operations against intset and the hash set, and check they agree
+	for run := 0; run < 20; run++ {
+		spread := 1 + rand.Intn(10_000)
+		items1 := make([]int, rand.Intn(2000))
+		items2 := make([]int, rand.Intn(2000))
+		for i := range items1 {
+			items1[i] = rand.Intn(spread) - spread/2
+		}
+		for i := range items2 {
+			items2[i] = rand.Intn(spread) - spread/2
+		}
+
+		s1, s2 := NewSet(items1), NewSet(items2)
+		h1, h2 := set.NewSet(items1), set.NewSet(items2)
+
+		union := s1.Union(s2)
+		h_union := h1.Union(h2)
+		intersection := s1.Intersection(s2)
+		h_intersection := h1.Intersection(h2)
+		difference := s1.Difference(s2)
+		h_difference := h1.Difference(h2)
+		sym_diff := s1.SymmetricDifference(s2)
+		h_sym_diff := h1.SymmetricDifference(h2)
+
+		checks := []struct {
+			desc string
+			got  Set
+			want set.Set[int]
+		}{
+			{desc: "union", got: union, want: h_union},
+			{desc: "intersection", got: intersection, want: h_intersection},
+			{desc: "difference", got: difference, want: h_difference},
+			{desc: "symmetric difference", got: sym_diff, want: h_sym_diff},
+		}
+		for _, c := range checks {
+			want := c.want.Slice()
+			slices.Sort(want)
+			if got := sorted(c.got); !equal(got, want) {
+				t.Errorf("%s: got %v; want %v", c.desc, got, want)
+			}
+			if c.got.Len() != c.want.Len() {
+				t.Errorf("%s: got length %d; want %d", c.desc, c.got.Len(), c.want.Len())
+			}
+		}
+
+		if s1.IsSubsetOf(s2) != h1.IsSubsetOf(h2) {
+			t.Errorf("IsSubsetOf disagrees for %v and %v", s1, s2)
+		}
+		if s1.IsDisjoint(s2) != h1.IsDisjoint(h2) {
+			t.Errorf("IsDisjoint disagrees for %v and %v", s1, s2)
+		}
+	}
+}
+
+func TestPop(t *testing.T) {
+	s := NewSet([]int{1, 2, 3})
+	for i := 0; i < 3; i++ {
+		if _, err := s.Pop(); err != nil {
+			t.Errorf("got error %v, want nil", err)
+		}
+	}
+	if _, err := s.Pop(); err != ErrElementNotFound {
+		t.Errorf("got error %v, want %v", err, ErrElementNotFound)
+	}
+}
+
+func BenchmarkAdd(b *testing.B) {
+	s := NewSet([]int{})
+	for i := 0; i < b.N; i++ {
+		s.Add(i)
+	}
+}
@@ -0,0 +1,244 @@
+// kvset keeps a set in an embedded key-value store, such as bbolt or Badger, with one
+// key per item. Adding or removing an item writes or deletes just that key, and nothing
+// is read until it is needed, so a set far larger than memory survives restarts
+// without ever being serialized as a whole.
+//
+// The package doesn't import any store. Instead, a store is adapted to the small
+// `Store` interface. For bbolt, with every set kept in one bucket, that looks like
+//
+//	type bolt_store struct {
+//		db     *bolt.DB
+//		bucket []byte
+//	}
+//
+//	func (b bolt_store) Put(key []byte) error {
+//		return b.db.Update(func(tx *bolt.Tx) error {
+//			return tx.Bucket(b.bucket).Put(key, []byte{})
+//		})
+//	}
+//
+// and the same for Delete, Has and Scan, using a cursor `Seek` to the prefix.
+package kvset
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/natemcintosh/set"
+)
+
+var (
+	// This error is returned when you try to remove an item from a set that doesn't
+	// exist. It is set.ErrElementNotFound, so errors.Is works the same for every set.
+	ErrElementNotFound = set.ErrElementNotFound
+)
+
+// Store is what a Set needs from a key-value store. Only keys are stored; their values
+// are empty. Each method should be its own transaction, so a write is durable once it
+// returns.
+type Store interface {
+	// Put stores `key`. It is not an error if it is already there.
+	Put(key []byte) error
+	// Delete removes `key`. It is not an error if it is not there.
+	Delete(key []byte) error
+	// Has returns true if `key` is stored
+	Has(key []byte) (bool, error)
+	// Scan calls `f` on every key starting with `prefix`, in ascending byte order,
+	// stopping early if `f` returns false. `f` must not keep the key after it returns.
+	Scan(prefix []byte, f func(key []byte) bool) error
+}
+
+// Codec turns items into the bytes of a key and back
+type Codec[T comparable] struct {
+	Encode func(item T) []byte
+	Decode func(key []byte) (T, error)
+}
+
+// StringCodec stores strings as their bytes
+var StringCodec = Codec[string]{
+	Encode: func(item string) []byte { return []byte(item) },
+	Decode: func(key []byte) (string, error) { return string(key), nil },
+}
+
+// IntCodec stores ints as 8 big-endian bytes with the sign bit flipped, so the store
+// keeps them in numeric order
+var IntCodec = Codec[int]{
+	Encode: func(item int) []byte {
+		return binary.BigEndian.AppendUint64(nil, uint64(item)^(1<<63))
+	},
+	Decode: func(key []byte) (int, error) {
+		if len(key) != 8 {
+			return 0, fmt.Errorf("kvset: int key has %d bytes, want 8", len(key))
+		}
+		u := binary.BigEndian.Uint64(key) ^ (1 << 63)
+		if int64(u) < math.MinInt || int64(u) > math.MaxInt {
+			return 0, fmt.Errorf("kvset: %d doesn't fit in an int", int64(u))
+		}
+		return int(u), nil
+	},
+}
+
+// Set is a set kept in a Store. Its keys all start with a prefix, so many sets can share
+// one store. Methods read and write the store directly, so they return the store's
+// errors. A Set is not safe for concurrent use, as it keeps its own count of the items;
+// guard it with a mutex, or give each goroutine its own Set and don't rely on `Len`.
+type Set[T comparable] struct {
+	store  Store
+	prefix []byte
+	codec  Codec[T]
+
+	// length is the number of items, or -1 until `Len` has counted them
+	length int
+}
+
+// Open will return the set kept in `store` under `prefix`. Nothing is read from the
+// store until it is needed. No two sets should use prefixes where one starts with the
+// other, as their keys would mix.
+func Open[T comparable](store Store, prefix string, codec Codec[T]) *Set[T] {
+	return &Set[T]{store: store, prefix: []byte(prefix), codec: codec, length: -1}
+}
+
+// key returns the store key for `item`
+func (s *Set[T]) key(item T) []byte {
+	return append(bytes.Clone(s.prefix), s.codec.Encode(item)...)
+}
+
+// Contains will return true if the item is in the set
+func (s *Set[T]) Contains(item T) (bool, error) {
+	return s.store.Has(s.key(item))
+}
+
+// Add will add an item to the set, writing a single key
+func (s *Set[T]) Add(item T) error {
+	key := s.key(item)
+	if s.length >= 0 {
+		// Only needed to keep the count right
+		found, err := s.store.Has(key)
+		if err != nil || found {
+			return err
+		}
+	}
+	if err := s.store.Put(key); err != nil {
+		return err
+	}
+	if s.length >= 0 {
+		s.length++
+	}
+	return nil
+}
+
+// TryRemove removes an item from the set, and returns true if it was there
+func (s *Set[T]) TryRemove(item T) (bool, error) {
+	key := s.key(item)
+	found, err := s.store.Has(key)
+	if err != nil || !found {
+		return false, err
+	}
+	if err := s.store.Delete(key); err != nil {
+		return false, err
+	}
+	if s.length >= 0 {
+		s.length--
+	}
+	return true, nil
+}
+
+// Remove removes an item from the set. Returns a *set.NotFoundError if it isn't there.
+func (s *Set[T]) Remove(item T) error {
+	found, err := s.TryRemove(item)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return &set.NotFoundError[T]{Item: item}
+	}
+	return nil
+}
+
+// Discard removes an item from the set if it is there
+func (s *Set[T]) Discard(item T) error {
+	_, err := s.TryRemove(item)
+	return err
+}
+
+// Iterate calls `f` on every item in the set, in the order the store keeps their keys,
+// reading them as it goes. It stops early if `f` returns false.
+func (s *Set[T]) Iterate(f func(item T) bool) error {
+	var decode_err error
+	err := s.store.Scan(s.prefix, func(key []byte) bool {
+		item, err := s.codec.Decode(key[len(s.prefix):])
+		if err != nil {
+			decode_err = err
+			return false
+		}
+		return f(item)
+	})
+	if err != nil {
+		return err
+	}
+	return decode_err
+}
+
+// Len returns the number of items in the set. The first call counts the keys in the
+// store; after that, the count is kept up to date as items are added and removed
+// through this Set.
+func (s *Set[T]) Len() (int, error) {
+	if s.length >= 0 {
+		return s.length, nil
+	}
+	n := 0
+	err := s.store.Scan(s.prefix, func([]byte) bool {
+		n++
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	s.length = n
+	return n, nil
+}
+
+// Load reads every item of the set into memory
+func (s *Set[T]) Load() (set.Set[T], error) {
+	result := set.NewSet([]T{})
+	err := s.Iterate(func(item T) bool {
+		result.Add(item)
+		return true
+	})
+	if err != nil {
+		return set.Set[T]{}, err
+	}
+	return result, nil
+}
+
+// AddSet will add every item of `items`, one key each
+func (s *Set[T]) AddSet(items set.Set[T]) error {
+	var err error
+	items.Iterate(func(item T) bool {
+		err = s.Add(item)
+		return err == nil
+	})
+	return err
+}
+
+// Clear will delete every key of the set from the store
+func (s *Set[T]) Clear() error {
+	// Gather the keys first, as stores don't allow deleting while scanning
+	var keys [][]byte
+	err := s.store.Scan(s.prefix, func(key []byte) bool {
+		keys = append(keys, bytes.Clone(key))
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := s.store.Delete(key); err != nil {
+			return err
+		}
+	}
+	s.length = 0
+	return nil
+}
@@ -0,0 +1,113 @@
+package kvset
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/natemcintosh/set"
+	"golang.org/x/exp/slices"
+)
+
+func TestSet(t *testing.T) {
+	store := NewMemStore()
+	s := Open(store, "ids/", IntCodec)
+
+	for _, v := range []int{5, -3, 100, 5} {
+		if err := s.Add(v); err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+	}
+	if n, err := s.Len(); err != nil || n != 3 {
+		t.Errorf("got %v, %v; want 3, nil", n, err)
+	}
+	if found, err := s.Contains(-3); err != nil || !found {
+		t.Errorf("got %v, %v; want true, nil", found, err)
+	}
+
+	// Adding and removing keep the count up to date
+	if err := s.Add(7); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Remove(5); err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+	err := s.Remove(5)
+	if !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrElementNotFound)
+	}
+	var not_found *set.NotFoundError[int]
+	if !errors.As(err, &not_found) || not_found.Item != 5 {
+		t.Errorf("got error %v, want a *set.NotFoundError[int] for 5", err)
+	}
+	if err := s.Discard(1000); err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+	if n, _ := s.Len(); n != 3 {
+		t.Errorf("got length %d; want 3", n)
+	}
+
+	// The int codec keeps the keys in numeric order
+	got := []int{}
+	if err := s.Iterate(func(item int) bool {
+		got = append(got, item)
+		return true
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{-3, 7, 100}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestReopen(t *testing.T) {
+	store := NewMemStore()
+	names := Open(store, "names/", StringCodec)
+	if err := names.AddSet(set.NewSet([]string{"ann", "bob"})); err != nil {
+		t.Fatal(err)
+	}
+	other := Open(store, "other/", StringCodec)
+	if err := other.Add("zed"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A set opened again later sees the same items, and not those of other prefixes
+	reopened := Open(store, "names/", StringCodec)
+	loaded, err := reopened.Load()
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if want := set.NewSet([]string{"ann", "bob"}); !loaded.Equals(want) {
+		t.Errorf("got %v; want %v", loaded, want)
+	}
+
+	if err := reopened.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := Open(store, "names/", StringCodec).Len(); n != 0 {
+		t.Errorf("got length %d after Clear; want 0", n)
+	}
+	if n, _ := other.Len(); n != 1 {
+		t.Errorf("Clear should leave other prefixes alone, got length %d", n)
+	}
+}
+
+func TestIntCodec(t *testing.T) {
+	items := []int{math.MinInt, -1000, -1, 0, 1, 1000, math.MaxInt}
+	var prev []byte
+	for _, v := range items {
+		key := IntCodec.Encode(v)
+		got, err := IntCodec.Decode(key)
+		if err != nil || got != v {
+			t.Errorf("got %v, %v; want %v, nil", got, err, v)
+		}
+		if prev != nil && string(prev) >= string(key) {
+			t.Errorf("the key of %d should sort after the one before it", v)
+		}
+		prev = key
+	}
+
+	if _, err := IntCodec.Decode([]byte{1, 2, 3}); err == nil {
+		t.Errorf("decoding 3 bytes should fail")
+	}
+}
@@ -0,0 +1,62 @@
+package kvset
+
+import (
+	"strings"
+	"sync"
+
+	"golang.org/x/exp/slices"
+)
+
+// MemStore is a Store held in memory, for tests, and for trying out a Set before
+// choosing a real store. It is safe for concurrent use.
+type MemStore struct {
+	mu   sync.RWMutex
+	keys map[string]struct{}
+}
+
+// NewMemStore will return an empty MemStore
+func NewMemStore() *MemStore {
+	return &MemStore{keys: make(map[string]struct{})}
+}
+
+func (m *MemStore) Put(key []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys[string(key)] = struct{}{}
+	return nil
+}
+
+func (m *MemStore) Delete(key []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.keys, string(key))
+	return nil
+}
+
+func (m *MemStore) Has(key []byte) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.keys[string(key)]
+	return ok, nil
+}
+
+// Scan sorts the matching keys before calling `f`, so it is O(n log n) in the number of
+// keys with the prefix
+func (m *MemStore) Scan(prefix []byte, f func(key []byte) bool) error {
+	m.mu.RLock()
+	matching := make([]string, 0)
+	for k := range m.keys {
+		if strings.HasPrefix(k, string(prefix)) {
+			matching = append(matching, k)
+		}
+	}
+	m.mu.RUnlock()
+
+	slices.Sort(matching)
+	for _, k := range matching {
+		if !f([]byte(k)) {
+			break
+		}
+	}
+	return nil
+}
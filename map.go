@@ -0,0 +1,31 @@
+package set
+
+import (
+	"maps"
+)
+
+// FromMap will return a Set holding the keys of `m`. The set takes ownership of `m`
+// rather than copying it, so it costs nothing, but `m` must not be used afterwards except
+// through the set. A nil map gives an empty set.
+func FromMap[T comparable](m map[T]struct{}) Set[T] {
+	if m == nil {
+		m = make(map[T]struct{})
+	}
+	return Set[T]{data: m}
+}
+
+// AsMap returns a copy of the set as a map with the items as keys. Changing the map does
+// not change the set.
+func (s *Set[T]) AsMap() map[T]struct{} {
+	result := make(map[T]struct{}, len(s.data))
+	maps.Copy(result, s.data)
+	return result
+}
+
+// UnsafeAsMap returns the map the set keeps its items in, without copying it. Changes to
+// the map are changes to the set, and changes to the set show up in the map. It is
+// meant for handing a set to code that takes a `map[T]struct{}` and only reads it. The
+// map is nil for the zero value Set.
+func (s *Set[T]) UnsafeAsMap() map[T]struct{} {
+	return s.data
+}
@@ -0,0 +1,66 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestFromMap(t *testing.T) {
+	m := map[int]struct{}{1: {}, 2: {}, 3: {}}
+	s := FromMap(m)
+	if want := NewSet([]int{1, 2, 3}); !s.Equals(want) {
+		t.Errorf("got %v; want %v", s, want)
+	}
+
+	// The set uses the map itself
+	s.Add(4)
+	if _, ok := m[4]; !ok {
+		t.Errorf("FromMap should take the map without copying it")
+	}
+
+	// Clearing the set empties the same map, rather than swapping in a new one
+	s.Clear()
+	s.Add(5)
+	if _, ok := m[5]; !ok || len(m) != 1 {
+		t.Errorf("got %v; want the map to hold only 5 after Clear and Add", m)
+	}
+
+	// A nil map gives a set that can be added to
+	empty := FromMap[int](nil)
+	empty.Add(1)
+	if empty.Len() != 1 {
+		t.Errorf("got %v; want {1}", empty)
+	}
+}
+
+func TestAsMap(t *testing.T) {
+	s := NewSet([]string{"a", "b"})
+
+	m := s.AsMap()
+	if len(m) != 2 {
+		t.Errorf("got %v; want 2 keys", m)
+	}
+	m["c"] = struct{}{}
+	if s.Contains("c") {
+		t.Errorf("AsMap should return a copy")
+	}
+
+	u := s.UnsafeAsMap()
+	u["d"] = struct{}{}
+	if !s.Contains("d") {
+		t.Errorf("UnsafeAsMap should return the set's own map")
+	}
+
+	s.Clear()
+	if len(u) != 0 {
+		t.Errorf("got %v; want Clear to empty the map from UnsafeAsMap", u)
+	}
+
+	// RetainSlice also changes the map in place
+	for _, v := range []string{"x", "y", "z"} {
+		s.Add(v)
+	}
+	s.RetainSlice([]string{"y", "q"})
+	if _, ok := u["y"]; !ok || len(u) != 1 {
+		t.Errorf("got %v; want RetainSlice to leave only y in the map from UnsafeAsMap", u)
+	}
+}
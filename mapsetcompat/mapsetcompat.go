@@ -0,0 +1,275 @@
+// mapsetcompat helps projects move between github.com/deckarep/golang-set/v2 and
+// `github.com/natemcintosh/set` one call site at a time.
+//
+// `Adapter` wraps a `set.Set` with the same method names and behaviour as mapset's
+// Set[T], so code written against mapset's methods keeps working. Go interfaces are
+// matched on exact method signatures, and mapset's methods take and return mapset.Set
+// itself, so no type outside of that library can satisfy mapset.Set directly. This
+// package has no dependencies, so it declares the same method set as its own `Set`
+// interface instead; swap the import in code that only names the interface.
+//
+// `FromMapset` and `ToMapset` convert real mapset values in both directions, without
+// this package needing to import mapset.
+package mapsetcompat
+
+import (
+	"encoding/json"
+
+	"github.com/natemcintosh/set"
+)
+
+// Set has the same methods as mapset.Set[T], apart from `Iterator`, which returns a
+// type that only exists in mapset
+type Set[T comparable] interface {
+	Add(val T) bool
+	Append(val ...T) int
+	Cardinality() int
+	Clear()
+	Clone() Set[T]
+	Contains(val ...T) bool
+	ContainsOne(val T) bool
+	ContainsAny(val ...T) bool
+	Difference(other Set[T]) Set[T]
+	Each(func(T) bool)
+	Equal(other Set[T]) bool
+	Intersect(other Set[T]) Set[T]
+	IsEmpty() bool
+	IsProperSubset(other Set[T]) bool
+	IsProperSuperset(other Set[T]) bool
+	IsSubset(other Set[T]) bool
+	IsSuperset(other Set[T]) bool
+	Iter() <-chan T
+	Pop() (T, bool)
+	Remove(i T)
+	RemoveAll(i ...T)
+	String() string
+	SymmetricDifference(other Set[T]) Set[T]
+	ToSlice() []T
+	Union(other Set[T]) Set[T]
+	MarshalJSON() ([]byte, error)
+	UnmarshalJSON(b []byte) error
+}
+
+// Adapter gives a `set.Set` the methods of mapset.Set[T]
+type Adapter[T comparable] struct {
+	s set.Set[T]
+}
+
+// Wrap will return an Adapter around `s`. The adapter shares storage with `s`, so
+// changes made through either one are seen by both.
+func Wrap[T comparable](s set.Set[T]) *Adapter[T] {
+	return &Adapter[T]{s: s}
+}
+
+// NewSet will return an Adapter holding the values, like mapset.NewSet
+func NewSet[T comparable](vals ...T) *Adapter[T] {
+	return Wrap(set.NewSet(vals))
+}
+
+// Unwrap will return the `set.Set` behind `other`. If `other` is an Adapter, its set is
+// returned without copying. Otherwise the values are copied into a new set.
+func Unwrap[T comparable](other Set[T]) set.Set[T] {
+	if a, ok := other.(*Adapter[T]); ok {
+		return a.s
+	}
+	return set.NewSet(other.ToSlice())
+}
+
+// FromMapset will return a new `set.Set` holding the values of a mapset.Set[T], or of
+// anything else with a `ToSlice` method
+func FromMapset[T comparable](m interface{ ToSlice() []T }) set.Set[T] {
+	return set.NewSet(m.ToSlice())
+}
+
+// ToMapset will return the values of `s` in a new mapset.Set[T]. Pass the mapset
+// constructor to use, such as mapset.NewSet[T] or mapset.NewThreadUnsafeSet[T].
+func ToMapset[T comparable, M any](s set.Set[T], new_set func(vals ...T) M) M {
+	return new_set(s.Slice()...)
+}
+
+// Set will return the `set.Set` behind the adapter, without copying
+func (a *Adapter[T]) Set() set.Set[T] {
+	return a.s
+}
+
+// Add adds `val`, and returns true if it was not already in the set
+func (a *Adapter[T]) Add(val T) bool {
+	if a.s.Contains(val) {
+		return false
+	}
+	a.s.Add(val)
+	return true
+}
+
+// Append adds every value, and returns how many were not already in the set
+func (a *Adapter[T]) Append(val ...T) int {
+	added := 0
+	for _, v := range val {
+		if a.Add(v) {
+			added += 1
+		}
+	}
+	return added
+}
+
+// Cardinality returns the number of values in the set
+func (a *Adapter[T]) Cardinality() int {
+	return a.s.Len()
+}
+
+// Clear removes every value. The set is emptied in place, so a `set.Set` passed to
+// `Wrap` is emptied too.
+func (a *Adapter[T]) Clear() {
+	a.s.Clear()
+}
+
+// Clone returns a copy of the set
+func (a *Adapter[T]) Clone() Set[T] {
+	return Wrap(a.s.Copy())
+}
+
+// Contains returns true if every value is in the set
+func (a *Adapter[T]) Contains(val ...T) bool {
+	for _, v := range val {
+		if !a.s.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsOne returns true if `val` is in the set
+func (a *Adapter[T]) ContainsOne(val T) bool {
+	return a.s.Contains(val)
+}
+
+// ContainsAny returns true if at least one of the values is in the set
+func (a *Adapter[T]) ContainsAny(val ...T) bool {
+	for _, v := range val {
+		if a.s.Contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Difference returns a new set with the values that are not in `other`
+func (a *Adapter[T]) Difference(other Set[T]) Set[T] {
+	return Wrap(a.s.Difference(Unwrap(other)))
+}
+
+// Each calls `f` on every value. Following mapset, iteration stops as soon as `f`
+// returns true.
+func (a *Adapter[T]) Each(f func(T) bool) {
+	for _, v := range a.s.Slice() {
+		if f(v) {
+			return
+		}
+	}
+}
+
+// Equal returns true if both sets hold the same values
+func (a *Adapter[T]) Equal(other Set[T]) bool {
+	return a.s.Equals(Unwrap(other))
+}
+
+// Intersect returns a new set with the values that are also in `other`
+func (a *Adapter[T]) Intersect(other Set[T]) Set[T] {
+	return Wrap(a.s.Intersection(Unwrap(other)))
+}
+
+// IsEmpty returns true if the set holds no values
+func (a *Adapter[T]) IsEmpty() bool {
+	return a.s.IsEmpty()
+}
+
+// IsProperSubset returns true if every value is in `other`, and the sets are not equal
+func (a *Adapter[T]) IsProperSubset(other Set[T]) bool {
+	return a.s.IsProperSubsetOf(Unwrap(other))
+}
+
+// IsProperSuperset returns true if every value of `other` is in the set, and the sets
+// are not equal
+func (a *Adapter[T]) IsProperSuperset(other Set[T]) bool {
+	return a.s.IsProperSuperSetOf(Unwrap(other))
+}
+
+// IsSubset returns true if every value is in `other`
+func (a *Adapter[T]) IsSubset(other Set[T]) bool {
+	return a.s.IsSubsetOf(Unwrap(other))
+}
+
+// IsSuperset returns true if every value of `other` is in the set
+func (a *Adapter[T]) IsSuperset(other Set[T]) bool {
+	return a.s.IsSuperSetOf(Unwrap(other))
+}
+
+// Iter returns a channel that receives every value, and is then closed. The values are
+// taken from a snapshot of the set, so it is safe to change the set while reading.
+func (a *Adapter[T]) Iter() <-chan T {
+	vals := a.s.Slice()
+	ch := make(chan T)
+	go func() {
+		for _, v := range vals {
+			ch <- v
+		}
+		close(ch)
+	}()
+	return ch
+}
+
+// Pop removes and returns an arbitrary value. The bool is false if the set was empty.
+func (a *Adapter[T]) Pop() (T, bool) {
+	v, err := a.s.Pop()
+	return v, err == nil
+}
+
+// Remove removes `i`, if it is in the set
+func (a *Adapter[T]) Remove(i T) {
+	a.s.Discard(i)
+}
+
+// RemoveAll removes every one of the values that is in the set
+func (a *Adapter[T]) RemoveAll(i ...T) {
+	for _, v := range i {
+		a.s.Discard(v)
+	}
+}
+
+// String formats the set the same way as `set.Set`
+func (a *Adapter[T]) String() string {
+	return a.s.String()
+}
+
+// SymmetricDifference returns a new set with the values in exactly one of the two sets
+func (a *Adapter[T]) SymmetricDifference(other Set[T]) Set[T] {
+	return Wrap(a.s.SymmetricDifference(Unwrap(other)))
+}
+
+// ToSlice returns the values as a slice, in no particular order
+func (a *Adapter[T]) ToSlice() []T {
+	return a.s.Slice()
+}
+
+// Union returns a new set with the values in either set
+func (a *Adapter[T]) Union(other Set[T]) Set[T] {
+	return Wrap(a.s.Union(Unwrap(other)))
+}
+
+// MarshalJSON encodes the set as a JSON array, like mapset
+func (a *Adapter[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.s.Slice())
+}
+
+// UnmarshalJSON adds the values of a JSON array to the set, like mapset. They are added
+// to the existing set, so a `set.Set` passed to `Wrap` sees them.
+func (a *Adapter[T]) UnmarshalJSON(b []byte) error {
+	var vals []T
+	if err := json.Unmarshal(b, &vals); err != nil {
+		return err
+	}
+	for _, v := range vals {
+		a.s.Add(v)
+	}
+	return nil
+}
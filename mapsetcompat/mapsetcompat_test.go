@@ -0,0 +1,114 @@
+package mapsetcompat
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/natemcintosh/set"
+)
+
+// Make sure the adapter keeps up with the interface
+var _ Set[int] = (*Adapter[int])(nil)
+
+// fake_mapset stands in for a mapset.Set, which is only used through ToSlice
+type fake_mapset []string
+
+func (f fake_mapset) ToSlice() []string { return f }
+
+func TestAdapter(t *testing.T) {
+	s := set.NewSet([]int{1, 2, 3})
+	var a Set[int] = Wrap(s)
+
+	if a.Add(3) {
+		t.Errorf("adding an existing value should return false")
+	}
+	if !a.Add(4) {
+		t.Errorf("adding a new value should return true")
+	}
+	// The adapter shares storage with the original set
+	if !s.Contains(4) {
+		t.Errorf("adding through the adapter should change the wrapped set")
+	}
+
+	if got := a.Append(4, 5, 6); got != 2 {
+		t.Errorf("got %d added, want 2", got)
+	}
+	if !a.Contains(1, 5) || a.Contains(1, 10) || !a.ContainsAny(10, 1) {
+		t.Errorf("Contains and ContainsAny disagree with the set %v", a)
+	}
+
+	other := NewSet(5, 6, 7)
+	if got, want := Unwrap(a.Intersect(other)), set.NewSet([]int{5, 6}); !got.Equals(want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+	if got, want := Unwrap(a.Difference(other)), set.NewSet([]int{1, 2, 3, 4}); !got.Equals(want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	count := 0
+	a.Each(func(int) bool {
+		count += 1
+		return count == 2
+	})
+	if count != 2 {
+		t.Errorf("Each should stop once the callback returns true, went %d times", count)
+	}
+
+	seen := 0
+	for range a.Iter() {
+		seen += 1
+	}
+	if seen != a.Cardinality() {
+		t.Errorf("Iter gave %d values, want %d", seen, a.Cardinality())
+	}
+}
+
+func TestJSON(t *testing.T) {
+	a := NewSet("a", "b")
+	data, err := json.Marshal(a)
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	b := NewSet[string]()
+	if err := json.Unmarshal(data, b); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if !a.Equal(b) {
+		t.Errorf("got %v; want %v", b, a)
+	}
+}
+
+func TestSharedStorage(t *testing.T) {
+	s := set.NewSet([]string{"a"})
+	a := Wrap(s)
+
+	// Clearing through the adapter empties the wrapped set, and later adds still reach it
+	a.Clear()
+	a.Add("b")
+	if want := set.NewSet([]string{"b"}); !s.Equals(want) {
+		t.Errorf("got %v; want %v", s, want)
+	}
+
+	// So does decoding into an empty adapter
+	a.Clear()
+	if err := json.Unmarshal([]byte(`["c", "d"]`), a); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if want := set.NewSet([]string{"c", "d"}); !s.Equals(want) {
+		t.Errorf("got %v; want %v", s, want)
+	}
+}
+
+func TestConversions(t *testing.T) {
+	m := fake_mapset{"x", "y", "x"}
+	got := FromMapset[string](m)
+	if want := set.NewSet([]string{"x", "y"}); !got.Equals(want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	back := ToMapset(got, func(vals ...string) fake_mapset { return fake_mapset(vals) })
+	if len(back) != 2 {
+		t.Errorf("got %v; want 2 values", back)
+	}
+}
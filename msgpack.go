@@ -0,0 +1,59 @@
+package set
+
+import (
+	"fmt"
+
+	"github.com/natemcintosh/set/internal/msgpack"
+)
+
+// MarshalMsgpack encodes the set as a MessagePack array of its items, in no particular
+// order. The item type must have a boolean, integer, float, or string as its underlying
+// type.
+func (s Set[T]) MarshalMsgpack() ([]byte, error) {
+	result := msgpack.AppendArrayHeader(nil, s.Len())
+	w := scalar_writer{
+		Bool:   func(v bool) { result = msgpack.AppendBool(result, v) },
+		Int:    func(v int64) { result = msgpack.AppendInt(result, v) },
+		Uint:   func(v uint64) { result = msgpack.AppendUint(result, v) },
+		Float:  func(v float64) { result = msgpack.AppendFloat(result, v) },
+		String: func(v string) { result = msgpack.AppendString(result, v) },
+	}
+	for v := range s.data {
+		if !write_scalar(v, w) {
+			return nil, fmt.Errorf("set: cannot encode %T as msgpack", v)
+		}
+	}
+	return result, nil
+}
+
+// UnmarshalMsgpack replaces the contents of the set with the items of a MessagePack
+// array. See `MarshalMsgpack` for the supported item types.
+func (s *Set[T]) UnmarshalMsgpack(data []byte) error {
+	d := msgpack.NewDecoder(data)
+	n, err := d.ReadArrayHeader()
+	if err != nil {
+		return err
+	}
+
+	r := scalar_reader{
+		Bool:   d.ReadBool,
+		Int:    func(int) (int64, error) { return d.ReadInt() },
+		Uint:   func(int) (uint64, error) { return d.ReadUint() },
+		Float:  func(int) (float64, error) { return d.ReadFloat() },
+		String: d.ReadString,
+	}
+	result := make(map[T]struct{}, n)
+	for i := 0; i < n; i++ {
+		item, err := read_scalar[T](r, "msgpack")
+		if err != nil {
+			return err
+		}
+		result[item] = struct{}{}
+	}
+	if !d.Done() {
+		return msgpack.ErrMalformed
+	}
+
+	s.data = result
+	return nil
+}
@@ -0,0 +1,99 @@
+package set
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	ints := NewSet([]int{-1 << 40, -1000, -33, -32, -1, 0, 1, 127, 128, 1 << 40})
+	data, err := ints.MarshalMsgpack()
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	var got_ints Set[int]
+	if err := got_ints.UnmarshalMsgpack(data); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if !got_ints.Equals(ints) {
+		t.Errorf("got %v; want %v", got_ints, ints)
+	}
+
+	strs := NewSet([]string{"", "a", "a string that is longer than thirty one bytes"})
+	data, err = strs.MarshalMsgpack()
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	var got_strs Set[string]
+	if err := got_strs.UnmarshalMsgpack(data); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if !got_strs.Equals(strs) {
+		t.Errorf("got %v; want %v", got_strs, strs)
+	}
+
+	uints := NewSet([]uint64{0, 1 << 63})
+	data, err = uints.MarshalMsgpack()
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	var got_uints Set[uint64]
+	if err := got_uints.UnmarshalMsgpack(data); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if !got_uints.Equals(uints) {
+		t.Errorf("got %v; want %v", got_uints, uints)
+	}
+}
+
+func TestMsgpackEncoding(t *testing.T) {
+	testCases := []struct {
+		desc string
+		got  func() ([]byte, error)
+		want []byte
+	}{
+		{
+			desc: "empty",
+			got:  NewSet([]int{}).MarshalMsgpack,
+			want: []byte{0x90},
+		},
+		{
+			desc: "negative fixint",
+			got:  NewSet([]int{-1}).MarshalMsgpack,
+			want: []byte{0x91, 0xff},
+		},
+		{
+			desc: "uint16",
+			got:  NewSet([]int{300}).MarshalMsgpack,
+			want: []byte{0x91, 0xcd, 0x01, 0x2c},
+		},
+		{
+			desc: "fixstr",
+			got:  NewSet([]string{"hi"}).MarshalMsgpack,
+			want: []byte{0x91, 0xa2, 0x68, 0x69},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := tC.got()
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if !bytes.Equal(got, tC.want) {
+				t.Errorf("got %x; want %x", got, tC.want)
+			}
+		})
+	}
+}
+
+func TestMsgpackErrors(t *testing.T) {
+	var small Set[uint8]
+	if err := small.UnmarshalMsgpack([]byte{0x91, 0xcd, 0x01, 0x2c}); err == nil {
+		t.Errorf("300 should overflow a uint8")
+	}
+
+	var ints Set[int]
+	if err := ints.UnmarshalMsgpack([]byte{0x92, 0x01}); err == nil {
+		t.Errorf("truncated input should fail")
+	}
+}
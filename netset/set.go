@@ -0,0 +1,394 @@
+// netset is a set of IP addresses. Members are stored as sorted, non-overlapping ranges
+// of addresses, so whole CIDR blocks can be added without enumerating every address in
+// them.
+package netset
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/netip"
+	"strings"
+)
+
+// addr_range is an inclusive range of addresses. `lo` and `hi` are always of the same
+// address family.
+type addr_range struct {
+	lo netip.Addr
+	hi netip.Addr
+}
+
+type Set struct {
+	// ranges is kept sorted by `lo`, and no two ranges overlap or touch
+	ranges []addr_range
+}
+
+// NewSet will return a Set containing every address in each of the prefixes
+func NewSet[S ~[]netip.Prefix](data S) Set {
+	result := Set{ranges: make([]addr_range, 0, len(data))}
+	for _, p := range data {
+		result.AddPrefix(p)
+	}
+	return result
+}
+
+// NewSetFromAddrs will return a Set containing each of the addresses
+func NewSetFromAddrs[S ~[]netip.Addr](data S) Set {
+	result := Set{ranges: make([]addr_range, 0, len(data))}
+	for _, a := range data {
+		result.AddAddr(a)
+	}
+	return result
+}
+
+// ReadList reads an allow/deny style list of addresses and CIDR prefixes, one per line.
+// Blank lines are skipped, and anything after a '#' is treated as a comment. Returns an
+// error naming the line number of the first entry that cannot be parsed.
+func ReadList(r io.Reader) (Set, error) {
+	result := Set{}
+	scanner := bufio.NewScanner(r)
+	line_number := 0
+	for scanner.Scan() {
+		line_number += 1
+		line := scanner.Text()
+		if idx := strings.IndexByte(line, '#'); idx >= 0 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if strings.Contains(line, "/") {
+			p, err := netip.ParsePrefix(line)
+			if err != nil {
+				return Set{}, fmt.Errorf("line %d: %w", line_number, err)
+			}
+			result.AddPrefix(p)
+		} else {
+			a, err := netip.ParseAddr(line)
+			if err != nil {
+				return Set{}, fmt.Errorf("line %d: %w", line_number, err)
+			}
+			result.AddAddr(a)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Set{}, err
+	}
+	return result, nil
+}
+
+func (s Set) String() string {
+	var b strings.Builder
+	b.WriteString("{")
+	for idx, p := range s.Prefixes() {
+		if idx > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(p.String())
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// Prefixes will return the smallest list of CIDR prefixes that covers exactly the
+// addresses in the set, in ascending order. Adjacent prefixes are aggregated, so adding
+// 10.0.0.0/25 and 10.0.0.128/25 will give back 10.0.0.0/24.
+func (s *Set) Prefixes() []netip.Prefix {
+	result := make([]netip.Prefix, 0, len(s.ranges))
+	for _, r := range s.ranges {
+		result = append_range_prefixes(result, r.lo, r.hi)
+	}
+	return result
+}
+
+// ContainsAddr will return true if the address is in the set
+func (s *Set) ContainsAddr(a netip.Addr) bool {
+	if !a.IsValid() {
+		return false
+	}
+	a = a.Unmap()
+	idx := s.search(a)
+	return idx < len(s.ranges) && s.ranges[idx].lo.Compare(a) <= 0
+}
+
+// ContainsPrefix will return true if every address in the prefix is in the set
+func (s *Set) ContainsPrefix(p netip.Prefix) bool {
+	if !p.IsValid() {
+		return false
+	}
+	lo, hi := prefix_range(p)
+	idx := s.search(lo)
+	return idx < len(s.ranges) &&
+		s.ranges[idx].lo.Compare(lo) <= 0 &&
+		s.ranges[idx].hi.Compare(hi) >= 0
+}
+
+// IsEmpty returns true if the set is empty
+func (s *Set) IsEmpty() bool {
+	return len(s.ranges) == 0
+}
+
+// AddAddr will add a single address to `s`. Invalid addresses are ignored
+func (s *Set) AddAddr(a netip.Addr) {
+	if !a.IsValid() {
+		return
+	}
+	a = a.Unmap()
+	s.add_range(addr_range{lo: a, hi: a})
+}
+
+// AddPrefix will add every address in the prefix to `s`. Invalid prefixes are ignored
+func (s *Set) AddPrefix(p netip.Prefix) {
+	if !p.IsValid() {
+		return
+	}
+	lo, hi := prefix_range(p)
+	s.add_range(addr_range{lo: lo, hi: hi})
+}
+
+// DiscardAddr removes a single address from the set. If it doesn't exist, it is ignored
+func (s *Set) DiscardAddr(a netip.Addr) {
+	if !a.IsValid() {
+		return
+	}
+	a = a.Unmap()
+	s.ranges = subtract_ranges(s.ranges, []addr_range{{lo: a, hi: a}})
+}
+
+// DiscardPrefix removes every address in the prefix from the set. Addresses that are
+// not in the set are ignored
+func (s *Set) DiscardPrefix(p netip.Prefix) {
+	if !p.IsValid() {
+		return
+	}
+	lo, hi := prefix_range(p)
+	s.ranges = subtract_ranges(s.ranges, []addr_range{{lo: lo, hi: hi}})
+}
+
+// Clear will remove all addresses from the set
+func (s *Set) Clear() {
+	s.ranges = nil
+}
+
+// Copy makes a deep copy of the set
+func (s *Set) Copy() Set {
+	ranges := make([]addr_range, len(s.ranges))
+	copy(ranges, s.ranges)
+	return Set{ranges: ranges}
+}
+
+// Equals will return true if `s` and `t` contain the same addresses
+func (s *Set) Equals(t Set) bool {
+	if len(s.ranges) != len(t.ranges) {
+		return false
+	}
+	for idx, r := range s.ranges {
+		if r != t.ranges[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+// Union will create a new Set, and fill it with the union of `s` and `t`
+func (s *Set) Union(t Set) Set {
+	return Set{ranges: union_ranges(s.ranges, t.ranges)}
+}
+
+// UnionInPlace will add all the addresses in set `t` to set `s`
+func (s *Set) UnionInPlace(t Set) {
+	s.ranges = union_ranges(s.ranges, t.ranges)
+}
+
+// Intersection will create a new Set, and fill it with the intersection of `s` and `t`
+func (s *Set) Intersection(t Set) Set {
+	return Set{ranges: intersect_ranges(s.ranges, t.ranges)}
+}
+
+// IntersectionInPlace will remove any addresses from `s` that are not in `t`
+func (s *Set) IntersectionInPlace(t Set) {
+	s.ranges = intersect_ranges(s.ranges, t.ranges)
+}
+
+// Difference returns a new set with addresses in `s` that are not in `t`
+func (s *Set) Difference(t Set) Set {
+	return Set{ranges: subtract_ranges(s.ranges, t.ranges)}
+}
+
+// DifferenceInPlace removes any addresses in `s` that are in `t`
+func (s *Set) DifferenceInPlace(t Set) {
+	s.ranges = subtract_ranges(s.ranges, t.ranges)
+}
+
+// search returns the index of the first range whose upper bound is >= a
+func (s *Set) search(a netip.Addr) int {
+	lo, hi := 0, len(s.ranges)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if s.ranges[mid].hi.Compare(a) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// add_range inserts `r` into the sorted ranges, merging it with any ranges it overlaps
+// or touches
+func (s *Set) add_range(r addr_range) {
+	s.ranges = union_ranges(s.ranges, []addr_range{r})
+}
+
+// union_ranges merges two sorted lists of ranges into a new sorted list
+func union_ranges(a, b []addr_range) []addr_range {
+	result := make([]addr_range, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		// Pick whichever range starts first
+		var next addr_range
+		if j >= len(b) || (i < len(a) && a[i].lo.Compare(b[j].lo) <= 0) {
+			next = a[i]
+			i += 1
+		} else {
+			next = b[j]
+			j += 1
+		}
+
+		// Merge into the last range if they overlap or touch
+		if n := len(result); n > 0 && touches(result[n-1], next) {
+			if next.hi.Compare(result[n-1].hi) > 0 {
+				result[n-1].hi = next.hi
+			}
+		} else {
+			result = append(result, next)
+		}
+	}
+	return result
+}
+
+// intersect_ranges returns the ranges covered by both `a` and `b`
+func intersect_ranges(a, b []addr_range) []addr_range {
+	result := make([]addr_range, 0)
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		lo := max_addr(a[i].lo, b[j].lo)
+		hi := min_addr(a[i].hi, b[j].hi)
+		if lo.Compare(hi) <= 0 {
+			result = append(result, addr_range{lo: lo, hi: hi})
+		}
+
+		// Advance whichever range ends first
+		if a[i].hi.Compare(b[j].hi) < 0 {
+			i += 1
+		} else {
+			j += 1
+		}
+	}
+	return result
+}
+
+// subtract_ranges returns the ranges covered by `a` but not by `b`
+func subtract_ranges(a, b []addr_range) []addr_range {
+	result := make([]addr_range, 0, len(a))
+	j := 0
+	for _, r := range a {
+		// Skip everything in `b` that ends before this range starts
+		for j < len(b) && b[j].hi.Compare(r.lo) < 0 {
+			j += 1
+		}
+
+		lo := r.lo
+		k := j
+		for k < len(b) && b[k].lo.Compare(r.hi) <= 0 {
+			if b[k].lo.Compare(lo) > 0 {
+				result = append(result, addr_range{lo: lo, hi: b[k].lo.Prev()})
+			}
+			if b[k].hi.Compare(r.hi) >= 0 {
+				// The rest of `r` is covered
+				lo = netip.Addr{}
+				break
+			}
+			lo = b[k].hi.Next()
+			k += 1
+		}
+		if lo.IsValid() {
+			result = append(result, addr_range{lo: lo, hi: r.hi})
+		}
+	}
+	return result
+}
+
+// touches returns true if `b` starts at or before the address just after `a` ends.
+// Assumes a.lo <= b.lo. Ranges of different families never touch.
+func touches(a, b addr_range) bool {
+	if a.hi.BitLen() != b.lo.BitLen() {
+		return false
+	}
+	if b.lo.Compare(a.hi) <= 0 {
+		return true
+	}
+	return a.hi.Next() == b.lo
+}
+
+func min_addr(a, b netip.Addr) netip.Addr {
+	if a.Compare(b) < 0 {
+		return a
+	}
+	return b
+}
+
+func max_addr(a, b netip.Addr) netip.Addr {
+	if a.Compare(b) > 0 {
+		return a
+	}
+	return b
+}
+
+// prefix_range returns the first and last address in the prefix
+func prefix_range(p netip.Prefix) (lo, hi netip.Addr) {
+	if p.Addr().Is4In6() && p.Bits() >= 96 {
+		p = netip.PrefixFrom(p.Addr().Unmap(), p.Bits()-96)
+	}
+	p = p.Masked()
+	lo = p.Addr()
+
+	// Set all of the host bits to get the last address
+	b := lo.As16()
+	offset := 128 - lo.BitLen()
+	for bit := offset + p.Bits(); bit < 128; bit++ {
+		b[bit/8] |= 1 << (7 - uint(bit%8))
+	}
+	hi = netip.AddrFrom16(b)
+	if lo.Is4() {
+		hi = hi.Unmap()
+	}
+	return lo, hi
+}
+
+// append_range_prefixes appends the fewest CIDR prefixes that exactly cover [lo, hi]
+func append_range_prefixes(dst []netip.Prefix, lo, hi netip.Addr) []netip.Prefix {
+	for lo.IsValid() && lo.Compare(hi) <= 0 {
+		// Find the shortest prefix that starts at `lo` and doesn't run past `hi`
+		bits := lo.BitLen()
+		for bits > 0 {
+			candidate := netip.PrefixFrom(lo, bits-1)
+			if candidate.Masked().Addr() != lo {
+				break
+			}
+			if _, last := prefix_range(candidate); last.Compare(hi) > 0 {
+				break
+			}
+			bits -= 1
+		}
+
+		p := netip.PrefixFrom(lo, bits)
+		dst = append(dst, p)
+
+		_, last := prefix_range(p)
+		lo = last.Next()
+	}
+	return dst
+}
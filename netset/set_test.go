@@ -0,0 +1,245 @@
+package netset
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+func prefixes(ss ...string) []netip.Prefix {
+	result := make([]netip.Prefix, 0, len(ss))
+	for _, s := range ss {
+		result = append(result, netip.MustParsePrefix(s))
+	}
+	return result
+}
+
+func equal_prefixes(a, b []netip.Prefix) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if v != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPrefixesAggregate(t *testing.T) {
+	testCases := []struct {
+		desc string
+		in   []netip.Prefix
+		want []netip.Prefix
+	}{
+		{
+			desc: "empty",
+			in:   prefixes(),
+			want: prefixes(),
+		},
+		{
+			desc: "two halves",
+			in:   prefixes("10.0.0.0/25", "10.0.0.128/25"),
+			want: prefixes("10.0.0.0/24"),
+		},
+		{
+			desc: "overlapping",
+			in:   prefixes("10.0.0.0/8", "10.1.0.0/16"),
+			want: prefixes("10.0.0.0/8"),
+		},
+		{
+			desc: "unaligned run",
+			in:   prefixes("10.0.0.1/32", "10.0.0.2/31"),
+			want: prefixes("10.0.0.1/32", "10.0.0.2/31"),
+		},
+		{
+			desc: "host bits are masked",
+			in:   prefixes("192.168.1.77/24"),
+			want: prefixes("192.168.1.0/24"),
+		},
+		{
+			desc: "mixed families stay apart",
+			in:   prefixes("2001:db8::/33", "255.255.255.255/32", "2001:db8:8000::/33"),
+			want: prefixes("255.255.255.255/32", "2001:db8::/32"),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := NewSet(tC.in)
+			got := s.Prefixes()
+			if !equal_prefixes(got, tC.want) {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+}
+
+func TestContainsAddr(t *testing.T) {
+	s := NewSet(prefixes("10.0.0.0/8", "2001:db8::/32"))
+	testCases := []struct {
+		desc string
+		addr netip.Addr
+		want bool
+	}{
+		{
+			desc: "inside v4",
+			addr: netip.MustParseAddr("10.200.3.4"),
+			want: true,
+		},
+		{
+			desc: "just past v4",
+			addr: netip.MustParseAddr("11.0.0.0"),
+			want: false,
+		},
+		{
+			desc: "v4 mapped into v6",
+			addr: netip.MustParseAddr("::ffff:10.0.0.1"),
+			want: true,
+		},
+		{
+			desc: "inside v6",
+			addr: netip.MustParseAddr("2001:db8::1"),
+			want: true,
+		},
+		{
+			desc: "outside v6",
+			addr: netip.MustParseAddr("2001:db9::1"),
+			want: false,
+		},
+		{
+			desc: "invalid",
+			addr: netip.Addr{},
+			want: false,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := s.ContainsAddr(tC.addr); got != tC.want {
+				t.Errorf("got %v, want %v", got, tC.want)
+			}
+		})
+	}
+}
+
+func TestContainsPrefix(t *testing.T) {
+	s := NewSet(prefixes("10.0.0.0/25", "10.0.0.128/25"))
+	if !s.ContainsPrefix(netip.MustParsePrefix("10.0.0.0/24")) {
+		t.Errorf("%v should contain 10.0.0.0/24", s)
+	}
+	if s.ContainsPrefix(netip.MustParsePrefix("10.0.0.0/23")) {
+		t.Errorf("%v should not contain 10.0.0.0/23", s)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		in1          []netip.Prefix
+		in2          []netip.Prefix
+		union        []netip.Prefix
+		intersection []netip.Prefix
+		difference   []netip.Prefix
+	}{
+		{
+			desc:         "nested",
+			in1:          prefixes("10.0.0.0/24"),
+			in2:          prefixes("10.0.0.128/25"),
+			union:        prefixes("10.0.0.0/24"),
+			intersection: prefixes("10.0.0.128/25"),
+			difference:   prefixes("10.0.0.0/25"),
+		},
+		{
+			desc:         "hole in the middle",
+			in1:          prefixes("10.0.0.0/30"),
+			in2:          prefixes("10.0.0.1/32", "10.0.0.2/32"),
+			union:        prefixes("10.0.0.0/30"),
+			intersection: prefixes("10.0.0.1/32", "10.0.0.2/32"),
+			difference:   prefixes("10.0.0.0/32", "10.0.0.3/32"),
+		},
+		{
+			desc:         "disjoint",
+			in1:          prefixes("10.0.0.0/24"),
+			in2:          prefixes("2001:db8::/32"),
+			union:        prefixes("10.0.0.0/24", "2001:db8::/32"),
+			intersection: prefixes(),
+			difference:   prefixes("10.0.0.0/24"),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s1 := NewSet(tC.in1)
+			s2 := NewSet(tC.in2)
+
+			union := s1.Union(s2)
+			if got := union.Prefixes(); !equal_prefixes(got, tC.union) {
+				t.Errorf("union: got %v; want %v", got, tC.union)
+			}
+			intersection := s1.Intersection(s2)
+			if got := intersection.Prefixes(); !equal_prefixes(got, tC.intersection) {
+				t.Errorf("intersection: got %v; want %v", got, tC.intersection)
+			}
+			difference := s1.Difference(s2)
+			if got := difference.Prefixes(); !equal_prefixes(got, tC.difference) {
+				t.Errorf("difference: got %v; want %v", got, tC.difference)
+			}
+
+			// The in place versions should agree with the copying versions
+			in_place := s1.Copy()
+			in_place.DifferenceInPlace(s2)
+			if !in_place.Equals(difference) {
+				t.Errorf("in place difference: got %v; want %v", in_place, difference)
+			}
+		})
+	}
+}
+
+func TestDiscardPrefix(t *testing.T) {
+	s := NewSet(prefixes("10.0.0.0/24"))
+	s.DiscardPrefix(netip.MustParsePrefix("10.0.0.0/25"))
+	s.DiscardAddr(netip.MustParseAddr("10.0.0.255"))
+
+	want := prefixes("10.0.0.128/26", "10.0.0.192/27", "10.0.0.224/28", "10.0.0.240/29",
+		"10.0.0.248/30", "10.0.0.252/31", "10.0.0.254/32")
+	if got := s.Prefixes(); !equal_prefixes(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestReadList(t *testing.T) {
+	in := `
+# office networks
+10.0.0.0/25
+10.0.0.128/25   # second half
+192.168.1.7
+2001:db8::/48
+`
+	s, err := ReadList(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	want := prefixes("10.0.0.0/24", "192.168.1.7/32", "2001:db8::/48")
+	if got := s.Prefixes(); !equal_prefixes(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	_, err = ReadList(strings.NewReader("10.0.0.0/8\nnot an address\n"))
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Errorf("got error %v, want an error for line 2", err)
+	}
+}
+
+func TestString(t *testing.T) {
+	s := NewSet(prefixes("10.0.0.0/24", "10.0.1.0/24"))
+	want := "{10.0.0.0/23}"
+	if got := s.String(); got != want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func BenchmarkContainsAddr(b *testing.B) {
+	s := NewSet(prefixes("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "2001:db8::/32"))
+	a := netip.MustParseAddr("192.168.44.12")
+	for i := 0; i < b.N; i++ {
+		s.ContainsAddr(a)
+	}
+}
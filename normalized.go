@@ -0,0 +1,184 @@
+package set
+
+// NormalizedSet is a set of strings where every item is passed through a normalizer
+// before it is stored or looked up. For example, using `strings.ToLower` as the
+// normalizer gives a case-insensitive set.
+type NormalizedSet struct {
+	normalize func(string) string
+	set       Set[string]
+}
+
+// NewNormalizedSet will return a NormalizedSet from an input slice, with every item
+// passed through `normalize`
+func NewNormalizedSet[S ~[]string](data S, normalize func(string) string) NormalizedSet {
+	result := make(map[string]struct{}, len(data))
+	for _, v := range data {
+		result[normalize(v)] = struct{}{}
+	}
+
+	return NormalizedSet{normalize: normalize, set: Set[string]{data: result}}
+}
+
+// normalized returns the items of `t` passed through the normalizer of `s`. `t` may
+// have been built with a different normalizer, so its items are always re-normalized.
+func (s *NormalizedSet) normalized(t NormalizedSet) Set[string] {
+	result := make(map[string]struct{}, t.Len())
+	for v := range t.set.data {
+		result[s.normalize(v)] = struct{}{}
+	}
+	return Set[string]{data: result}
+}
+
+func (s NormalizedSet) String() string {
+	return s.set.String()
+}
+
+// Set will return a copy of the normalized items as a plain Set
+func (s *NormalizedSet) Set() Set[string] {
+	return s.set.Copy()
+}
+
+// Slice will return all the normalized items in the set as a slice. They are not
+// guaranteed in any particular order.
+func (s *NormalizedSet) Slice() []string {
+	return s.set.Slice()
+}
+
+// Iterate calls `f` on every normalized item in the set, in no particular order. It
+// stops early if `f` returns false.
+func (s *NormalizedSet) Iterate(f func(item string) bool) {
+	s.set.Iterate(f)
+}
+
+// Contains will return true if the set contains the normalized form of the item
+func (s *NormalizedSet) Contains(item string) bool {
+	return s.set.Contains(s.normalize(item))
+}
+
+// Len returns the length of the set
+func (s *NormalizedSet) Len() int {
+	return s.set.Len()
+}
+
+// IsEmpty returns true if the set is empty
+func (s *NormalizedSet) IsEmpty() bool {
+	return s.set.IsEmpty()
+}
+
+// Add will add the normalized form of the item to `s`. If it already exists, it is
+// ignored
+func (s *NormalizedSet) Add(item string) {
+	s.set.Add(s.normalize(item))
+}
+
+// Remove removes the normalized form of the item from the set. Returns a *NotFoundError
+// holding the normalized form if it doesn't exist.
+func (s *NormalizedSet) Remove(item string) error {
+	return s.set.Remove(s.normalize(item))
+}
+
+// TryRemove removes the normalized form of the item from the set, and returns true if
+// it was there
+func (s *NormalizedSet) TryRemove(item string) bool {
+	return s.set.TryRemove(s.normalize(item))
+}
+
+// Discard removes the normalized form of the item from the set. If it doesn't exist,
+// it is ignored
+func (s *NormalizedSet) Discard(item string) {
+	s.set.Discard(s.normalize(item))
+}
+
+// Pop will remove and return an arbitrary item from the set. If the set is empty,
+// it will return an error
+func (s *NormalizedSet) Pop() (string, error) {
+	return s.set.Pop()
+}
+
+// Clear will remove all items from the set
+func (s *NormalizedSet) Clear() {
+	s.set.Clear()
+}
+
+// Copy makes a deep copy which shares the same normalizer
+func (s *NormalizedSet) Copy() NormalizedSet {
+	return NormalizedSet{normalize: s.normalize, set: s.set.Copy()}
+}
+
+// Equals will return true if `s` and `t` contain the same normalized items
+func (s *NormalizedSet) Equals(t NormalizedSet) bool {
+	return s.set.Equals(s.normalized(t))
+}
+
+// Union will create a new NormalizedSet, and fill it with the union of `s` and `t`
+func (s *NormalizedSet) Union(t NormalizedSet) NormalizedSet {
+	return NormalizedSet{normalize: s.normalize, set: s.set.Union(s.normalized(t))}
+}
+
+// UnionInPlace will add all the items in set `t` to set `s`
+func (s *NormalizedSet) UnionInPlace(t NormalizedSet) {
+	s.set.UnionInPlace(s.normalized(t))
+}
+
+// Intersection will create a new NormalizedSet, and fill it with the intersection of
+// `s` and `t`
+func (s *NormalizedSet) Intersection(t NormalizedSet) NormalizedSet {
+	return NormalizedSet{normalize: s.normalize, set: s.set.Intersection(s.normalized(t))}
+}
+
+// IntersectionInPlace will remove any items from `s` that are not in `t`
+func (s *NormalizedSet) IntersectionInPlace(t NormalizedSet) {
+	s.set.IntersectionInPlace(s.normalized(t))
+}
+
+// IsDisjoint will return true if the set has no elements in common with `t`
+func (s *NormalizedSet) IsDisjoint(t NormalizedSet) bool {
+	return s.set.IsDisjoint(s.normalized(t))
+}
+
+// IsSubsetOf tests whether every element in `s` is in `t`
+func (s *NormalizedSet) IsSubsetOf(t NormalizedSet) bool {
+	return s.set.IsSubsetOf(s.normalized(t))
+}
+
+// IsProperSubsetOf tests whether every element in `s` is in `t`, but that
+// `s.Equals(t) == false`
+func (s *NormalizedSet) IsProperSubsetOf(t NormalizedSet) bool {
+	return s.set.IsProperSubsetOf(s.normalized(t))
+}
+
+// IsSuperSetOf tests whether every element in `t` is in `s`
+func (s *NormalizedSet) IsSuperSetOf(t NormalizedSet) bool {
+	return s.set.IsSuperSetOf(s.normalized(t))
+}
+
+// IsProperSuperSetOf tests whether every element in `t` is in `s`, but that
+// `s.Equals(t) == false`
+func (s *NormalizedSet) IsProperSuperSetOf(t NormalizedSet) bool {
+	return s.set.IsProperSuperSetOf(s.normalized(t))
+}
+
+// Difference returns a new set with elements in `s` that are not in `t`
+func (s *NormalizedSet) Difference(t NormalizedSet) NormalizedSet {
+	return NormalizedSet{normalize: s.normalize, set: s.set.Difference(s.normalized(t))}
+}
+
+// DifferenceInPlace removes any elements in `s` that are in `t`
+func (s *NormalizedSet) DifferenceInPlace(t NormalizedSet) {
+	s.set.DifferenceInPlace(s.normalized(t))
+}
+
+// SymmetricDifference returns a new set with elements in either `s` or `t`, but not
+// both
+func (s *NormalizedSet) SymmetricDifference(t NormalizedSet) NormalizedSet {
+	return NormalizedSet{
+		normalize: s.normalize,
+		set:       s.set.SymmetricDifference(s.normalized(t)),
+	}
+}
+
+// SymmetricDifferenceInPlace removes any elements in `s` that are in `t`, and adds any
+// elements in `t` that are not in `s`
+func (s *NormalizedSet) SymmetricDifferenceInPlace(t NormalizedSet) {
+	s.set.SymmetricDifferenceInPlace(s.normalized(t))
+}
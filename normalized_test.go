@@ -0,0 +1,89 @@
+package set
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalizedSetContains(t *testing.T) {
+	s := NewNormalizedSet([]string{"Hello", "WORLD"}, strings.ToLower)
+
+	testCases := []struct {
+		desc string
+		v    string
+		want bool
+	}{
+		{
+			desc: "same case",
+			v:    "Hello",
+			want: true,
+		},
+		{
+			desc: "different case",
+			v:    "world",
+			want: true,
+		},
+		{
+			desc: "not in set",
+			v:    "goodbye",
+			want: false,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := s.Contains(tC.v); got != tC.want {
+				t.Errorf("got %v, want %v", got, tC.want)
+			}
+		})
+	}
+
+	if s.Len() != 2 {
+		t.Errorf("got length %d, want 2", s.Len())
+	}
+}
+
+func TestNormalizedSetAddRemove(t *testing.T) {
+	s := NewNormalizedSet([]string{"a"}, strings.ToLower)
+	s.Add("A")
+	if s.Len() != 1 {
+		t.Errorf("got length %d, want 1", s.Len())
+	}
+
+	if err := s.Remove("A"); err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+	if err := s.Remove("a"); !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrElementNotFound)
+	}
+}
+
+func TestNormalizedSetOperations(t *testing.T) {
+	s1 := NewNormalizedSet([]string{"Larry", "Curly", "Moe"}, strings.ToLower)
+	s2 := NewNormalizedSet([]string{"CURLY", "moe", "Shemp"}, strings.ToUpper)
+
+	union := s1.Union(s2)
+	want_union := NewSet([]string{"larry", "curly", "moe", "shemp"})
+	if got := union.Set(); !got.Equals(want_union) {
+		t.Errorf("got %v; want %v", got, want_union)
+	}
+
+	intersection := s1.Intersection(s2)
+	want_intersection := NewSet([]string{"curly", "moe"})
+	if got := intersection.Set(); !got.Equals(want_intersection) {
+		t.Errorf("got %v; want %v", got, want_intersection)
+	}
+
+	difference := s1.Difference(s2)
+	want_difference := NewSet([]string{"larry"})
+	if got := difference.Set(); !got.Equals(want_difference) {
+		t.Errorf("got %v; want %v", got, want_difference)
+	}
+
+	if s1.IsDisjoint(s2) {
+		t.Errorf("%v and %v should not be disjoint", s1, s2)
+	}
+	if !intersection.IsSubsetOf(s2) {
+		t.Errorf("%v should be a subset of %v", intersection, s2)
+	}
+}
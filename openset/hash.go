@@ -0,0 +1,31 @@
+package openset
+
+import (
+	"hash/maphash"
+)
+
+// Integer is any type with an integer as its underlying type
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// HashInt is a hash function for integer items. It runs the value through the
+// splitmix64 finalizer, so that runs of consecutive integers spread across the table.
+func HashInt[T Integer](v T) uint64 {
+	x := uint64(v)
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// string_seed is picked once per process, so string hashes are not stable between runs
+var string_seed = maphash.MakeSeed()
+
+// HashString is a hash function for string items
+func HashString(v string) uint64 {
+	return maphash.String(string_seed, v)
+}
@@ -0,0 +1,446 @@
+// openset is a set of comparable items stored in a single open addressing hash table,
+// in the style of a Swiss table. Each item costs one slot plus one control byte, which
+// is less than the per item overhead of `map[T]struct{}` for very large sets. In return,
+// you have to supply the hash function for the item type. `HashInt` and `HashString`
+// cover the most common cases.
+//
+// The method set matches `github.com/natemcintosh/set`.
+package openset
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/natemcintosh/set"
+)
+
+var (
+	// This error is returned when you try to remove an item from a set that doesn't
+	// exist. It is set.ErrElementNotFound, so errors.Is works the same for every set.
+	ErrElementNotFound = set.ErrElementNotFound
+)
+
+const (
+	// Control bytes for slots that don't hold an item. Full slots store the low 7 bits
+	// of the item's hash, so that most mismatches are caught without comparing items.
+	ctrl_empty   uint8 = 0x80
+	ctrl_deleted uint8 = 0xFE
+
+	min_capacity = 8
+)
+
+// table is shared between copies of a Set value, the same way a map is
+type table[T comparable] struct {
+	hash  func(T) uint64
+	ctrl  []uint8
+	slots []T
+
+	length int
+
+	// tombstones is the number of slots marked as deleted. They still count towards
+	// the load factor, as lookups have to probe past them.
+	tombstones int
+}
+
+type Set[T comparable] struct {
+	t *table[T]
+}
+
+// capacity_for returns the number of slots needed to hold `size` items while staying
+// under the maximum load factor of 7/8. It is always a power of two.
+func capacity_for(size int) int {
+	capacity := min_capacity
+	for capacity*7 < size*8 {
+		capacity *= 2
+	}
+	return capacity
+}
+
+func new_table[T comparable](capacity int, hash func(T) uint64) *table[T] {
+	t := &table[T]{
+		hash:  hash,
+		ctrl:  make([]uint8, capacity),
+		slots: make([]T, capacity),
+	}
+	for i := range t.ctrl {
+		t.ctrl[i] = ctrl_empty
+	}
+	return t
+}
+
+// NewSet will return a Set object from an input slice, or anything that has a slice as
+// the underlying data type. `hash` must return the same value for equal items, and
+// should spread different items across all 64 bits.
+func NewSet[T comparable, S ~[]T](data S, hash func(T) uint64) Set[T] {
+	return NewSetWithCapacity(data, len(data), hash)
+}
+
+// NewSetWithCapacity will return a Set object with room for `size` items before it needs
+// to grow. Note that if len(data) >= size, size will simply be ignored.
+func NewSetWithCapacity[T comparable, S ~[]T](data S, size int, hash func(T) uint64) Set[T] {
+	if len(data) > size {
+		size = len(data)
+	}
+
+	s := Set[T]{t: new_table(capacity_for(size), hash)}
+	for _, v := range data {
+		s.Add(v)
+	}
+	return s
+}
+
+// find returns the slot holding `item` and true, or else the slot where `item` should be
+// inserted and false
+func (t *table[T]) find(item T) (int, bool) {
+	h := t.hash(item)
+	h2 := uint8(h & 0x7F)
+	mask := uint64(len(t.ctrl) - 1)
+
+	first_deleted := -1
+	for i := (h >> 7) & mask; ; i = (i + 1) & mask {
+		switch c := t.ctrl[i]; c {
+		case ctrl_empty:
+			// The item isn't in the table. Prefer reusing a deleted slot.
+			if first_deleted >= 0 {
+				return first_deleted, false
+			}
+			return int(i), false
+		case ctrl_deleted:
+			if first_deleted < 0 {
+				first_deleted = int(i)
+			}
+		default:
+			if c == h2 && t.slots[i] == item {
+				return int(i), true
+			}
+		}
+	}
+}
+
+// rehash moves every item into a fresh table with `capacity` slots, which also clears
+// out all the tombstones
+func (t *table[T]) rehash(capacity int) {
+	fresh := new_table(capacity, t.hash)
+	for i, c := range t.ctrl {
+		if c&ctrl_empty == 0 {
+			idx, _ := fresh.find(t.slots[i])
+			fresh.ctrl[idx] = c
+			fresh.slots[idx] = t.slots[i]
+			fresh.length += 1
+		}
+	}
+	*t = *fresh
+}
+
+// each calls `f` on every item, stopping early if `f` returns false
+func (s *Set[T]) each(f func(T) bool) {
+	for i, c := range s.t.ctrl {
+		if c&ctrl_empty == 0 {
+			if !f(s.t.slots[i]) {
+				return
+			}
+		}
+	}
+}
+
+// empty_like returns an empty set using the same hash function as `s`
+func (s *Set[T]) empty_like(size int) Set[T] {
+	return Set[T]{t: new_table(capacity_for(size), s.t.hash)}
+}
+
+func (s Set[T]) String() string {
+	var b strings.Builder
+	last_index := s.Len() - 1
+	index := -1
+	b.WriteString("{")
+	s.each(func(v T) bool {
+		index += 1
+
+		if index < last_index {
+			b.WriteString(fmt.Sprintf("%v, ", v))
+		} else {
+			b.WriteString(fmt.Sprintf("%v", v))
+		}
+		return true
+	})
+	b.WriteString("}")
+
+	return b.String()
+}
+
+// Slice will return all the items in the set as a slice. They are not guaranteed in any
+// particular order.
+func (s *Set[T]) Slice() []T {
+	result := make([]T, 0, s.Len())
+	s.each(func(v T) bool {
+		result = append(result, v)
+		return true
+	})
+	return result
+}
+
+// Iterate calls `f` on every item in the set, in no particular order. It stops early if
+// `f` returns false.
+func (s *Set[T]) Iterate(f func(item T) bool) {
+	s.each(f)
+}
+
+// Contains will return true if the set contains the item. If the set is empty, returns
+// false
+func (s *Set[T]) Contains(item T) bool {
+	_, ok := s.t.find(item)
+	return ok
+}
+
+// Len returns the length of the Set
+func (s *Set[T]) Len() int {
+	return s.t.length
+}
+
+// IsEmpty returns true if the set is empty
+func (s *Set[T]) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+// Add will add a new item to `s`. If it already exists, it is ignored
+func (s *Set[T]) Add(item T) {
+	idx, ok := s.t.find(item)
+	if ok {
+		return
+	}
+
+	// Make room first if this insert would take the table past its load factor
+	if s.t.ctrl[idx] == ctrl_empty && (s.t.length+s.t.tombstones+1)*8 > len(s.t.ctrl)*7 {
+		s.t.rehash(capacity_for(s.t.length + 1))
+		idx, _ = s.t.find(item)
+	}
+
+	if s.t.ctrl[idx] == ctrl_deleted {
+		s.t.tombstones -= 1
+	}
+	s.t.ctrl[idx] = uint8(s.t.hash(item) & 0x7F)
+	s.t.slots[idx] = item
+	s.t.length += 1
+}
+
+// Remove removes an item from the set. Returns an error if the item doesn't exist.
+// See `Discard` for method that does not return an error
+func (s *Set[T]) Remove(item T) error {
+	if !s.Contains(item) {
+		return ErrElementNotFound
+	}
+
+	s.Discard(item)
+	return nil
+}
+
+// Discard removes an item from the set. If it doesn't exist, it is ignored
+func (s *Set[T]) Discard(item T) {
+	idx, ok := s.t.find(item)
+	if !ok {
+		return
+	}
+
+	var zero T
+	s.t.ctrl[idx] = ctrl_deleted
+	s.t.slots[idx] = zero
+	s.t.length -= 1
+	s.t.tombstones += 1
+}
+
+// Pop will remove and return an arbitrary item from the set. If the set is empty,
+// it will return an error
+func (s *Set[T]) Pop() (item T, err error) {
+	if s.IsEmpty() {
+		return item, ErrElementNotFound
+	}
+
+	// Get the first item
+	s.each(func(v T) bool {
+		item = v
+		return false
+	})
+
+	// Discard it
+	s.Discard(item)
+
+	return item, nil
+}
+
+// Clear will remove all items from the set
+func (s *Set[T]) Clear() {
+	*s.t = *new_table(min_capacity, s.t.hash)
+}
+
+// Copy makes a deep copy as quickly as possible
+func (s *Set[T]) Copy() Set[T] {
+	t := &table[T]{
+		hash:       s.t.hash,
+		ctrl:       make([]uint8, len(s.t.ctrl)),
+		slots:      make([]T, len(s.t.slots)),
+		length:     s.t.length,
+		tombstones: s.t.tombstones,
+	}
+	copy(t.ctrl, s.t.ctrl)
+	copy(t.slots, s.t.slots)
+	return Set[T]{t: t}
+}
+
+// Equals will return true if `s` and `t` are
+// - the same length
+// - contain the same elements
+func (s *Set[T]) Equals(t Set[T]) bool {
+	if s.Len() != t.Len() {
+		return false
+	}
+	return s.IsSubsetOf(t)
+}
+
+// Union will create a new Set, and fill it with the union of `s` and `t`
+func (s *Set[T]) Union(t Set[T]) Set[T] {
+	// Copy whichever is larger, and add the smaller one to it
+	if s.Len() > t.Len() {
+		result := s.Copy()
+		result.UnionInPlace(t)
+		return result
+	}
+	result := t.Copy()
+	result.UnionInPlace(*s)
+	return result
+}
+
+// UnionInPlace will add all the items in set `t` to set `s`
+func (s *Set[T]) UnionInPlace(t Set[T]) {
+	t.each(func(v T) bool {
+		s.Add(v)
+		return true
+	})
+}
+
+// Intersection will create a new Set, and fill it with the intersection of `s` and `t`
+func (s *Set[T]) Intersection(t Set[T]) Set[T] {
+	// Iterate over the smaller of the two sets, and add the item to `result` if it is
+	// in the larger of the two sets
+	smaller, larger := *s, t
+	if t.Len() < s.Len() {
+		smaller, larger = t, *s
+	}
+
+	result := s.empty_like(smaller.Len())
+	smaller.each(func(v T) bool {
+		if larger.Contains(v) {
+			result.Add(v)
+		}
+		return true
+	})
+	return result
+}
+
+// IntersectionInPlace will remove any items from `s` that are not in `t`
+func (s *Set[T]) IntersectionInPlace(t Set[T]) {
+	for i, c := range s.t.ctrl {
+		if c&ctrl_empty == 0 && !t.Contains(s.t.slots[i]) {
+			var zero T
+			s.t.ctrl[i] = ctrl_deleted
+			s.t.slots[i] = zero
+			s.t.length -= 1
+			s.t.tombstones += 1
+		}
+	}
+}
+
+// IsDisjoint will return true if the set has no elements in common with `t`. Sets are
+// disjoint if and only if their intersection is the empty set
+func (s *Set[T]) IsDisjoint(t Set[T]) bool {
+	smaller, larger := *s, t
+	if t.Len() < s.Len() {
+		smaller, larger = t, *s
+	}
+
+	result := true
+	smaller.each(func(v T) bool {
+		if larger.Contains(v) {
+			result = false
+		}
+		return result
+	})
+	return result
+}
+
+// IsSubsetOf tests whether every element in `s` is in `t`
+func (s *Set[T]) IsSubsetOf(t Set[T]) bool {
+	if s.Len() > t.Len() {
+		return false
+	}
+
+	result := true
+	s.each(func(v T) bool {
+		if !t.Contains(v) {
+			result = false
+		}
+		return result
+	})
+	return result
+}
+
+// IsProperSubsetOf tests whether every element in `s` is in `t`, but that
+// `s.Equals(t) == false`
+func (s *Set[T]) IsProperSubsetOf(t Set[T]) bool {
+	return s.Len() < t.Len() && s.IsSubsetOf(t)
+}
+
+// IsSuperSetOf tests whether every element in `t` is in `s`
+func (s *Set[T]) IsSuperSetOf(t Set[T]) bool {
+	return t.IsSubsetOf(*s)
+}
+
+// IsProperSuperSetOf tests whether every element in `t` is in `s`, but that
+// `s.Equals(t) == false`
+func (s *Set[T]) IsProperSuperSetOf(t Set[T]) bool {
+	return s.Len() > t.Len() && t.IsSubsetOf(*s)
+}
+
+// Difference returns a new set with elements in `s` that are not in `t`
+func (s *Set[T]) Difference(t Set[T]) Set[T] {
+	result := s.empty_like(s.Len())
+	s.each(func(v T) bool {
+		if !t.Contains(v) {
+			result.Add(v)
+		}
+		return true
+	})
+	return result
+}
+
+// DifferenceInPlace removes any elements in `s` that are in `t`
+func (s *Set[T]) DifferenceInPlace(t Set[T]) {
+	t.each(func(v T) bool {
+		s.Discard(v)
+		return true
+	})
+}
+
+// SymmetricDifference returns a new set with elements in either `s` or `t`, but not both
+func (s *Set[T]) SymmetricDifference(t Set[T]) Set[T] {
+	result := s.Difference(t)
+	t.each(func(v T) bool {
+		if !s.Contains(v) {
+			result.Add(v)
+		}
+		return true
+	})
+	return result
+}
+
+// SymmetricDifferenceInPlace removes any elements in `s` that are in `t`, and adds any
+// elements in `t` that are not in `s`
+func (s *Set[T]) SymmetricDifferenceInPlace(t Set[T]) {
+	t.each(func(v T) bool {
+		if s.Contains(v) {
+			s.Discard(v)
+		} else {
+			s.Add(v)
+		}
+		return true
+	})
+}
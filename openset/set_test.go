@@ -0,0 +1,165 @@
+package openset
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/natemcintosh/set"
+	"golang.org/x/exp/slices"
+)
+
+func equal(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if v != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAddContains(t *testing.T) {
+	s := NewSet([]string{"a", "b", "c"}, HashString)
+	testCases := []struct {
+		desc string
+		v    string
+		want bool
+	}{
+		{
+			desc: "in the set",
+			v:    "a",
+			want: true,
+		},
+		{
+			desc: "not in the set",
+			v:    "d",
+			want: false,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := s.Contains(tC.v); got != tC.want {
+				t.Errorf("got %v, want %v", got, tC.want)
+			}
+		})
+	}
+
+	s.Add("a")
+	if s.Len() != 3 {
+		t.Errorf("got length %d, want 3", s.Len())
+	}
+}
+
+func TestGrowAndChurn(t *testing.T) {
+	// Add and remove enough to force several grows and tombstone clean ups, and check
+	// against the map based set the whole way
+	s := NewSet([]int{}, HashInt[int])
+	want := set.NewSet([]int{})
+	for i := 0; i < 20_000; i++ {
+		v := rand.Intn(5000)
+		if rand.Intn(3) == 0 {
+			s.Discard(v)
+			want.Discard(v)
+		} else {
+			s.Add(v)
+			want.Add(v)
+		}
+	}
+
+	if s.Len() != want.Len() {
+		t.Fatalf("got length %d, want %d", s.Len(), want.Len())
+	}
+	got_slice, want_slice := s.Slice(), want.Slice()
+	slices.Sort(got_slice)
+	slices.Sort(want_slice)
+	if !equal(got_slice, want_slice) {
+		t.Errorf("got %v; want %v", got_slice, want_slice)
+	}
+}
+
+func TestRemovePop(t *testing.T) {
+	s := NewSet([]int{1, 2, 3}, HashInt[int])
+	if err := s.Remove(2); err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+	if err := s.Remove(2); err != ErrElementNotFound {
+		t.Errorf("got error %v, want %v", err, ErrElementNotFound)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.Pop(); err != nil {
+			t.Errorf("got error %v, want nil", err)
+		}
+	}
+	if _, err := s.Pop(); err != ErrElementNotFound {
+		t.Errorf("got error %v, want %v", err, ErrElementNotFound)
+	}
+}
+
+func TestOperations(t *testing.T) {
+	for run := 0; run < 10; run++ {
+		items1 := make([]int, rand.Intn(500))
+		items2 := make([]int, rand.Intn(500))
+		for i := range items1 {
+			items1[i] = rand.Intn(600)
+		}
+		for i := range items2 {
+			items2[i] = rand.Intn(600)
+		}
+		s1, s2 := NewSet(items1, HashInt[int]), NewSet(items2, HashInt[int])
+		h1, h2 := set.NewSet(items1), set.NewSet(items2)
+
+		checks := []struct {
+			desc string
+			got  Set[int]
+			want set.Set[int]
+		}{
+			{desc: "union", got: s1.Union(s2), want: h1.Union(h2)},
+			{desc: "intersection", got: s1.Intersection(s2), want: h1.Intersection(h2)},
+			{desc: "difference", got: s1.Difference(s2), want: h1.Difference(h2)},
+			{desc: "symmetric difference", got: s1.SymmetricDifference(s2), want: h1.SymmetricDifference(h2)},
+		}
+		for _, c := range checks {
+			got, want := c.got.Slice(), c.want.Slice()
+			slices.Sort(got)
+			slices.Sort(want)
+			if !equal(got, want) {
+				t.Errorf("%s: got %v; want %v", c.desc, got, want)
+			}
+		}
+
+		in_place := s1.Copy()
+		in_place.IntersectionInPlace(s2)
+		if want := s1.Intersection(s2); !in_place.Equals(want) {
+			t.Errorf("in place intersection: got %v; want %v", in_place, want)
+		}
+		if s1.IsDisjoint(s2) != h1.IsDisjoint(h2) {
+			t.Errorf("IsDisjoint disagrees for %v and %v", s1, s2)
+		}
+		if s1.IsSubsetOf(s2) != h1.IsSubsetOf(h2) {
+			t.Errorf("IsSubsetOf disagrees for %v and %v", s1, s2)
+		}
+	}
+}
+
+func BenchmarkContains(b *testing.B) {
+	in := make([]int, 1_000_000)
+	for i := range in {
+		in[i] = i
+	}
+
+	b.Run("openset", func(b *testing.B) {
+		s := NewSet(in, HashInt[int])
+		for i := 0; i < b.N; i++ {
+			s.Contains(i % 2_000_000)
+		}
+	})
+	b.Run("map", func(b *testing.B) {
+		s := set.NewSet(in)
+		for i := 0; i < b.N; i++ {
+			s.Contains(i % 2_000_000)
+		}
+	})
+}
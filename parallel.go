@@ -0,0 +1,264 @@
+package set
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// min_parallel_chunk is the smallest number of items that is worth handing to its own
+// goroutine
+const min_parallel_chunk = 1 << 14
+
+// NewSetParallel will return a Set object from an input slice, splitting the work of
+// hashing the items across `workers` goroutines. Each goroutine de-duplicates its own
+// shard of `data`, and the shards are then merged into the largest one. This is most
+// useful for very large inputs with many duplicates, where most of the time in `NewSet`
+// is spent hashing items that are already in the set. If `workers` is less than 1,
+// `runtime.GOMAXPROCS(0)` is used.
+func NewSetParallel[T comparable, S ~[]T](data S, workers int) Set[T] {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	// Don't bother with goroutines for small inputs
+	if max_workers := len(data) / min_parallel_chunk; workers > max_workers {
+		workers = max_workers
+	}
+	if workers <= 1 {
+		return NewSet(data)
+	}
+
+	// Build each shard's set concurrently
+	shards := make([]Set[T], workers)
+	chunk_size := (len(data) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		start := i * chunk_size
+		end := start + chunk_size
+		if end > len(data) {
+			end = len(data)
+		}
+
+		wg.Add(1)
+		go func(i int, chunk S) {
+			defer wg.Done()
+			shards[i] = NewSet(chunk)
+		}(i, data[start:end])
+	}
+	wg.Wait()
+
+	// Merge everything into the largest shard to save re-hashing as many items as
+	// possible
+	largest := 0
+	for i, shard := range shards {
+		if shard.Len() > shards[largest].Len() {
+			largest = i
+		}
+	}
+	result := shards[largest]
+	for i, shard := range shards {
+		if i != largest {
+			result.UnionInPlace(shard)
+		}
+	}
+
+	return result
+}
+
+// Chunk will split `s` into `n` disjoint sets whose union is `s`, for handing out to
+// workers. Items are dealt out in turn in a single pass, so the sizes differ by at most
+// one, and some sets are empty if `s` has fewer than `n` items. Which items end up
+// together is not specified. It panics if `n` is less than 1.
+func Chunk[T comparable](s Set[T], n int) []Set[T] {
+	if n < 1 {
+		panic(fmt.Sprintf("set: cannot split a set into %d chunks", n))
+	}
+
+	result := make([]Set[T], n)
+	for i := range result {
+		result[i] = Set[T]{data: make(map[T]struct{}, s.Len()/n+1)}
+		result[i].take_options(s)
+	}
+	i := 0
+	for v := range s.data {
+		result[i].data[v] = struct{}{}
+		i++
+		if i == n {
+			i = 0
+		}
+	}
+	return result
+}
+
+// ForEachParallel calls `f` on every item of `s`, handing the items out to `workers`
+// goroutines, and waits for them to finish. If `workers` is less than 1,
+// `runtime.GOMAXPROCS(0)` is used. The first error returned by `f` stops any more items
+// being handed out, and is returned once the calls already running have finished. The
+// same happens if `ctx` is cancelled, and `ctx.Err()` is returned. `f` is given a
+// context that is cancelled in either case, so long calls can give up early. `f` must be
+// safe to call concurrently, and `s` may not be modified while this runs.
+func ForEachParallel[T comparable](
+	ctx context.Context,
+	s Set[T],
+	workers int,
+	f func(ctx context.Context, item T) error,
+) error {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, s.Len())
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var first_err error
+	var once sync.Once
+	items := make(chan T)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range items {
+				if err := f(ctx, v); err != nil {
+					once.Do(func() {
+						first_err = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+	// Hand out the items until they run out, or everything is cancelled. `select` picks
+	// at random when a worker is free as well, so check for cancelling first.
+send:
+	for v := range s.data {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case items <- v:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(items)
+	wg.Wait()
+
+	if first_err != nil {
+		return first_err
+	}
+	return ctx.Err()
+}
+
+// parallel_filter splits `items` across `workers` goroutines, and returns the items
+// for which `keep` returns true. `keep` must be safe to call concurrently.
+func parallel_filter[T comparable](items []T, workers int, keep func(T) bool) []T {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if max_workers := len(items) / min_parallel_chunk; workers > max_workers {
+		workers = max_workers
+	}
+	if workers <= 1 {
+		result := make([]T, 0)
+		for _, v := range items {
+			if keep(v) {
+				result = append(result, v)
+			}
+		}
+		return result
+	}
+
+	kept := make([][]T, workers)
+	chunk_size := (len(items) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		start := i * chunk_size
+		end := start + chunk_size
+		if end > len(items) {
+			end = len(items)
+		}
+
+		wg.Add(1)
+		go func(i int, chunk []T) {
+			defer wg.Done()
+			for _, v := range chunk {
+				if keep(v) {
+					kept[i] = append(kept[i], v)
+				}
+			}
+		}(i, items[start:end])
+	}
+	wg.Wait()
+
+	total := 0
+	for _, k := range kept {
+		total += len(k)
+	}
+	result := make([]T, 0, total)
+	for _, k := range kept {
+		result = append(result, k...)
+	}
+	return result
+}
+
+// UnionParallel will create a new Set, and fill it with the union of `s` and `t`. The
+// search for items of the smaller set that are missing from the larger one is split
+// across `workers` goroutines. If `workers` is less than 1, `runtime.GOMAXPROCS(0)` is
+// used. Neither set may be modified while this runs. As with `Union`, the result takes
+// the display options and pop order of `s`.
+func (s *Set[T]) UnionParallel(t Set[T], workers int) Set[T] {
+	larger, smaller := *s, t
+	if t.Len() > s.Len() {
+		larger, smaller = t, *s
+	}
+
+	missing := parallel_filter(smaller.Slice(), workers, func(v T) bool {
+		return !larger.Contains(v)
+	})
+
+	result := NewSetWithCapacity([]T{}, larger.Len()+len(missing))
+	result.take_options(*s)
+	result.UnionInPlace(larger)
+	for _, v := range missing {
+		result.Add(v)
+	}
+	return result
+}
+
+// IntersectionParallel will create a new Set, and fill it with the intersection of `s`
+// and `t`. The smaller set is split across `workers` goroutines, which each look up
+// their share of items in the larger set. If `workers` is less than 1,
+// `runtime.GOMAXPROCS(0)` is used. Neither set may be modified while this runs. The
+// result takes the display options and pop order of `s`.
+func (s *Set[T]) IntersectionParallel(t Set[T], workers int) Set[T] {
+	larger, smaller := *s, t
+	if t.Len() > s.Len() {
+		larger, smaller = t, *s
+	}
+
+	common := parallel_filter(smaller.Slice(), workers, func(v T) bool {
+		return larger.Contains(v)
+	})
+	result := NewSet(common)
+	result.take_options(*s)
+	return result
+}
+
+// DifferenceParallel returns a new set with elements in `s` that are not in `t`. The
+// items of `s` are split across `workers` goroutines, which each look up their share of
+// items in `t`. If `workers` is less than 1, `runtime.GOMAXPROCS(0)` is used. Neither
+// set may be modified while this runs. The result keeps the options of `s`, as with
+// `Difference`.
+func (s *Set[T]) DifferenceParallel(t Set[T], workers int) Set[T] {
+	kept := parallel_filter(s.Slice(), workers, func(v T) bool {
+		return !t.Contains(v)
+	})
+	result := NewSet(kept)
+	result.take_options(*s)
+	return result
+}
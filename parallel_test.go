@@ -0,0 +1,312 @@
+package set
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestNewSetParallel(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		n       int
+		spread  int
+		workers int
+	}{
+		{
+			desc:    "small input",
+			n:       100,
+			spread:  50,
+			workers: 4,
+		},
+		{
+			desc:    "many duplicates",
+			n:       200_000,
+			spread:  1000,
+			workers: 4,
+		},
+		{
+			desc:    "few duplicates",
+			n:       200_000,
+			spread:  1 << 30,
+			workers: 8,
+		},
+		{
+			desc:    "default workers",
+			n:       200_000,
+			spread:  10_000,
+			workers: 0,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			in := make([]int, tC.n)
+			for i := range in {
+				in[i] = rand.Intn(tC.spread)
+			}
+
+			want := NewSet(in)
+			got := NewSetParallel(in, tC.workers)
+			if !want.Equals(got) {
+				t.Errorf("got %d items; want %d items", got.Len(), want.Len())
+			}
+		})
+	}
+}
+
+func BenchmarkNewSetParallel(b *testing.B) {
+	in := make([]int, 1_000_000)
+	for i := range in {
+		in[i] = rand.Intn(10_000)
+	}
+
+	b.Run("serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewSet(in)
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewSetParallel(in, 0)
+		}
+	})
+}
+
+func TestParallelOperations(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		n1      int
+		n2      int
+		workers int
+	}{
+		{
+			desc:    "small sets",
+			n1:      100,
+			n2:      200,
+			workers: 4,
+		},
+		{
+			desc:    "large sets",
+			n1:      100_000,
+			n2:      60_000,
+			workers: 4,
+		},
+		{
+			desc:    "default workers",
+			n1:      60_000,
+			n2:      100_000,
+			workers: 0,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			in1 := make([]int, tC.n1)
+			for i := range in1 {
+				in1[i] = rand.Intn(2 * tC.n1)
+			}
+			in2 := make([]int, tC.n2)
+			for i := range in2 {
+				in2[i] = rand.Intn(2 * tC.n2)
+			}
+			s1, s2 := NewSet(in1), NewSet(in2)
+
+			if got, want := s1.UnionParallel(s2, tC.workers), s1.Union(s2); !got.Equals(want) {
+				t.Errorf("union: got %d items; want %d items", got.Len(), want.Len())
+			}
+			if got, want := s1.IntersectionParallel(s2, tC.workers), s1.Intersection(s2); !got.Equals(want) {
+				t.Errorf("intersection: got %d items; want %d items", got.Len(), want.Len())
+			}
+			if got, want := s1.DifferenceParallel(s2, tC.workers), s1.Difference(s2); !got.Equals(want) {
+				t.Errorf("difference: got %d items; want %d items", got.Len(), want.Len())
+			}
+		})
+	}
+}
+
+func TestParallelKeepsOptions(t *testing.T) {
+	s1 := NewSetPopSmallest([]int{1, 2, 3})
+	s1.SetFormatter(func(i int) string { return "#" })
+	// The larger set has neither option, so only taking them from `s1` passes
+	s2 := NewSet([]int{2, 3, 4, 5, 6, 7})
+
+	results := map[string]Set[int]{
+		"union":        s1.UnionParallel(s2, 2),
+		"intersection": s1.IntersectionParallel(s2, 2),
+		"difference":   s1.DifferenceParallel(s2, 2),
+	}
+	serial := map[string]Set[int]{
+		"union":        s1.Union(s2),
+		"intersection": s1.Intersection(s2),
+		"difference":   s1.Difference(s2),
+	}
+	for name, got := range results {
+		want := serial[name]
+		if got.String() != want.String() {
+			t.Errorf("%s: got %q; want %q", name, got.String(), want.String())
+		}
+		if got.pop_less == nil {
+			t.Errorf("%s: the pop order of the receiver should be kept", name)
+		}
+	}
+
+	// Every chunk takes the options of the set it was cut from
+	for i, part := range Chunk(s1, 2) {
+		want := s1.Intersection(part)
+		if part.String() != want.String() {
+			t.Errorf("chunk %d: got %q; want %q", i, part.String(), want.String())
+		}
+		if part.pop_less == nil {
+			t.Errorf("chunk %d: the pop order of the set should be kept", i)
+		}
+	}
+}
+
+func TestChunk(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		items int
+		n     int
+	}{
+		{desc: "empty", items: 0, n: 3},
+		{desc: "one chunk", items: 10, n: 1},
+		{desc: "even split", items: 12, n: 4},
+		{desc: "uneven split", items: 13, n: 4},
+		{desc: "more chunks than items", items: 2, n: 5},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			data := make([]int, tC.items)
+			for i := range data {
+				data[i] = i
+			}
+			s := NewSet(data)
+
+			chunks := Chunk(s, tC.n)
+			if len(chunks) != tC.n {
+				t.Fatalf("got %d chunks; want %d", len(chunks), tC.n)
+			}
+
+			union := NewSet([]int{})
+			smallest, largest := tC.items, 0
+			for _, c := range chunks {
+				if !union.IsDisjoint(c) {
+					t.Errorf("chunk %v overlaps an earlier chunk", c)
+				}
+				union.UnionInPlace(c)
+				smallest = min(smallest, c.Len())
+				largest = max(largest, c.Len())
+			}
+			if !union.Equals(s) {
+				t.Errorf("got %v from joining the chunks; want %v", union, s)
+			}
+			if largest-smallest > 1 {
+				t.Errorf("got chunk sizes from %d to %d; want them within one", smallest, largest)
+			}
+		})
+	}
+}
+
+func TestChunkPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("splitting into 0 chunks should panic")
+		}
+	}()
+	Chunk(NewSet([]int{1}), 0)
+}
+
+func TestForEachParallel(t *testing.T) {
+	data := make([]int, 1000)
+	for i := range data {
+		data[i] = i
+	}
+	s := NewSet(data)
+
+	for _, workers := range []int{0, 1, 4, 5000} {
+		var mu sync.Mutex
+		seen := NewSet([]int{})
+		err := ForEachParallel(context.Background(), s, workers, func(_ context.Context, item int) error {
+			mu.Lock()
+			defer mu.Unlock()
+			seen.Add(item)
+			return nil
+		})
+		if err != nil {
+			t.Errorf("%d workers: got error %v, want nil", workers, err)
+		}
+		if !seen.Equals(s) {
+			t.Errorf("%d workers: visited %d items; want %d", workers, seen.Len(), s.Len())
+		}
+	}
+}
+
+func TestForEachParallelError(t *testing.T) {
+	data := make([]int, 10_000)
+	for i := range data {
+		data[i] = i
+	}
+	s := NewSet(data)
+
+	want := errors.New("stop")
+	var calls atomic.Int64
+	err := ForEachParallel(context.Background(), s, 4, func(ctx context.Context, item int) error {
+		calls.Add(1)
+		return want
+	})
+	if !errors.Is(err, want) {
+		t.Errorf("got error %v, want %v", err, want)
+	}
+	// Every worker may have one item in hand when the first error comes back
+	if n := calls.Load(); n > 8 {
+		t.Errorf("got %d calls after the first error; want the rest skipped", n)
+	}
+}
+
+func TestForEachParallelCancel(t *testing.T) {
+	s := NewSet([]int{1, 2, 3, 4, 5})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var calls atomic.Int64
+	err := ForEachParallel(ctx, s, 2, func(context.Context, int) error {
+		calls.Add(1)
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+	if n := calls.Load(); n != 0 {
+		t.Errorf("got %d calls with a cancelled context; want 0", n)
+	}
+
+	var empty Set[int]
+	if err := ForEachParallel(context.Background(), empty, 4, func(context.Context, int) error {
+		return errors.New("should not be called")
+	}); err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+}
+
+func BenchmarkIntersectionParallel(b *testing.B) {
+	in1 := make([]int, 1_000_000)
+	in2 := make([]int, 1_000_000)
+	for i := range in1 {
+		in1[i] = rand.Intn(2_000_000)
+		in2[i] = rand.Intn(2_000_000)
+	}
+	s1, s2 := NewSet(in1), NewSet(in2)
+
+	b.Run("serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s1.Intersection(s2)
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s1.IntersectionParallel(s2, 0)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package set
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// This error is returned by `Parse` when the braces around the set don't match up
+	ErrUnbalancedBraces = errors.New("set: unbalanced braces")
+	// This error is returned by `Parse` when the set was printed with a limit, and the
+	// items past it were summed up as "… and N more"
+	ErrTruncated = errors.New("set: cannot parse a truncated set")
+)
+
+// ParseFunc will return a Set holding the items in `str`, in the form printed by
+// `String`, such as "{a, b, c}". Each item is trimmed of surrounding whitespace and
+// handed to `parse`. The braces are optional. Returns an error naming the position of
+// the first item that `parse` fails on.
+//
+// Items are split on commas, so string items that hold a comma can't be read back.
+func ParseFunc[T comparable](str string, parse func(string) (T, error)) (Set[T], error) {
+	str = strings.TrimSpace(str)
+	has_open, has_close := strings.HasPrefix(str, "{"), strings.HasSuffix(str, "}")
+	if has_open != has_close {
+		return Set[T]{}, ErrUnbalancedBraces
+	}
+	if has_open {
+		str = strings.TrimSpace(str[1 : len(str)-1])
+	}
+
+	if is_truncated(str) {
+		return Set[T]{}, ErrTruncated
+	}
+
+	result := NewSet([]T{})
+	if str == "" {
+		return result, nil
+	}
+
+	for idx, field := range strings.Split(str, ",") {
+		field = strings.TrimSpace(field)
+		v, err := parse(field)
+		if err != nil {
+			return Set[T]{}, fmt.Errorf("set: item %d: %w", idx+1, err)
+		}
+		result.Add(v)
+	}
+	return result, nil
+}
+
+// is_truncated returns true if `str` ends with the "… and N more" written by `String`
+// when the set is longer than its limit. N may have commas in it.
+func is_truncated(str string) bool {
+	idx := strings.LastIndex(str, "… and ")
+	if idx < 0 || !strings.HasSuffix(str, " more") {
+		return false
+	}
+	count := strings.TrimSuffix(str[idx+len("… and "):], " more")
+	if count == "" {
+		return false
+	}
+	for _, r := range count {
+		if (r < '0' || r > '9') && r != ',' {
+			return false
+		}
+	}
+	return true
+}
+
+// Parse is the same as `ParseFunc`, for items whose underlying type is a bool, integer,
+// float, or string. Numbers are parsed as Go writes them with %v, so the `String` output
+// of such a set can be read back in. Strings are taken as is, without quotes.
+func Parse[T comparable](str string) (Set[T], error) {
+	if !is_scalar[T]() {
+		var zero T
+		return Set[T]{}, fmt.Errorf("set: cannot parse items of type %T", zero)
+	}
+
+	return ParseFunc(str, parse_item[T])
+}
+
+// parse_item parses a single item. Its kind must already have been checked by `Parse`.
+func parse_item[T comparable](field string) (T, error) {
+	return read_scalar[T](scalar_reader{
+		Bool: func() (bool, error) { return strconv.ParseBool(field) },
+		Int: func(bits int) (int64, error) {
+			return strconv.ParseInt(field, 10, bits)
+		},
+		Uint: func(bits int) (uint64, error) {
+			return strconv.ParseUint(field, 10, bits)
+		},
+		Float: func(bits int) (float64, error) {
+			return strconv.ParseFloat(field, bits)
+		},
+		String: func() (string, error) { return field, nil },
+	}, "text")
+}
@@ -0,0 +1,115 @@
+package set
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		desc string
+		in   string
+		want []int
+	}{
+		{desc: "empty braces", in: "{}", want: []int{}},
+		{desc: "empty string", in: "", want: []int{}},
+		{desc: "String form", in: "{-3, 0, 12}", want: []int{-3, 0, 12}},
+		{desc: "no braces", in: "1,2, 3", want: []int{1, 2, 3}},
+		{desc: "surrounding space and duplicates", in: "  { 2, 2 , 1 }  ", want: []int{1, 2}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := Parse[int](tC.in)
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if want := NewSet(tC.want); !got.Equals(want) {
+				t.Errorf("got %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	ints := NewSet([]int{-100, 0, 7, 1 << 40})
+	if got, err := Parse[int](ints.String()); err != nil || !got.Equals(ints) {
+		t.Errorf("got %v, %v; want %v, nil", got, err, ints)
+	}
+
+	floats := NewSet([]float64{-1.5, 0, 3.14, 1e300})
+	if got, err := Parse[float64](floats.String()); err != nil || !got.Equals(floats) {
+		t.Errorf("got %v, %v; want %v, nil", got, err, floats)
+	}
+
+	words := NewSet([]string{"apple", "fig", "pear tree"})
+	if got, err := Parse[string](words.String()); err != nil || !got.Equals(words) {
+		t.Errorf("got %v, %v; want %v, nil", got, err, words)
+	}
+
+	type level uint8
+	levels := NewSet([]level{0, 3, 255})
+	if got, err := Parse[level](levels.String()); err != nil || !got.Equals(levels) {
+		t.Errorf("got %v, %v; want %v, nil", got, err, levels)
+	}
+
+	flags := NewSet([]bool{true, false})
+	if got, err := Parse[bool](flags.String()); err != nil || !got.Equals(flags) {
+		t.Errorf("got %v, %v; want %v, nil", got, err, flags)
+	}
+}
+
+func TestParseFunc(t *testing.T) {
+	got, err := ParseFunc("{A, b, C}", func(field string) (string, error) {
+		return strings.ToLower(field), nil
+	})
+	want := NewSet([]string{"a", "b", "c"})
+	if err != nil || !got.Equals(want) {
+		t.Errorf("got %v, %v; want %v, nil", got, err, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	testCases := []struct {
+		desc string
+		in   string
+		want error
+	}{
+		{desc: "missing close", in: "{1, 2", want: ErrUnbalancedBraces},
+		{desc: "missing open", in: "1, 2}", want: ErrUnbalancedBraces},
+		{desc: "not a number", in: "{1, two, 3}", want: strconv.ErrSyntax},
+		{desc: "empty item", in: "{1, , 3}", want: strconv.ErrSyntax},
+		{desc: "overflows int8", in: "{1, 200}", want: strconv.ErrRange},
+		{desc: "truncated", in: "{1, 2, … and 1,000 more}", want: ErrTruncated},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if _, err := Parse[int8](tC.in); !errors.Is(err, tC.want) {
+				t.Errorf("got error %v, want %v", err, tC.want)
+			}
+		})
+	}
+
+	if _, err := Parse[[2]int]("{}"); err == nil {
+		t.Errorf("parsing a type with no text form should return an error")
+	}
+}
+
+func TestParseTruncatedString(t *testing.T) {
+	items := make([]int, 2000)
+	for i := range items {
+		items[i] = i
+	}
+	s := NewSet(items)
+	s.SetStringLimit(3)
+	if _, err := Parse[int](s.String()); !errors.Is(err, ErrTruncated) {
+		t.Errorf("got error %v, want %v", err, ErrTruncated)
+	}
+
+	// Only a real count counts as truncation
+	words, err := Parse[string]("{… and some more}")
+	if want := NewSet([]string{"… and some more"}); err != nil || !words.Equals(want) {
+		t.Errorf("got %v, %v; want %v, nil", words, err, want)
+	}
+}
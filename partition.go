@@ -0,0 +1,82 @@
+package set
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// These errors are matched by the PartitionError returned from `CheckPartition`, to
+	// say which way the parts fail to partition the whole
+	ErrPartsOverlap = errors.New("parts overlap")
+	ErrNotInWhole   = errors.New("part has an item not in the whole")
+	ErrNotCovered   = errors.New("whole has an item in no part")
+)
+
+// PartitionError says where `CheckPartition` found that the parts don't partition the
+// whole. It matches one of ErrPartsOverlap, ErrNotInWhole or ErrNotCovered with
+// `errors.Is`.
+type PartitionError[T comparable] struct {
+	// Item is the item that breaks the partition
+	Item T
+	// Part is the index of the part holding `Item`, or -1 for ErrNotCovered
+	Part int
+	// Other is the index of the earlier part also holding `Item` for ErrPartsOverlap,
+	// and -1 otherwise
+	Other int
+	// Err is which of the three checks failed
+	Err error
+}
+
+func (e *PartitionError[T]) Error() string {
+	switch e.Err {
+	case ErrPartsOverlap:
+		return fmt.Sprintf("item %v is in part %d and part %d", e.Item, e.Other, e.Part)
+	case ErrNotInWhole:
+		return fmt.Sprintf("item %v of part %d is not in the whole", e.Item, e.Part)
+	default:
+		return fmt.Sprintf("item %v of the whole is in no part", e.Item)
+	}
+}
+
+// Unwrap returns which of the three checks failed
+func (e *PartitionError[T]) Unwrap() error {
+	return e.Err
+}
+
+// CheckPartition will return nil if `parts` partition `whole`: no item is in more than
+// one part, and together the parts hold exactly the items of `whole`. Empty parts are
+// allowed. Otherwise it returns a *PartitionError naming the first item found that
+// breaks the partition.
+func CheckPartition[T comparable](parts []Set[T], whole Set[T]) error {
+	// Which part each item was found in
+	owner := make(map[T]int, whole.Len())
+	for idx, part := range parts {
+		for v := range part.data {
+			if !whole.Contains(v) {
+				return &PartitionError[T]{Item: v, Part: idx, Other: -1, Err: ErrNotInWhole}
+			}
+			if other, ok := owner[v]; ok {
+				return &PartitionError[T]{Item: v, Part: idx, Other: other, Err: ErrPartsOverlap}
+			}
+			owner[v] = idx
+		}
+	}
+
+	// Every item found is in `whole`, so if the counts match, all of `whole` is covered
+	if len(owner) == whole.Len() {
+		return nil
+	}
+	for v := range whole.data {
+		if _, ok := owner[v]; !ok {
+			return &PartitionError[T]{Item: v, Part: -1, Other: -1, Err: ErrNotCovered}
+		}
+	}
+	return nil
+}
+
+// IsPartitionOf will return true if `parts` partition `whole`. See `CheckPartition` to
+// find out where it fails.
+func IsPartitionOf[T comparable](parts []Set[T], whole Set[T]) bool {
+	return CheckPartition(parts, whole) == nil
+}
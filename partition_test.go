@@ -0,0 +1,67 @@
+package set
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckPartition(t *testing.T) {
+	whole := NewSet([]int{1, 2, 3, 4, 5})
+	testCases := []struct {
+		desc  string
+		parts [][]int
+		want  error
+		item  int
+		part  int
+		other int
+	}{
+		{desc: "partition", parts: [][]int{{1, 2}, {}, {3, 4, 5}}, want: nil},
+		{desc: "one part", parts: [][]int{{1, 2, 3, 4, 5}}, want: nil},
+		{desc: "overlap", parts: [][]int{{1, 2}, {3}, {2, 4, 5}}, want: ErrPartsOverlap, item: 2, part: 2, other: 0},
+		{desc: "outside the whole", parts: [][]int{{1, 2, 3}, {4, 5, 6}}, want: ErrNotInWhole, item: 6, part: 1, other: -1},
+		{desc: "not covered", parts: [][]int{{1, 2}, {4, 5}}, want: ErrNotCovered, item: 3, part: -1, other: -1},
+		{desc: "no parts", parts: [][]int{}, want: ErrNotCovered, part: -1, other: -1},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			parts := make([]Set[int], len(tC.parts))
+			for i, items := range tC.parts {
+				parts[i] = NewSet(items)
+			}
+
+			err := CheckPartition(parts, whole)
+			if got := IsPartitionOf(parts, whole); got != (tC.want == nil) {
+				t.Errorf("IsPartitionOf: got %v; want %v", got, tC.want == nil)
+			}
+			if tC.want == nil {
+				if err != nil {
+					t.Errorf("got error %v, want nil", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, tC.want) {
+				t.Fatalf("got error %v, want %v", err, tC.want)
+			}
+			var perr *PartitionError[int]
+			if !errors.As(err, &perr) {
+				t.Fatalf("got error %T, want a *PartitionError[int]", err)
+			}
+			// With no parts, any item of the whole could be reported
+			if len(tC.parts) > 0 && perr.Item != tC.item {
+				t.Errorf("Item: got %v; want %v", perr.Item, tC.item)
+			}
+			if perr.Part != tC.part || perr.Other != tC.other {
+				t.Errorf("got parts %d and %d; want %d and %d", perr.Part, perr.Other, tC.part, tC.other)
+			}
+		})
+	}
+}
+
+func TestPartitionErrorString(t *testing.T) {
+	err := &PartitionError[int]{Item: 2, Part: 2, Other: 0, Err: ErrPartsOverlap}
+	want := "item 2 is in part 0 and part 2"
+	if got := err.Error(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
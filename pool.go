@@ -0,0 +1,70 @@
+package set
+
+import "sync"
+
+// Pool holds on to sets that are no longer needed, so that their maps can be reused
+// instead of allocating new ones. This is most useful for the temporary sets produced
+// by set algebra in hot loops. The zero value is ready to use. A Pool must not be copied
+// after first use.
+type Pool[T comparable] struct {
+	pool sync.Pool
+}
+
+// Get will return an empty set, reusing one that was handed back with `Put` if there
+// is one available
+func (p *Pool[T]) Get() Set[T] {
+	if s, ok := p.pool.Get().(*Set[T]); ok {
+		return *s
+	}
+	return Set[T]{data: make(map[T]struct{})}
+}
+
+// Put hands `s` back to the pool, to be reused by a later `Get`. The set is cleared,
+// and it must not be used again by the caller after this.
+func (p *Pool[T]) Put(s Set[T]) {
+	if s.data == nil {
+		return
+	}
+
+	// Emptying the map in place keeps its memory around for the next user
+	clear(s.data)
+	p.pool.Put(&s)
+}
+
+// Intersection will return a set from the pool, filled with the intersection of `s` and
+// `t`. Hand it back with `Put` once you are done with it.
+func (p *Pool[T]) Intersection(s, t Set[T]) Set[T] {
+	result := p.Get()
+
+	// Iterate over the smaller of the two sets, and add the item to `result` if it is
+	// in the larger of the two sets
+	if s.Len() < t.Len() {
+		for v := range s.data {
+			if t.Contains(v) {
+				result.Add(v)
+			}
+		}
+	} else {
+		for v := range t.data {
+			if s.Contains(v) {
+				result.Add(v)
+			}
+		}
+	}
+
+	return result
+}
+
+// Difference will return a set from the pool, filled with the elements in `s` that are
+// not in `t`. Hand it back with `Put` once you are done with it.
+func (p *Pool[T]) Difference(s, t Set[T]) Set[T] {
+	result := p.Get()
+
+	for v := range s.data {
+		if !t.Contains(v) {
+			result.Add(v)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,67 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestPool(t *testing.T) {
+	var p Pool[int]
+
+	s := p.Get()
+	if !s.IsEmpty() {
+		t.Errorf("got %v, want empty", s)
+	}
+	s.Add(1)
+	s.Add(2)
+	p.Put(s)
+
+	// Whatever comes back out must be empty, whether it was reused or not
+	s = p.Get()
+	if !s.IsEmpty() {
+		t.Errorf("got %v, want empty", s)
+	}
+}
+
+func TestPoolOperations(t *testing.T) {
+	var p Pool[int]
+	s1 := NewSet([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+	s2 := NewSet([]int{5, 6, 7, 8, 9, 10, 11, 12, 13, 14})
+
+	for i := 0; i < 3; i++ {
+		intersection := p.Intersection(s1, s2)
+		if want := s1.Intersection(s2); !intersection.Equals(want) {
+			t.Errorf("got %v, want %v", intersection, want)
+		}
+		p.Put(intersection)
+
+		difference := p.Difference(s1, s2)
+		if want := s1.Difference(s2); !difference.Equals(want) {
+			t.Errorf("got %v, want %v", difference, want)
+		}
+		p.Put(difference)
+	}
+}
+
+func BenchmarkPoolIntersection(b *testing.B) {
+	in1 := make([]int, 1000)
+	in2 := make([]int, 1000)
+	for i := range in1 {
+		in1[i] = i
+		in2[i] = i + 500
+	}
+	s1, s2 := NewSet(in1), NewSet(in2)
+
+	b.Run("no pool", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s1.Intersection(s2)
+		}
+	})
+	b.Run("pool", func(b *testing.B) {
+		b.ReportAllocs()
+		var p Pool[int]
+		for i := 0; i < b.N; i++ {
+			p.Put(p.Intersection(s1, s2))
+		}
+	})
+}
@@ -0,0 +1,22 @@
+package set
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+// ToProtoRepeated will return the items of the set in ascending order, for a `repeated`
+// field of a protobuf message, such as `msg.Ids = set.ToProtoRepeated(ids)`. Generated
+// code holds repeated fields as plain slices, so any slice would do, but the order of
+// `Slice` changes from run to run, and sorting makes the encoded message the same every
+// time. Use `Slice` instead for item types that aren't ordered.
+func ToProtoRepeated[T constraints.Ordered](s Set[T]) []T {
+	return SortedSlice(s)
+}
+
+// FromProtoRepeated will return a Set holding the items of a `repeated` field of a
+// protobuf message, such as `set.FromProtoRepeated(msg.Ids)`. A repeated field may hold
+// the same item more than once, and it is kept just once in the set. The field is not
+// kept, so the message can be reused.
+func FromProtoRepeated[T comparable, S ~[]T](field S) Set[T] {
+	return NewSet(field)
+}
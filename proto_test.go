@@ -0,0 +1,36 @@
+package set
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestProtoRepeated(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		field []int64
+		want  []int64
+	}{
+		{desc: "empty", field: []int64{}, want: []int64{}},
+		{desc: "in order", field: []int64{1, 2, 3}, want: []int64{1, 2, 3}},
+		{desc: "out of order", field: []int64{30, -4, 7}, want: []int64{-4, 7, 30}},
+		{desc: "repeated items", field: []int64{5, 1, 5, 1}, want: []int64{1, 5}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := FromProtoRepeated(tC.field)
+			if got := ToProtoRepeated(s); !slices.Equal(got, tC.want) {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+
+	// The field can be reused once the set is made
+	field := []string{"b", "a"}
+	s := FromProtoRepeated(field)
+	field[0] = "z"
+	if !s.Contains("b") || s.Contains("z") {
+		t.Errorf("got %v; want {a, b}", s)
+	}
+}
@@ -0,0 +1,181 @@
+package set
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/natemcintosh/set/internal/binfile"
+)
+
+var (
+	// This error is returned by `Load` when the input is not a saved set, or is cut short
+	ErrInvalidFile = binfile.ErrInvalidFile
+
+	// This error is returned by `Load` when the file was saved by a newer version
+	ErrUnsupportedVersion = binfile.ErrUnsupportedVersion
+
+	// This error is returned by `Load` when the file has been corrupted
+	ErrChecksum = binfile.ErrChecksum
+)
+
+// The file format version written by `Save`
+const save_version = 1
+
+var save_magic = [4]byte{'G', 'S', 'E', 'T'}
+
+// max_preallocate limits how much room `Load` makes up front, so a corrupt count can't
+// allocate a huge map
+const max_preallocate = 1 << 20
+
+// Save writes the set to `w` in a compact binary form that `Load` can read back. The
+// file starts with a magic number and format version, and ends with a checksum. The
+// items are streamed through a buffer, so the encoded set is never held in memory. The
+// item type must have a boolean, integer, float, or string as its underlying type.
+func (s *Set[T]) Save(w io.Writer) error {
+	kind, err := item_kind[T]()
+	if err != nil {
+		return err
+	}
+
+	bw := binfile.NewWriter(w, save_magic, save_version)
+	bw.Byte(byte(kind))
+	bw.Uvarint(uint64(s.Len()))
+	for v := range s.data {
+		write_item(bw, v)
+	}
+	return bw.Close()
+}
+
+// Load replaces the contents of the set with a set written by `Save`. It returns an
+// error if the file is not a saved set, was saved with a different kind of item, or
+// fails its checksum. The set is left unchanged on error.
+func (s *Set[T]) Load(r io.Reader) error {
+	br, err := binfile.NewReader(r, save_magic, save_version)
+	if err != nil {
+		return err
+	}
+
+	if err := check_kind[T](br); err != nil {
+		return err
+	}
+	n, err := br.Uvarint()
+	if err != nil {
+		return err
+	}
+
+	result := make(map[T]struct{}, min(n, max_preallocate))
+	for i := uint64(0); i < n; i++ {
+		item, err := read_item[T](br)
+		if err != nil {
+			return err
+		}
+		result[item] = struct{}{}
+	}
+	if err := br.Close(); err != nil {
+		return err
+	}
+
+	s.data = result
+	return nil
+}
+
+// item_kind returns the reflect.Kind of T, or an error if items of that kind can't be
+// written by `write_item`
+func item_kind[T comparable]() (reflect.Kind, error) {
+	var zero T
+	kind := reflect.ValueOf(&zero).Elem().Kind()
+	switch kind {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr, reflect.Float32, reflect.Float64, reflect.String:
+		return kind, nil
+	default:
+		return kind, fmt.Errorf("set: cannot save items of type %T", zero)
+	}
+}
+
+// check_kind reads the kind byte written after the header, and makes sure it is the
+// kind of T
+func check_kind[T comparable](br *binfile.Reader) error {
+	kind, err := br.ReadByte()
+	if err != nil {
+		return err
+	}
+	var zero T
+	if reflect.Kind(kind) != reflect.ValueOf(&zero).Elem().Kind() {
+		return fmt.Errorf("set: cannot load %v items into %T", reflect.Kind(kind), zero)
+	}
+	return nil
+}
+
+// write_item writes a single item. Its kind must already have been checked with
+// `item_kind`.
+func write_item[T comparable](bw *binfile.Writer, v T) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			bw.Byte(1)
+		} else {
+			bw.Byte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bw.Varint(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		bw.Uvarint(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		bw.Float64(rv.Float())
+	case reflect.String:
+		bw.String(rv.String())
+	}
+}
+
+// read_item reads a single item written by `write_item`
+func read_item[T comparable](br *binfile.Reader) (item T, err error) {
+	rv := reflect.ValueOf(&item).Elem()
+	switch rv.Kind() {
+	case reflect.Bool:
+		b, err := br.ReadByte()
+		if err != nil {
+			return item, err
+		}
+		rv.SetBool(b != 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := br.Varint()
+		if err != nil {
+			return item, err
+		}
+		if rv.OverflowInt(v) {
+			return item, fmt.Errorf("set: saved value %d overflows %T", v, item)
+		}
+		rv.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		v, err := br.Uvarint()
+		if err != nil {
+			return item, err
+		}
+		if rv.OverflowUint(v) {
+			return item, fmt.Errorf("set: saved value %d overflows %T", v, item)
+		}
+		rv.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := br.Float64()
+		if err != nil {
+			return item, err
+		}
+		rv.SetFloat(v)
+	case reflect.String:
+		v, err := br.String()
+		if err != nil {
+			return item, err
+		}
+		rv.SetString(v)
+	default:
+		return item, fmt.Errorf("set: cannot load items of type %T", item)
+	}
+	return item, nil
+}
@@ -0,0 +1,80 @@
+package set
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestSaveLoad(t *testing.T) {
+	ints := NewSet([]int{-1 << 40, -3, 0, 7, 1 << 62})
+	var buf bytes.Buffer
+	if err := ints.Save(&buf); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	var got_ints Set[int]
+	if err := got_ints.Load(&buf); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if !got_ints.Equals(ints) {
+		t.Errorf("got %v; want %v", got_ints, ints)
+	}
+
+	strs := NewSet([]string{"", "a", "hello world"})
+	buf.Reset()
+	if err := strs.Save(&buf); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	var got_strs Set[string]
+	if err := got_strs.Load(&buf); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if !got_strs.Equals(strs) {
+		t.Errorf("got %v; want %v", got_strs, strs)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	s := NewSet([]uint8{1, 2, 3})
+	var buf bytes.Buffer
+	if err := s.Save(&buf); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	good := buf.Bytes()
+
+	corrupt := bytes.Clone(good)
+	corrupt[len(corrupt)-1] ^= 0xff
+
+	future := bytes.Clone(good)
+	future[4] = save_version + 1
+
+	testCases := []struct {
+		desc string
+		data []byte
+		want error
+	}{
+		{desc: "empty", data: nil, want: ErrInvalidFile},
+		{desc: "bad magic", data: []byte("NOPE\x01"), want: ErrInvalidFile},
+		{desc: "truncated", data: good[:len(good)-2], want: ErrInvalidFile},
+		{desc: "corrupt", data: corrupt, want: ErrChecksum},
+		{desc: "future version", data: future, want: ErrUnsupportedVersion},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got := NewSet([]uint8{100})
+			err := got.Load(bytes.NewReader(tC.data))
+			if !errors.Is(err, tC.want) {
+				t.Errorf("got error %v, want %v", err, tC.want)
+			}
+			if !got.Contains(100) || got.Len() != 1 {
+				t.Errorf("a failed Load should leave the set alone, got %v", got)
+			}
+		})
+	}
+
+	// The item type has to match the one that was saved
+	var strs Set[string]
+	if err := strs.Load(bytes.NewReader(good)); err == nil {
+		t.Errorf("loading uint8 items into a Set[string] should fail")
+	}
+}
@@ -0,0 +1,111 @@
+package set
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// scalar_writer writes one item of each kind that `write_scalar` supports. Each format
+// fills it in with the functions that append to its output.
+type scalar_writer struct {
+	Bool   func(v bool)
+	Int    func(v int64)
+	Uint   func(v uint64)
+	Float  func(v float64)
+	String func(v string)
+}
+
+// scalar_reader reads one item of each kind that `read_scalar` supports. The numeric
+// readers are given the size in bits of the item type, for formats that parse to it.
+type scalar_reader struct {
+	Bool   func() (bool, error)
+	Int    func(bits int) (int64, error)
+	Uint   func(bits int) (uint64, error)
+	Float  func(bits int) (float64, error)
+	String func() (string, error)
+}
+
+// is_scalar returns true if the underlying type of `T` is a bool, integer, float, or
+// string, which are the items the Marshal, Unmarshal and Parse functions handle
+func is_scalar[T any]() bool {
+	var zero T
+	switch reflect.ValueOf(&zero).Elem().Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr, reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+	return false
+}
+
+// write_scalar hands `item` to the function of `w` for its kind. Returns false if `T`
+// is not one `is_scalar` accepts.
+func write_scalar[T any](item T, w scalar_writer) bool {
+	rv := reflect.ValueOf(item)
+	switch rv.Kind() {
+	case reflect.Bool:
+		w.Bool(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		w.Int(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		w.Uint(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		w.Float(rv.Float())
+	case reflect.String:
+		w.String(rv.String())
+	default:
+		return false
+	}
+	return true
+}
+
+// read_scalar reads an item with the function of `r` for the kind of `T`. Returns an
+// error naming `format` if the value read doesn't fit in `T`, or if `T` is not one
+// `is_scalar` accepts.
+func read_scalar[T any](r scalar_reader, format string) (item T, err error) {
+	rv := reflect.ValueOf(&item).Elem()
+	switch rv.Kind() {
+	case reflect.Bool:
+		v, err := r.Bool()
+		if err != nil {
+			return item, err
+		}
+		rv.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := r.Int(rv.Type().Bits())
+		if err != nil {
+			return item, err
+		}
+		if rv.OverflowInt(v) {
+			return item, fmt.Errorf("set: %s value %d overflows %T", format, v, item)
+		}
+		rv.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		v, err := r.Uint(rv.Type().Bits())
+		if err != nil {
+			return item, err
+		}
+		if rv.OverflowUint(v) {
+			return item, fmt.Errorf("set: %s value %d overflows %T", format, v, item)
+		}
+		rv.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := r.Float(rv.Type().Bits())
+		if err != nil {
+			return item, err
+		}
+		rv.SetFloat(v)
+	case reflect.String:
+		v, err := r.String()
+		if err != nil {
+			return item, err
+		}
+		rv.SetString(v)
+	default:
+		return item, fmt.Errorf("set: cannot decode %s into %T", format, item)
+	}
+	return item, nil
+}
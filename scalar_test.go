@@ -0,0 +1,46 @@
+package set
+
+import (
+	"testing"
+)
+
+func TestScalarRoundTrip(t *testing.T) {
+	type level uint8
+
+	// Write the item into `got`, then read it back out as the same type
+	var got any
+	w := scalar_writer{
+		Bool:   func(v bool) { got = v },
+		Int:    func(v int64) { got = v },
+		Uint:   func(v uint64) { got = v },
+		Float:  func(v float64) { got = v },
+		String: func(v string) { got = v },
+	}
+	r := scalar_reader{
+		Bool:   func() (bool, error) { return got.(bool), nil },
+		Int:    func(int) (int64, error) { return got.(int64), nil },
+		Uint:   func(int) (uint64, error) { return got.(uint64), nil },
+		Float:  func(int) (float64, error) { return got.(float64), nil },
+		String: func() (string, error) { return got.(string), nil },
+	}
+
+	if !write_scalar(level(7), w) {
+		t.Fatalf("a named uint8 should be written")
+	}
+	if v, err := read_scalar[level](r, "test"); err != nil || v != 7 {
+		t.Errorf("got %v, %v; want 7, nil", v, err)
+	}
+
+	// 300 doesn't fit in a level
+	got = uint64(300)
+	if _, err := read_scalar[level](r, "test"); err == nil {
+		t.Errorf("300 should overflow a uint8")
+	}
+
+	if write_scalar([2]int{}, w) || is_scalar[[2]int]() {
+		t.Errorf("an array is not a scalar")
+	}
+	if _, err := read_scalar[[2]int](r, "test"); err == nil {
+		t.Errorf("reading an array should return an error")
+	}
+}
@@ -0,0 +1,71 @@
+//go:build go1.23
+
+// The Seq functions return range-over-func iterators, which need go1.23 or later.
+
+package set
+
+import (
+	"iter"
+)
+
+// IntersectSeq returns an iterator over the items in both `a` and `b`, found as it goes
+// rather than gathered into a new set. The smaller set is walked and each item looked up
+// in the larger one. Neither set may be modified while the iterator is in use.
+func IntersectSeq[T comparable](a, b Set[T]) iter.Seq[T] {
+	small, large := a, b
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	return func(yield func(T) bool) {
+		for v := range small.data {
+			if large.Contains(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// UnionSeq returns an iterator over the items in either `a` or `b`, each yielded once.
+// All of `a` comes first, then the items of `b` that are not in `a`.
+func UnionSeq[T comparable](a, b Set[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range a.data {
+			if !yield(v) {
+				return
+			}
+		}
+		for v := range b.data {
+			if !a.Contains(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// DifferenceSeq returns an iterator over the items in `a` that are not in `b`
+func DifferenceSeq[T comparable](a, b Set[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range a.data {
+			if !b.Contains(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SymmetricDifferenceSeq returns an iterator over the items in either `a` or `b`, but
+// not both
+func SymmetricDifferenceSeq[T comparable](a, b Set[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range DifferenceSeq(a, b) {
+			if !yield(v) {
+				return
+			}
+		}
+		for v := range DifferenceSeq(b, a) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+//go:build go1.23
+
+package set
+
+import (
+	"iter"
+	"testing"
+)
+
+func TestSeq(t *testing.T) {
+	s1 := NewSet([]int{1, 2, 3, 4, 5, 6})
+	s2 := NewSet([]int{4, 5, 6, 7, 8})
+
+	testCases := []struct {
+		desc string
+		seq  iter.Seq[int]
+		want Set[int]
+	}{
+		{desc: "intersect", seq: IntersectSeq(s1, s2), want: s1.Intersection(s2)},
+		{desc: "union", seq: UnionSeq(s1, s2), want: s1.Union(s2)},
+		{desc: "difference", seq: DifferenceSeq(s1, s2), want: s1.Difference(s2)},
+		{desc: "symmetric difference", seq: SymmetricDifferenceSeq(s1, s2), want: s1.SymmetricDifference(s2)},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			// Each item comes out exactly once
+			got := NewSet([]int{})
+			count := 0
+			for v := range tC.seq {
+				got.Add(v)
+				count++
+			}
+			if !got.Equals(tC.want) || count != tC.want.Len() {
+				t.Errorf("got %v from %d items; want %v", got, count, tC.want)
+			}
+
+			// Breaking out early stops the iterator
+			count = 0
+			for range tC.seq {
+				count++
+				break
+			}
+			if count != 1 {
+				t.Errorf("got %d items before stopping; want 1", count)
+			}
+		})
+	}
+}
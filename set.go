@@ -4,7 +4,11 @@ package set
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"strconv"
 	"strings"
+
+	"golang.org/x/exp/slices"
 )
 
 var (
@@ -12,10 +16,46 @@ var (
 	ErrElementNotFound = errors.New("element not found")
 )
 
+// NotFoundError is returned by `Remove` when the item isn't in the set. It matches
+// ErrElementNotFound with `errors.Is`, and `errors.As` gives back the missing item.
+type NotFoundError[T comparable] struct {
+	Item T
+}
+
+func (e *NotFoundError[T]) Error() string {
+	return fmt.Sprintf("element not found: %v", e.Item)
+}
+
+// Unwrap returns ErrElementNotFound
+func (e *NotFoundError[T]) Unwrap() error {
+	return ErrElementNotFound
+}
+
 type Set[T comparable] struct {
 	data map[T]struct{}
+
+	// display holds the options String uses to print the set
+	display display_options[T]
+
+	// pop_less, if not nil, makes Pop take the smallest item by it, rather than
+	// whichever item the map gives first
+	pop_less func(a, b T) bool
+}
+
+// display_options are the per-set options for String
+type display_options[T comparable] struct {
+	// format is used to print each item. If nil, items are printed with %v
+	format func(T) string
+
+	// limit is the most items to print. If 0, `DefaultStringLimit` is used
+	limit int
 }
 
+// DefaultStringLimit is the most items String will print for sets that haven't been
+// given a limit with `SetStringLimit`. The rest are summed up as "… and N more". If 0,
+// every item is printed.
+var DefaultStringLimit = 0
+
 // NewSet will return a Set object from an input slice, or anything that has a slice as
 // the underlying data type
 func NewSet[T comparable, S ~[]T](data S) Set[T] {
@@ -53,32 +93,121 @@ func NewSetWithCapacity[T comparable, S ~[]T](data S, size int) Set[T] {
 }
 
 func (s Set[T]) String() string {
+	return s.render(s.string_limit(), s.format_item)
+}
+
+// string_limit returns the most items String prints, from `SetStringLimit` or else
+// `DefaultStringLimit`. It is negative if there is no limit.
+func (s *Set[T]) string_limit() int {
+	limit := s.display.limit
+	if limit == 0 {
+		limit = DefaultStringLimit
+	}
+	if limit == 0 {
+		limit = -1
+	}
+	return limit
+}
+
+// render prints at most `limit` items using `item`, and sums up the rest as "… and N
+// more". A negative limit prints every item.
+func (s *Set[T]) render(limit int, item func(T) string) string {
 	var b strings.Builder
-	last_index := s.Len() - 1
-	index := -1
 	b.WriteString("{")
+	printed := 0
 	for v := range s.data {
-		index += 1
-
-		if index < last_index {
-			b.WriteString(fmt.Sprintf("%v, ", v))
-		} else {
-			b.WriteString(fmt.Sprintf("%v}", v))
+		if printed == limit {
+			break
+		}
+		if printed > 0 {
+			b.WriteString(", ")
 		}
+		b.WriteString(item(v))
+		printed += 1
 	}
+	if remaining := s.Len() - printed; remaining > 0 {
+		if printed > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("… and " + with_commas(remaining) + " more")
+	}
+	b.WriteString("}")
+
+	return b.String()
+}
 
+// with_commas formats a non-negative number with a comma between each group of three
+// digits
+func with_commas(n int) string {
+	digits := strconv.Itoa(n)
+	var b strings.Builder
+	for idx, c := range digits {
+		if idx > 0 && (len(digits)-idx)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
 	return b.String()
 }
 
+// SetStringLimit sets the most items String will print. The rest are summed up as
+// "… and N more", so accidentally logging a huge set stays cheap. Pass 0 to go back to
+// `DefaultStringLimit`, or a negative number to always print every item. The limit is
+// kept the same way as the formatter from `SetFormatter`.
+func (s *Set[T]) SetStringLimit(n int) {
+	s.display.limit = n
+}
+
+// SetFormatter sets the function String uses to print each item, such as printing just
+// the ID of a struct rather than all of its fields. Pass nil to go back to %v. The
+// formatter is kept by `Copy`, and by the sets returned from set operations on `s`.
+func (s *Set[T]) SetFormatter(format func(T) string) {
+	s.display.format = format
+}
+
+// format_item returns the string form of a single item
+func (s *Set[T]) format_item(v T) string {
+	if s.display.format != nil {
+		return s.display.format(v)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
 // Slice will return all the items in the set as a slice. They are not guaranteed in any
 // particular order.
 func (s *Set[T]) Slice() []T {
-	result := make([]T, 0, s.Len())
+	return s.AppendTo(make([]T, 0, s.Len()))
+}
 
+// AppendTo appends the items of the set to `dst`, in no particular order, and returns
+// the extended slice. Reusing one buffer, resliced with `buf[:0]`, saves the allocation
+// `Slice` makes on every call.
+func (s *Set[T]) AppendTo(dst []T) []T {
+	dst = slices.Grow(dst, len(s.data))
 	for v := range s.data {
-		result = append(result, v)
+		dst = append(dst, v)
 	}
+	return dst
+}
 
+// Iterate calls `f` on every item in the set, in no particular order. It stops early if
+// `f` returns false.
+func (s *Set[T]) Iterate(f func(item T) bool) {
+	for v := range s.data {
+		if !f(v) {
+			return
+		}
+	}
+}
+
+// Count returns how many items in the set `pred` returns true for
+func (s *Set[T]) Count(pred func(item T) bool) int {
+	result := 0
+	for v := range s.data {
+		if pred(v) {
+			result++
+		}
+	}
 	return result
 }
 
@@ -104,32 +233,54 @@ func (s *Set[T]) Add(item T) {
 	s.data[item] = struct{}{}
 }
 
-// Remove removes an item from the set. Returns an error if the item doesn't exist.
-// See `Discard` for method that does not return an error
+// Remove removes an item from the set. Returns a *NotFoundError if the item doesn't
+// exist. See `Discard` for method that does not return an error
 func (s *Set[T]) Remove(item T) error {
 	if !s.Contains(item) {
-		return ErrElementNotFound
+		return &NotFoundError[T]{Item: item}
 	}
 
 	delete(s.data, item)
 	return nil
 }
 
+// TryRemove removes an item from the set, and returns true if it was there. Use it
+// instead of `Remove` when a missing item is a normal condition, as it doesn't need to
+// build an error.
+func (s *Set[T]) TryRemove(item T) bool {
+	if _, ok := s.data[item]; !ok {
+		return false
+	}
+
+	delete(s.data, item)
+	return true
+}
+
 // Discard removes an item from the set. If it doesn't exist, it is ignored
 func (s *Set[T]) Discard(item T) {
 	delete(s.data, item)
 }
 
 // Pop will remove and return an arbitrary item from the set. If the set is empty,
-// it will return an error
+// it will return an error. Which item is arbitrary, and changes from run to run, unless
+// an order has been set with `SetPopOrder`.
 func (s *Set[T]) Pop() (item T, err error) {
 	if s.IsEmpty() {
 		return item, ErrElementNotFound
 	}
 
-	// Get the first item
-	for item = range s.data {
-		break
+	if s.pop_less != nil {
+		first := true
+		for v := range s.data {
+			if first || s.pop_less(v, item) {
+				item, first = v, false
+			}
+		}
+	} else {
+		// Get the first item
+		for item = range s.data {
+			break
+		}
 	}
 
 	// Discard it
@@ -138,22 +289,39 @@ func (s *Set[T]) Pop() (item T, err error) {
 	return item, nil
 }
 
-// Clear will remove all items from the set
+// SetPopOrder makes Pop always take the smallest item according to `less`, so that a
+// simulation popping items replays the same way every time. Finding the smallest item
+// means looking at every item, so each Pop becomes O(n). Pass nil to go back to popping
+// whichever item is quickest to find. The order is kept by `Copy`.
+func (s *Set[T]) SetPopOrder(less func(a, b T) bool) {
+	s.pop_less = less
+}
+
+// take_options gives `s` the display options and pop order of `from`, so a result made
+// from `from` prints and pops the same way
+func (s *Set[T]) take_options(from Set[T]) {
+	s.display = from.display
+	s.pop_less = from.pop_less
+}
+
+// Clear will remove all items from the set. The map is emptied in place, so it keeps its
+// memory, and a map handed out by `UnsafeAsMap` or taken by `FromMap` stays the set's.
 func (s *Set[T]) Clear() {
-	s.data = make(map[T]struct{})
+	if s.data == nil {
+		s.data = make(map[T]struct{})
+		return
+	}
+	clear(s.data)
 }
 
 // Copy makes a deep copy as quickly as possible
 func (s *Set[T]) Copy() Set[T] {
-	// Make sure to allocate the same size
-	copy := make(map[T]struct{}, len(s.data))
-
-	// Fill it up
-	for v := range s.data {
-		copy[v] = struct{}{}
+	// maps.Clone hands back nil for a nil map, so make sure the copy is always usable
+	if s.data == nil {
+		return Set[T]{data: make(map[T]struct{}), display: s.display, pop_less: s.pop_less}
 	}
 
-	return Set[T]{data: copy}
+	return Set[T]{data: maps.Clone(s.data), display: s.display, pop_less: s.pop_less}
 }
 
 // Equals will return true if `s` and `t` are
@@ -175,43 +343,45 @@ func (s *Set[T]) Equals(t Set[T]) bool {
 
 // Union will create a new Set, and fill it with the union of `s` and `t`
 func (s *Set[T]) Union(t Set[T]) Set[T] {
-	// Figure out which is larger
-	s_is_larger := s.Len() > t.Len()
-
 	// First create a copy of either `s` or `t`. Pick whichever is largest to reduce
-	// allocations.
-	var result Set[T]
-	if s_is_larger {
-		result = s.Copy()
-	} else {
-		result = t.Copy()
-	}
-
-	// Iterate over the smaller set, and add all it's items to `result`
-	if s_is_larger {
-		for v := range t.data {
-			result.Add(v)
-		}
-	} else {
-		for v := range s.data {
-			result.Add(v)
-		}
+	// allocations, then copy the smaller set into it.
+	if s.Len() > t.Len() {
+		result := s.Copy()
+		maps.Copy(result.data, t.data)
+		return result
 	}
 
+	// The options always come from `s`, whichever set was copied
+	result := t.Copy()
+	maps.Copy(result.data, s.data)
+	result.take_options(*s)
 	return result
 }
 
 // UnionInPlace will add all the items in set `t` to set `s`
 func (s *Set[T]) UnionInPlace(t Set[T]) {
-	for v := range t.data {
-		s.Add(v)
-	}
+	maps.Copy(s.data, t.data)
 }
 
-// Intersection will create a new Set, and fill it with the intersection of `s` and `t`
+// Intersection will create a new Set, and fill it with the intersection of `s` and `t`.
+// The result is sized to hold the smaller of the two sets, as it can never be any
+// larger than that. See `IntersectionWithCapacity` to choose the size yourself.
 func (s *Set[T]) Intersection(t Set[T]) Set[T] {
+	size := s.Len()
+	if t.Len() < size {
+		size = t.Len()
+	}
+
+	return s.IntersectionWithCapacity(t, size)
+}
+
+// IntersectionWithCapacity is the same as `Intersection`, but the result is created
+// with room for `size` items. This is most useful when you expect the overlap to be
+// much smaller than either set.
+func (s *Set[T]) IntersectionWithCapacity(t Set[T], size int) Set[T] {
 	// Create an empty set result
-	result := NewSet([]T{})
+	result := NewSetWithCapacity([]T{}, size)
+	result.take_options(*s)
 
 	// Iterate over the smaller of the two sets, and add the item to `result` if it is
 	// in the larger of the two sets
@@ -241,6 +411,23 @@ func (s *Set[T]) IntersectionInPlace(t Set[T]) {
 	}
 }
 
+// IntersectionLen returns the number of items in both `s` and `t`, without building the
+// intersection
+func (s *Set[T]) IntersectionLen(t Set[T]) int {
+	small, large := s.data, t.data
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+
+	result := 0
+	for v := range small {
+		if _, ok := large[v]; ok {
+			result++
+		}
+	}
+	return result
+}
+
 // IsDisjoint will return true if the set has no elements in common with `t`. Sets are
 // disjoint if and only if their intersection is the empty set
 func (s *Set[T]) IsDisjoint(t Set[T]) bool {
@@ -262,8 +449,42 @@ func (s *Set[T]) IsDisjoint(t Set[T]) bool {
 	return true
 }
 
+// ArePairwiseDisjoint will return true if no item is in more than one of `sets`, such as
+// when checking that shard assignments really do partition a set of keys. Rather than
+// comparing every pair, each item is checked against one set of every item seen so far,
+// so the cost grows with the total number of items.
+func ArePairwiseDisjoint[T comparable](sets ...Set[T]) bool {
+	if len(sets) < 2 {
+		return true
+	}
+
+	// The last set never needs to be added, as nothing comes after it to check
+	total := 0
+	for _, t := range sets[:len(sets)-1] {
+		total += t.Len()
+	}
+	seen := make(map[T]struct{}, total)
+	for idx, t := range sets {
+		last := idx == len(sets)-1
+		for v := range t.data {
+			if _, ok := seen[v]; ok {
+				return false
+			}
+			if !last {
+				seen[v] = struct{}{}
+			}
+		}
+	}
+	return true
+}
+
 // IsSubsetOf tests whether every element in `s` is in `t`
 func (s *Set[T]) IsSubsetOf(t Set[T]) bool {
+	// Quick check that `s` doesn't have more elements than `t`
+	if s.Len() > t.Len() {
+		return false
+	}
+
 	// Iterate over `s`. If we find an item in `s` that is not in `t`, return false
 	for v := range s.data {
 		if !t.Contains(v) {
@@ -276,8 +497,8 @@ func (s *Set[T]) IsSubsetOf(t Set[T]) bool {
 // IsProperSubsetOf tests whether every element in `s` is in `t`, but that
 // `s.Equals(t) == false`
 func (s *Set[T]) IsProperSubsetOf(t Set[T]) bool {
-
-	// Quick check that `s` has fewer elements than `t`
+	// Quick check that `s` has fewer elements than `t`. If every element of `s` is then
+	// found in `t`, the two sets cannot be equal.
 	if s.Len() >= t.Len() {
 		return false
 	}
@@ -288,18 +509,16 @@ func (s *Set[T]) IsProperSubsetOf(t Set[T]) bool {
 			return false
 		}
 	}
-
-	// If the lengths are equal, we have just verified that the two sets are equal.
-	if s.Len() == t.Len() {
-		return false
-	} else {
-		return true
-	}
-
+	return true
 }
 
 // IsSuperSetOf tests whether every element in `t` is in `s`
 func (s *Set[T]) IsSuperSetOf(t Set[T]) bool {
+	// Quick check that `t` doesn't have more elements than `s`
+	if t.Len() > s.Len() {
+		return false
+	}
+
 	// Iterate over `t`. If we find an item in `t` that is not in `s`, return false
 	for v := range t.data {
 		if !s.Contains(v) {
@@ -312,6 +531,11 @@ func (s *Set[T]) IsSuperSetOf(t Set[T]) bool {
 // IsProperSuperSetOf tests whether every element in `t` is in `s`, but that
 // `s.Equals(t) == false`
 func (s *Set[T]) IsProperSuperSetOf(t Set[T]) bool {
+	// Quick check that `t` has fewer elements than `s`. If every element of `t` is then
+	// found in `s`, the two sets cannot be equal.
+	if t.Len() >= s.Len() {
+		return false
+	}
 
 	// Iterate over `t`. If we find an item in `t` that is not in `s`, return false
 	for v := range t.data {
@@ -319,14 +543,7 @@ func (s *Set[T]) IsProperSuperSetOf(t Set[T]) bool {
 			return false
 		}
 	}
-
-	// If the lengths are equal, we have just verified that the two sets are equal.
-	if s.Len() == t.Len() {
-		return false
-	} else {
-		return true
-	}
-
+	return true
 }
 
 // Difference returns a new set with elements in `s` that are not in `t`
@@ -350,10 +567,31 @@ func (s *Set[T]) DifferenceInPlace(t Set[T]) {
 	}
 }
 
-// SymmetricDifference returns a new set with elements in either `s` or `t`, but not both
+// DifferenceLen returns the number of items in `s` that are not in `t`, without building
+// the difference. It counts the overlap from whichever set is smaller, and takes that
+// from the length of `s`.
+func (s *Set[T]) DifferenceLen(t Set[T]) int {
+	return s.Len() - s.IntersectionLen(t)
+}
+
+// SymmetricDifference returns a new set with elements in either `s` or `t`, but not
+// both. The result is sized to hold the larger of the two sets. See
+// `SymmetricDifferenceWithCapacity` to choose the size yourself.
 func (s *Set[T]) SymmetricDifference(t Set[T]) Set[T] {
+	size := s.Len()
+	if t.Len() > size {
+		size = t.Len()
+	}
+
+	return s.SymmetricDifferenceWithCapacity(t, size)
+}
+
+// SymmetricDifferenceWithCapacity is the same as `SymmetricDifference`, but the result
+// is created with room for `size` items.
+func (s *Set[T]) SymmetricDifferenceWithCapacity(t Set[T], size int) Set[T] {
 	// Make an empty set to populate
-	result := NewSet([]T{})
+	result := NewSetWithCapacity([]T{}, size)
+	result.take_options(*s)
 
 	// The big question here is whether it's worth allocating a little to save a few checks
 	// For now, assume that it's best to just check everything, and store as little as
@@ -389,3 +627,53 @@ func (s *Set[T]) SymmetricDifferenceInPlace(t Set[T]) {
 	}
 
 }
+
+// UnionSlice will create a new Set, and fill it with the items of `s` and `items`,
+// without making a Set out of `items` first
+func (s *Set[T]) UnionSlice(items []T) Set[T] {
+	result := NewSetWithCapacity([]T{}, s.Len()+len(items))
+	result.take_options(*s)
+	maps.Copy(result.data, s.data)
+	for _, v := range items {
+		result.data[v] = struct{}{}
+	}
+	return result
+}
+
+// IntersectSlice will create a new Set, holding the items of `items` that are also in
+// `s`
+func (s *Set[T]) IntersectSlice(items []T) Set[T] {
+	result := NewSetWithCapacity([]T{}, min(s.Len(), len(items)))
+	result.take_options(*s)
+	for _, v := range items {
+		if s.Contains(v) {
+			result.data[v] = struct{}{}
+		}
+	}
+	return result
+}
+
+// DifferenceSlice returns a new set with elements in `s` that are not in `items`
+func (s *Set[T]) DifferenceSlice(items []T) Set[T] {
+	result := s.Copy()
+	for _, v := range items {
+		delete(result.data, v)
+	}
+	return result
+}
+
+// RetainSlice will remove any items from `s` that are not in `items`. It is the in
+// place version of `IntersectSlice`. Items are deleted from the set's own map, so a map
+// shared through `FromMap` or `UnsafeAsMap` stays attached.
+func (s *Set[T]) RetainSlice(items []T) {
+	// Items can't be looked up in a slice quickly, so gather them into a map first
+	keep := make(map[T]struct{}, len(items))
+	for _, v := range items {
+		keep[v] = struct{}{}
+	}
+	for v := range s.data {
+		if _, ok := keep[v]; !ok {
+			delete(s.data, v)
+		}
+	}
+}
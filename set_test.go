@@ -1,6 +1,7 @@
 package set
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -255,7 +256,7 @@ func TestRemove(t *testing.T) {
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
 			err := tC.s.Remove(tC.v)
-			if err != tC.want_err_value {
+			if !errors.Is(err, tC.want_err_value) {
 				t.Errorf("got error %v, want %v", err, tC.want_err_value)
 			}
 			if !tC.s.Equals(tC.want_set) {
@@ -327,6 +328,64 @@ func TestPop(t *testing.T) {
 	}
 }
 
+func TestPopOrder(t *testing.T) {
+	type job struct {
+		name     string
+		priority int
+	}
+	s := NewSet([]job{{"c", 3}, {"a", 1}, {"b", 2}})
+	s.SetPopOrder(func(x, y job) bool { return x.priority < y.priority })
+
+	// The copy keeps the order too
+	c := s.Copy()
+	for _, set := range []*Set[job]{&s, &c} {
+		got := []string{}
+		for !set.IsEmpty() {
+			j, err := set.Pop()
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			got = append(got, j.name)
+		}
+		if want := "a b c"; strings.Join(got, " ") != want {
+			t.Errorf("got %v; want %v", got, want)
+		}
+	}
+
+	smallest := NewSetPopSmallest([]string{"pear", "apple", "fig"})
+	if got, _ := smallest.Pop(); got != "apple" {
+		t.Errorf("got %v; want apple", got)
+	}
+
+	// A union takes the order of the receiver, whichever set is larger
+	ordered := NewSetPopSmallest([]int{5, 4, 3, 2, 1})
+	plain := NewSet([]int{10})
+	if u := plain.Union(ordered); u.pop_less != nil {
+		t.Errorf("a union of an unordered set should not take the pop order of the other")
+	}
+	u := ordered.Union(plain)
+	if got, _ := u.Pop(); got != 1 {
+		t.Errorf("got %v; want 1", got)
+	}
+
+	// Every other new set made from `ordered` keeps its order too
+	results := map[string]Set[int]{
+		"Intersection":        ordered.Intersection(NewSet([]int{1, 2, 3})),
+		"SymmetricDifference": ordered.SymmetricDifference(NewSet([]int{5, 6})),
+		"UnionSlice":          ordered.UnionSlice([]int{0}),
+		"IntersectSlice":      ordered.IntersectSlice([]int{3, 2}),
+	}
+	for name, r := range results {
+		want := 100
+		for _, v := range r.Slice() {
+			want = min(want, v)
+		}
+		if got, _ := r.Pop(); got != want {
+			t.Errorf("%s: got %v; want %v", name, got, want)
+		}
+	}
+}
+
 func TestClear(t *testing.T) {
 	s := NewSet([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
 	s.Clear()
@@ -335,6 +394,51 @@ func TestClear(t *testing.T) {
 	}
 }
 
+func TestCopy(t *testing.T) {
+	testCases := []struct {
+		desc string
+		s    Set[int]
+	}{
+		{
+			desc: "some items",
+			s:    NewSet([]int{1, 2, 3}),
+		},
+		{
+			desc: "empty",
+			s:    NewSet([]int{}),
+		},
+		{
+			desc: "zero value",
+			s:    Set[int]{},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got := tC.s.Copy()
+			if !got.Equals(tC.s) {
+				t.Errorf("got %v, want %v", got, tC.s)
+			}
+
+			// The copy should be usable, and independent of the original
+			got.Add(100)
+			if tC.s.Contains(100) {
+				t.Errorf("adding to the copy changed the original")
+			}
+		})
+	}
+}
+
+func BenchmarkCopy(b *testing.B) {
+	in := make([]int, 100_000)
+	for i := range in {
+		in[i] = i
+	}
+	s := NewSet(in)
+	for i := 0; i < b.N; i++ {
+		s.Copy()
+	}
+}
+
 func TestContains(t *testing.T) {
 	type Person struct {
 		Name string
@@ -463,6 +567,18 @@ func TestIntersection(t *testing.T) {
 	}
 }
 
+func TestIntersectionWithCapacity(t *testing.T) {
+	s1 := NewSet([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+	s2 := NewSet([]int{5, 6, 7, 8, 9, 10, 11, 12, 13, 14})
+	want := NewSet([]int{5, 6, 7, 8, 9, 10})
+
+	for _, size := range []int{0, 1, 100} {
+		if got := s1.IntersectionWithCapacity(s2, size); !got.Equals(want) {
+			t.Errorf("size %d: got %v, want %v", size, got, want)
+		}
+	}
+}
+
 func BenchmarkIntersectionString(b *testing.B) {
 	benchCases := []struct {
 		desc string
@@ -704,6 +820,26 @@ func BenchmarkIsSubsetInt(b *testing.B) {
 	}
 }
 
+func BenchmarkIsSubsetLargeOfSmall(b *testing.B) {
+	in := make([]int, 1_000_000)
+	for i := range in {
+		in[i] = i
+	}
+	large := NewSet(in)
+	small := NewSet([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+
+	b.Run("subset", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			large.IsSubsetOf(small)
+		}
+	})
+	b.Run("superset", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			small.IsSuperSetOf(large)
+		}
+	})
+}
+
 func TestIsProperSubset(t *testing.T) {
 	testCases := []struct {
 		desc string
@@ -1094,6 +1230,18 @@ func TestSymmetricDifference(t *testing.T) {
 	}
 }
 
+func TestSymmetricDifferenceWithCapacity(t *testing.T) {
+	s1 := NewSet([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+	s2 := NewSet([]int{5, 6, 7, 8, 9, 10, 11, 12, 13, 14})
+	want := NewSet([]int{1, 2, 3, 4, 11, 12, 13, 14})
+
+	for _, size := range []int{0, 1, 100} {
+		if got := s1.SymmetricDifferenceWithCapacity(s2, size); !got.Equals(want) {
+			t.Errorf("size %d: got %v, want %v", size, got, want)
+		}
+	}
+}
+
 func BenchmarkSymmetricDifference(b *testing.B) {
 	benchCases := []struct {
 		desc string
@@ -1264,3 +1412,291 @@ func TestFormat(t *testing.T) {
 		t.Errorf("saw %d ', '; wanted %d", counted_commas, expected_commas)
 	}
 }
+
+func TestSetFormatter(t *testing.T) {
+	type user struct {
+		id   int
+		name string
+	}
+	s := NewSet([]user{{id: 7, name: "Alice"}})
+	s.SetFormatter(func(u user) string { return fmt.Sprintf("user:%d", u.id) })
+
+	if got, want := s.String(), "{user:7}"; got != want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	// Derived sets keep the formatter
+	other := NewSet([]user{{id: 8, name: "Bob"}})
+	union := s.Union(other)
+	if got := union.String(); !strings.Contains(got, "user:8") {
+		t.Errorf("got %v; want the items printed by the formatter", got)
+	}
+	c := s.Copy()
+	if got, want := c.String(), "{user:7}"; got != want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	s.SetFormatter(nil)
+	if got, want := s.String(), "{{7 Alice}}"; got != want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestStringLimit(t *testing.T) {
+	data := make([]int, 0, 1234)
+	for i := 0; i < 1234; i++ {
+		data = append(data, i)
+	}
+	s := NewSet(data)
+	s.SetStringLimit(3)
+
+	got := s.String()
+	if want := ", … and 1,231 more}"; !strings.HasSuffix(got, want) {
+		t.Errorf("got %v; want it to end with %v", got, want)
+	}
+	if counted := strings.Count(got, ", "); counted != 3 {
+		t.Errorf("saw %d ', '; wanted 3", counted)
+	}
+
+	// A limit larger than the set prints everything
+	small := NewSet([]int{5})
+	small.SetStringLimit(10)
+	if got, want := small.String(), "{5}"; got != want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	// The package default applies to sets without their own limit
+	DefaultStringLimit = 1
+	defer func() { DefaultStringLimit = 0 }()
+	two := NewSet([]int{1, 2})
+	if got := two.String(); !strings.HasSuffix(got, "… and 1 more}") {
+		t.Errorf("got %v; want the default limit to apply", got)
+	}
+	two.SetStringLimit(-1)
+	if got := two.String(); strings.Contains(got, "more") {
+		t.Errorf("got %v; want a negative limit to print every item", got)
+	}
+
+	empty := NewSet([]int{})
+	if got, want := empty.String(), "{}"; got != want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestWithCommas(t *testing.T) {
+	testCases := []struct {
+		n    int
+		want string
+	}{
+		{n: 0, want: "0"},
+		{n: 999, want: "999"},
+		{n: 1000, want: "1,000"},
+		{n: 1234567, want: "1,234,567"},
+	}
+	for _, tC := range testCases {
+		if got := with_commas(tC.n); got != tC.want {
+			t.Errorf("got %v; want %v", got, tC.want)
+		}
+	}
+}
+
+func TestNotFoundError(t *testing.T) {
+	s := NewSet([]string{"a"})
+	err := s.Remove("missing-id")
+
+	var not_found *NotFoundError[string]
+	if !errors.As(err, &not_found) {
+		t.Fatalf("got error %v, want a *NotFoundError[string]", err)
+	}
+	if not_found.Item != "missing-id" {
+		t.Errorf("got item %v; want missing-id", not_found.Item)
+	}
+	if got, want := err.Error(), "element not found: missing-id"; got != want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestTryRemove(t *testing.T) {
+	s := NewSet([]int{1, 2})
+	testCases := []struct {
+		desc string
+		v    int
+		want bool
+	}{
+		{desc: "present", v: 1, want: true},
+		{desc: "already removed", v: 1, want: false},
+		{desc: "never there", v: 10, want: false},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := s.TryRemove(tC.v); got != tC.want {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+
+	want := NewSet([]int{2})
+	if !s.Equals(want) {
+		t.Errorf("got %v; want %v", s, want)
+	}
+}
+
+func TestSliceOperations(t *testing.T) {
+	s := NewSet([]int{1, 2, 3, 4, 5})
+	testCases := []struct {
+		desc  string
+		items []int
+	}{
+		{desc: "empty", items: []int{}},
+		{desc: "some overlap", items: []int{4, 5, 6, 7, 5}},
+		{desc: "subset", items: []int{1, 3}},
+		{desc: "disjoint", items: []int{10, 11}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			other := NewSet(tC.items)
+
+			if got, want := s.UnionSlice(tC.items), s.Union(other); !got.Equals(want) {
+				t.Errorf("UnionSlice: got %v; want %v", got, want)
+			}
+			if got, want := s.IntersectSlice(tC.items), s.Intersection(other); !got.Equals(want) {
+				t.Errorf("IntersectSlice: got %v; want %v", got, want)
+			}
+			if got, want := s.DifferenceSlice(tC.items), s.Difference(other); !got.Equals(want) {
+				t.Errorf("DifferenceSlice: got %v; want %v", got, want)
+			}
+			if s.Len() != 5 {
+				t.Errorf("the slice operations should leave `s` alone, got %v", s)
+			}
+
+			retained := s.Copy()
+			retained.RetainSlice(tC.items)
+			if want := s.Intersection(other); !retained.Equals(want) {
+				t.Errorf("RetainSlice: got %v; want %v", retained, want)
+			}
+		})
+	}
+}
+
+func TestCount(t *testing.T) {
+	s := NewSet([]int{1, 2, 3, 4, 5, 6})
+	testCases := []struct {
+		desc string
+		pred func(int) bool
+		want int
+	}{
+		{desc: "none", pred: func(i int) bool { return i > 10 }, want: 0},
+		{desc: "even", pred: func(i int) bool { return i%2 == 0 }, want: 3},
+		{desc: "all", pred: func(i int) bool { return true }, want: 6},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := s.Count(tC.pred); got != tC.want {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+
+	var empty Set[int]
+	if got := empty.Count(func(int) bool { return true }); got != 0 {
+		t.Errorf("got %v; want 0", got)
+	}
+}
+
+func TestCombinedLen(t *testing.T) {
+	testCases := []struct {
+		desc string
+		s1   []int
+		s2   []int
+	}{
+		{desc: "both empty", s1: []int{}, s2: []int{}},
+		{desc: "one empty", s1: []int{1, 2}, s2: []int{}},
+		{desc: "overlap", s1: []int{1, 2, 3, 4}, s2: []int{3, 4, 5}},
+		{desc: "subset", s1: []int{1, 2}, s2: []int{1, 2, 3, 4, 5}},
+		{desc: "disjoint", s1: []int{1, 2}, s2: []int{10, 20, 30}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s1, s2 := NewSet(tC.s1), NewSet(tC.s2)
+			intersection, difference := s1.Intersection(s2), s1.Difference(s2)
+			if got, want := s1.IntersectionLen(s2), intersection.Len(); got != want {
+				t.Errorf("IntersectionLen: got %v; want %v", got, want)
+			}
+			if got, want := s1.DifferenceLen(s2), difference.Len(); got != want {
+				t.Errorf("DifferenceLen: got %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkIntersectionLen(b *testing.B) {
+	in1 := make([]int, 10_000)
+	in2 := make([]int, 10_000)
+	for i := range in1 {
+		in1[i] = i
+		in2[i] = i + 5_000
+	}
+	s1, s2 := NewSet(in1), NewSet(in2)
+
+	b.Run("Intersection", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			r := s1.Intersection(s2)
+			_ = r.Len()
+		}
+	})
+	b.Run("IntersectionLen", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s1.IntersectionLen(s2)
+		}
+	})
+}
+
+func TestArePairwiseDisjoint(t *testing.T) {
+	testCases := []struct {
+		desc string
+		sets [][]int
+		want bool
+	}{
+		{desc: "no sets", sets: [][]int{}, want: true},
+		{desc: "one set", sets: [][]int{{1, 2}}, want: true},
+		{desc: "partition", sets: [][]int{{1, 2}, {3}, {}, {4, 5, 6}}, want: true},
+		{desc: "first and last overlap", sets: [][]int{{1, 2}, {3}, {4, 1}}, want: false},
+		{desc: "middle overlap", sets: [][]int{{1}, {2, 3}, {3, 4}, {5}}, want: false},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			sets := make([]Set[int], len(tC.sets))
+			for i, items := range tC.sets {
+				sets[i] = NewSet(items)
+			}
+			if got := ArePairwiseDisjoint(sets...); got != tC.want {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+}
+
+func TestAppendTo(t *testing.T) {
+	s := NewSet([]int{1, 2, 3})
+	buf := []int{100}
+	buf = s.AppendTo(buf)
+	if len(buf) != 4 || buf[0] != 100 {
+		t.Fatalf("got %v; want 100 followed by the items", buf)
+	}
+	if got := NewSet(buf[1:]); !got.Equals(s) {
+		t.Errorf("got %v; want %v", got, s)
+	}
+
+	// Reusing the buffer doesn't allocate
+	allocs := testing.AllocsPerRun(10, func() {
+		buf = s.AppendTo(buf[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("got %v allocations; want 0", allocs)
+	}
+	if got := NewSet(buf); !got.Equals(s) || len(buf) != 3 {
+		t.Errorf("got %v; want %v", buf, s)
+	}
+}
@@ -0,0 +1,109 @@
+// setflag lets a command line flag fill a `github.com/natemcintosh/set`. The flag takes
+// comma separated values, and may be given more than once, with every value ending up in
+// the same set:
+//
+//	tags := set.NewSet([]string{})
+//	flag.Var(setflag.Strings(&tags), "tag", "comma separated tags")
+//	// -tag a,b -tag c gives {a, b, c}
+//
+// Values also have a `Type` method, so they can be used with github.com/spf13/pflag.
+package setflag
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/natemcintosh/set"
+)
+
+// Value is a flag.Value that parses comma separated items into a set
+type Value[T comparable] struct {
+	set       *set.Set[T]
+	parse     func(string) (T, error)
+	type_name string
+
+	// allowed is nil if any value is accepted
+	allowed *set.Set[T]
+}
+
+// New will return a Value that adds items to `s`, using `parse` to turn each comma
+// separated part of the flag into an item. `type_name` is what pflag shows in the usage
+// message.
+func New[T comparable](s *set.Set[T], parse func(string) (T, error), type_name string) *Value[T] {
+	return &Value[T]{set: s, parse: parse, type_name: type_name}
+}
+
+// Strings will return a Value that adds strings to `s`
+func Strings(s *set.Set[string]) *Value[string] {
+	return New(s, func(v string) (string, error) { return v, nil }, "strings")
+}
+
+// Ints will return a Value that adds ints to `s`
+func Ints(s *set.Set[int]) *Value[int] {
+	return New(s, strconv.Atoi, "ints")
+}
+
+// WithAllowed makes the flag reject any item that is not in `allowed`
+func (v *Value[T]) WithAllowed(allowed set.Set[T]) *Value[T] {
+	v.allowed = &allowed
+	return v
+}
+
+// String returns the items in the set, sorted and comma separated
+func (v *Value[T]) String() string {
+	// The flag package calls this on a zero Value to find the default
+	if v == nil || v.set == nil {
+		return ""
+	}
+
+	parts := make([]string, 0, v.set.Len())
+	for _, item := range v.set.Slice() {
+		parts = append(parts, fmt.Sprint(item))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+// Set parses a comma separated list, and adds every item to the set. Whitespace around
+// each item is ignored, as are empty items. If any item fails to parse, or is not
+// allowed, nothing is added and an error is returned.
+func (v *Value[T]) Set(raw string) error {
+	items := make([]T, 0)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		item, err := v.parse(part)
+		if err != nil {
+			return fmt.Errorf("invalid value %q: %w", part, err)
+		}
+		if v.allowed != nil && !v.allowed.Contains(item) {
+			return fmt.Errorf("invalid value %q: must be one of %s", part, allowed_list(*v.allowed))
+		}
+		items = append(items, item)
+	}
+
+	for _, item := range items {
+		v.set.Add(item)
+	}
+	return nil
+}
+
+// Type returns the name of the item type, for pflag
+func (v *Value[T]) Type() string {
+	return v.type_name
+}
+
+// allowed_list formats the allowed values for an error message
+func allowed_list[T comparable](allowed set.Set[T]) string {
+	parts := make([]string, 0, allowed.Len())
+	for _, item := range allowed.Slice() {
+		parts = append(parts, fmt.Sprint(item))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
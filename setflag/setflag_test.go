@@ -0,0 +1,89 @@
+package setflag
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/natemcintosh/set"
+)
+
+func TestStrings(t *testing.T) {
+	tags := set.NewSet([]string{})
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(Strings(&tags), "tag", "comma separated tags")
+
+	if err := fs.Parse([]string{"-tag", "a, b", "-tag", "c,,a"}); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	want := set.NewSet([]string{"a", "b", "c"})
+	if !tags.Equals(want) {
+		t.Errorf("got %v; want %v", tags, want)
+	}
+}
+
+func TestInts(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		in       string
+		want     set.Set[int]
+		want_err bool
+	}{
+		{
+			desc: "valid",
+			in:   "1,2,3,2",
+			want: set.NewSet([]int{1, 2, 3}),
+		},
+		{
+			desc:     "not a number",
+			in:       "1,two",
+			want:     set.NewSet([]int{}),
+			want_err: true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got := set.NewSet([]int{})
+			err := Ints(&got).Set(tC.in)
+			if (err != nil) != tC.want_err {
+				t.Errorf("got error %v, want error %v", err, tC.want_err)
+			}
+			if !got.Equals(tC.want) {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+}
+
+func TestWithAllowed(t *testing.T) {
+	levels := set.NewSet([]string{})
+	v := Strings(&levels).WithAllowed(set.NewSet([]string{"debug", "info", "warn"}))
+
+	if err := v.Set("debug,info"); err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+	if err := v.Set("warn,trace"); err == nil {
+		t.Errorf("trace should not be allowed")
+	}
+	// Nothing from the failed call should have been added
+	want := set.NewSet([]string{"debug", "info"})
+	if !levels.Equals(want) {
+		t.Errorf("got %v; want %v", levels, want)
+	}
+	if got := v.String(); got != "debug,info" {
+		t.Errorf("got %q; want %q", got, "debug,info")
+	}
+}
+
+func TestUsage(t *testing.T) {
+	// The flag package calls String on a zero Value while printing usage
+	ids := set.NewSet([]int{})
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(Ints(&ids), "id", "ids to keep")
+	fs.PrintDefaults()
+
+	if got := Ints(&ids).Type(); got != "ints" {
+		t.Errorf("got %q; want %q", got, "ints")
+	}
+}
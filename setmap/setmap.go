@@ -0,0 +1,182 @@
+// setmap maps keys to sets of values. It is useful for building adjacency lists, indexes,
+// and any other one-to-many relationship on top of `github.com/natemcintosh/set`.
+package setmap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/natemcintosh/set"
+)
+
+var (
+	// This error is returned when you try to remove a value from a key that doesn't
+	// exist, or from a key whose set doesn't hold that value. It is
+	// set.ErrElementNotFound.
+	ErrElementNotFound = set.ErrElementNotFound
+)
+
+type SetMap[K comparable, V comparable] struct {
+	data map[K]set.Set[V]
+}
+
+// NewSetMap will return an empty SetMap
+func NewSetMap[K comparable, V comparable]() SetMap[K, V] {
+	return SetMap[K, V]{data: make(map[K]set.Set[V])}
+}
+
+func (m SetMap[K, V]) String() string {
+	var b strings.Builder
+	last_index := len(m.data) - 1
+	index := -1
+	b.WriteString("{")
+	for k, values := range m.data {
+		index += 1
+
+		if index < last_index {
+			b.WriteString(fmt.Sprintf("%v: %v, ", k, values))
+		} else {
+			b.WriteString(fmt.Sprintf("%v: %v", k, values))
+		}
+	}
+	b.WriteString("}")
+
+	return b.String()
+}
+
+// Add will add `v` to the set of values for `k`, creating the set if needed
+func (m *SetMap[K, V]) Add(k K, v V) {
+	values, ok := m.data[k]
+	if !ok {
+		values = set.NewSet([]V{})
+		m.data[k] = values
+	}
+	values.Add(v)
+}
+
+// Remove removes `v` from the set of values for `k`. If it doesn't exist, returns a
+// *set.NotFoundError holding `v`, which matches ErrElementNotFound. Keys left with no
+// values are removed from the map.
+func (m *SetMap[K, V]) Remove(k K, v V) error {
+	values, ok := m.data[k]
+	if !ok {
+		return &set.NotFoundError[V]{Item: v}
+	}
+	if err := values.Remove(v); err != nil {
+		return err
+	}
+	if values.IsEmpty() {
+		delete(m.data, k)
+	}
+	return nil
+}
+
+// RemoveKey removes `k` and all of its values. If it doesn't exist, it is ignored
+func (m *SetMap[K, V]) RemoveKey(k K) {
+	delete(m.data, k)
+}
+
+// Contains will return true if `v` is in the set of values for `k`
+func (m *SetMap[K, V]) Contains(k K, v V) bool {
+	values, ok := m.data[k]
+	return ok && values.Contains(v)
+}
+
+// HasKey will return true if `k` has at least one value
+func (m *SetMap[K, V]) HasKey(k K) bool {
+	_, ok := m.data[k]
+	return ok
+}
+
+// Len returns the number of keys
+func (m *SetMap[K, V]) Len() int {
+	return len(m.data)
+}
+
+// Keys will return a set of all the keys
+func (m *SetMap[K, V]) Keys() set.Set[K] {
+	result := set.NewSetWithCapacity([]K{}, len(m.data))
+	for k := range m.data {
+		result.Add(k)
+	}
+	return result
+}
+
+// ValuesFor will return a copy of the set of values for `k`. If `k` doesn't exist, the
+// set is empty
+func (m *SetMap[K, V]) ValuesFor(k K) set.Set[V] {
+	values, ok := m.data[k]
+	if !ok {
+		return set.NewSet([]V{})
+	}
+	return values.Copy()
+}
+
+// Invert will create a new SetMap where each value maps to the set of keys that held it
+func (m *SetMap[K, V]) Invert() SetMap[V, K] {
+	result := NewSetMap[V, K]()
+	for k, values := range m.data {
+		for _, v := range values.Slice() {
+			result.Add(v, k)
+		}
+	}
+	return result
+}
+
+// Union will return a new set of every value held by any of the keys. If no keys are
+// given, every key in the map is used
+func (m *SetMap[K, V]) Union(keys ...K) set.Set[V] {
+	result := set.NewSet([]V{})
+	if len(keys) == 0 {
+		for _, values := range m.data {
+			result.UnionInPlace(values)
+		}
+		return result
+	}
+
+	for _, k := range keys {
+		if values, ok := m.data[k]; ok {
+			result.UnionInPlace(values)
+		}
+	}
+	return result
+}
+
+// Intersection will return a new set of the values held by all of the keys. If no keys
+// are given, every key in the map is used
+func (m *SetMap[K, V]) Intersection(keys ...K) set.Set[V] {
+	if len(keys) == 0 {
+		keys = make([]K, 0, len(m.data))
+		for k := range m.data {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return set.NewSet([]V{})
+	}
+
+	// Start from the smallest set, as the result can be no bigger than it
+	smallest_idx := -1
+	smallest_len := 0
+	for idx, k := range keys {
+		values, ok := m.data[k]
+		if !ok {
+			// A missing key has no values, so nothing is common to all keys
+			return set.NewSet([]V{})
+		}
+		if smallest_idx == -1 || values.Len() < smallest_len {
+			smallest_idx = idx
+			smallest_len = values.Len()
+		}
+	}
+
+	smallest := m.data[keys[smallest_idx]]
+	result := smallest.Copy()
+	for idx, k := range keys {
+		if idx == smallest_idx {
+			continue
+		}
+		result.IntersectionInPlace(m.data[k])
+	}
+	return result
+}
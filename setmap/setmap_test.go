@@ -0,0 +1,146 @@
+package setmap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/natemcintosh/set"
+)
+
+func graph() SetMap[string, string] {
+	m := NewSetMap[string, string]()
+	m.Add("a", "b")
+	m.Add("a", "c")
+	m.Add("b", "c")
+	m.Add("b", "d")
+	m.Add("c", "d")
+	return m
+}
+
+func TestAddContains(t *testing.T) {
+	m := graph()
+	testCases := []struct {
+		desc string
+		k    string
+		v    string
+		want bool
+	}{
+		{
+			desc: "edge exists",
+			k:    "a",
+			v:    "b",
+			want: true,
+		},
+		{
+			desc: "edge does not exist",
+			k:    "a",
+			v:    "d",
+			want: false,
+		},
+		{
+			desc: "key does not exist",
+			k:    "d",
+			v:    "a",
+			want: false,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := m.Contains(tC.k, tC.v); got != tC.want {
+				t.Errorf("got %v, want %v", got, tC.want)
+			}
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	m := graph()
+	if err := m.Remove("c", "d"); err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+	if m.HasKey("c") {
+		t.Errorf("key c should be removed once it has no values")
+	}
+	if err := m.Remove("c", "d"); !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrElementNotFound)
+	}
+
+	// The missing value comes back with the error
+	err := m.Remove("a", "z")
+	var not_found *set.NotFoundError[string]
+	if !errors.As(err, &not_found) || not_found.Item != "z" {
+		t.Errorf("got error %v, want a *set.NotFoundError for z", err)
+	}
+}
+
+func TestKeysValuesFor(t *testing.T) {
+	m := graph()
+	want_keys := set.NewSet([]string{"a", "b", "c"})
+	if got := m.Keys(); !got.Equals(want_keys) {
+		t.Errorf("got %v; want %v", got, want_keys)
+	}
+
+	values := m.ValuesFor("b")
+	want_values := set.NewSet([]string{"c", "d"})
+	if !values.Equals(want_values) {
+		t.Errorf("got %v; want %v", values, want_values)
+	}
+
+	// Changing the returned set should not change the map
+	values.Add("z")
+	if m.Contains("b", "z") {
+		t.Errorf("ValuesFor should return a copy")
+	}
+}
+
+func TestInvert(t *testing.T) {
+	m := graph()
+	inverted := m.Invert()
+
+	want := set.NewSet([]string{"b", "c"})
+	if got := inverted.ValuesFor("d"); !got.Equals(want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+	if inverted.HasKey("a") {
+		t.Errorf("nothing points to a, so it should not be a key")
+	}
+}
+
+func TestUnionIntersection(t *testing.T) {
+	m := graph()
+	testCases := []struct {
+		desc              string
+		keys              []string
+		want_union        set.Set[string]
+		want_intersection set.Set[string]
+	}{
+		{
+			desc:              "all keys",
+			keys:              []string{},
+			want_union:        set.NewSet([]string{"b", "c", "d"}),
+			want_intersection: set.NewSet([]string{}),
+		},
+		{
+			desc:              "two keys",
+			keys:              []string{"a", "b"},
+			want_union:        set.NewSet([]string{"b", "c", "d"}),
+			want_intersection: set.NewSet([]string{"c"}),
+		},
+		{
+			desc:              "missing key",
+			keys:              []string{"a", "z"},
+			want_union:        set.NewSet([]string{"b", "c"}),
+			want_intersection: set.NewSet([]string{}),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := m.Union(tC.keys...); !got.Equals(tC.want_union) {
+				t.Errorf("union: got %v; want %v", got, tC.want_union)
+			}
+			if got := m.Intersection(tC.keys...); !got.Equals(tC.want_intersection) {
+				t.Errorf("intersection: got %v; want %v", got, tC.want_intersection)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+// setmetrics wraps any `set.Interface` so that every call is counted, for services that
+// need to see how a set is being used. Counts go to small `Counter` and `Gauge`
+// interfaces, which `*expvar.Int` already satisfies, and which Prometheus metrics can
+// satisfy through `CounterFunc` and `GaugeFunc`:
+//
+//	s := set.NewSet([]string{})
+//	m := setmetrics.Wrap[string](&s, setmetrics.Expvar("sessions"))
+//	m.Add("abc") // counted in /debug/vars under "sessions"
+//
+//	hits := prometheus.NewCounter(...)
+//	m = setmetrics.Wrap[string](&s, setmetrics.Metrics{
+//		Hits: setmetrics.CounterFunc(func(d int64) { hits.Add(float64(d)) }),
+//	})
+package setmetrics
+
+import (
+	"expvar"
+	"math/bits"
+	"sync/atomic"
+
+	"github.com/natemcintosh/set"
+)
+
+// Counter is a count that only goes up
+type Counter interface {
+	Add(delta int64)
+}
+
+// Gauge is a value that is set to its latest reading
+type Gauge interface {
+	Set(value int64)
+}
+
+// CounterFunc turns a function into a Counter
+type CounterFunc func(delta int64)
+
+func (f CounterFunc) Add(delta int64) { f(delta) }
+
+// GaugeFunc turns a function into a Gauge
+type GaugeFunc func(value int64)
+
+func (f GaugeFunc) Set(value int64) { f(value) }
+
+// Metrics is where a wrapped set reports. Any field left nil is not reported.
+type Metrics struct {
+	// Adds, Removes, Discards, Pops and Clears count calls to each method, whether or
+	// not the call changed the set
+	Adds, Removes, Discards, Pops, Clears Counter
+	// Hits and Misses count calls to Contains that found and did not find the item
+	Hits, Misses Counter
+	// Size is set to the length of the set after every call that may change it
+	Size Gauge
+	// Growths counts each time the set grows past a power of two it has never reached
+	// before, which roughly tracks when the underlying storage has to grow
+	Growths Counter
+}
+
+// Expvar will publish a map of counters named `name` with expvar, and return Metrics
+// that report to it, under the keys "adds", "removes", "discards", "pops", "clears",
+// "hits", "misses", "size" and "growths". As with `expvar.NewMap`, it panics if `name`
+// is already published.
+func Expvar(name string) Metrics {
+	published := expvar.NewMap(name)
+	new_int := func(key string) *expvar.Int {
+		v := new(expvar.Int)
+		published.Set(key, v)
+		return v
+	}
+	return Metrics{
+		Adds:     new_int("adds"),
+		Removes:  new_int("removes"),
+		Discards: new_int("discards"),
+		Pops:     new_int("pops"),
+		Clears:   new_int("clears"),
+		Hits:     new_int("hits"),
+		Misses:   new_int("misses"),
+		Size:     new_int("size"),
+		Growths:  new_int("growths"),
+	}
+}
+
+// Set is a set that reports its use to Metrics. It is as safe for concurrent use as the
+// set it wraps.
+type Set[T comparable] struct {
+	inner   set.Interface[T]
+	metrics Metrics
+
+	// peak is the bit length of the largest size seen, so a growth is a new peak
+	peak atomic.Int64
+}
+
+var _ set.Interface[int] = (*Set[int])(nil)
+
+// Wrap will return a Set that passes every call through to `s`, and reports it to `m`
+func Wrap[T comparable](s set.Interface[T], m Metrics) *Set[T] {
+	result := &Set[T]{inner: s, metrics: m}
+	result.peak.Store(int64(bits.Len(uint(s.Len()))))
+	if m.Size != nil {
+		m.Size.Set(int64(s.Len()))
+	}
+	return result
+}
+
+// Unwrap returns the set being wrapped, so it can be used without being counted
+func (s *Set[T]) Unwrap() set.Interface[T] {
+	return s.inner
+}
+
+// count adds one to `c` if it is not nil
+func count(c Counter) {
+	if c != nil {
+		c.Add(1)
+	}
+}
+
+// observe reports the size of the set, and a growth if it is a new peak
+func (s *Set[T]) observe() {
+	n := s.inner.Len()
+	if s.metrics.Size != nil {
+		s.metrics.Size.Set(int64(n))
+	}
+	length := int64(bits.Len(uint(n)))
+	for {
+		peak := s.peak.Load()
+		if length <= peak {
+			return
+		}
+		if s.peak.CompareAndSwap(peak, length) {
+			if s.metrics.Growths != nil {
+				s.metrics.Growths.Add(length - peak)
+			}
+			return
+		}
+	}
+}
+
+// Contains will return true if the set contains the item, and count a hit or a miss
+func (s *Set[T]) Contains(item T) bool {
+	found := s.inner.Contains(item)
+	if found {
+		count(s.metrics.Hits)
+	} else {
+		count(s.metrics.Misses)
+	}
+	return found
+}
+
+// Add will add an item to the set
+func (s *Set[T]) Add(item T) {
+	s.inner.Add(item)
+	count(s.metrics.Adds)
+	s.observe()
+}
+
+// Remove will remove an item from the set, returning the wrapped set's error if it was
+// not there
+func (s *Set[T]) Remove(item T) error {
+	err := s.inner.Remove(item)
+	count(s.metrics.Removes)
+	s.observe()
+	return err
+}
+
+// Discard will remove an item from the set if it is there
+func (s *Set[T]) Discard(item T) {
+	s.inner.Discard(item)
+	count(s.metrics.Discards)
+	s.observe()
+}
+
+// Pop will remove and return an item, as the wrapped set picks it
+func (s *Set[T]) Pop() (T, error) {
+	item, err := s.inner.Pop()
+	count(s.metrics.Pops)
+	s.observe()
+	return item, err
+}
+
+// Clear will remove all items from the set
+func (s *Set[T]) Clear() {
+	s.inner.Clear()
+	count(s.metrics.Clears)
+	s.observe()
+}
+
+// Len returns the number of items in the set
+func (s *Set[T]) Len() int {
+	return s.inner.Len()
+}
+
+// IsEmpty returns true if the set is empty
+func (s *Set[T]) IsEmpty() bool {
+	return s.inner.IsEmpty()
+}
+
+// Slice will return all the items in the set as a slice
+func (s *Set[T]) Slice() []T {
+	return s.inner.Slice()
+}
+
+// Iterate calls `f` on every item in the set, stopping early if `f` returns false
+func (s *Set[T]) Iterate(f func(item T) bool) {
+	s.inner.Iterate(f)
+}
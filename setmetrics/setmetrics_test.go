@@ -0,0 +1,111 @@
+package setmetrics
+
+import (
+	"expvar"
+	"testing"
+
+	"github.com/natemcintosh/set"
+)
+
+func TestWrap(t *testing.T) {
+	var adds, removes, discards, pops, clears, hits, misses, growths expvar.Int
+	var size expvar.Int
+	s := set.NewSet([]int{1})
+	m := Wrap[int](&s, Metrics{
+		Adds: &adds, Removes: &removes, Discards: &discards, Pops: &pops, Clears: &clears,
+		Hits: &hits, Misses: &misses, Size: &size, Growths: &growths,
+	})
+	if size.Value() != 1 {
+		t.Errorf("Wrap should report the starting size, got %d", size.Value())
+	}
+
+	for i := 2; i <= 5; i++ {
+		m.Add(i)
+	}
+	m.Add(5)
+	m.Contains(1)
+	m.Contains(2)
+	m.Contains(100)
+	m.Remove(1)
+	m.Remove(1)
+	m.Discard(2)
+	m.Pop()
+	m.Clear()
+
+	testCases := []struct {
+		desc string
+		got  *expvar.Int
+		want int64
+	}{
+		{desc: "adds", got: &adds, want: 5},
+		{desc: "removes", got: &removes, want: 2},
+		{desc: "discards", got: &discards, want: 1},
+		{desc: "pops", got: &pops, want: 1},
+		{desc: "clears", got: &clears, want: 1},
+		{desc: "hits", got: &hits, want: 2},
+		{desc: "misses", got: &misses, want: 1},
+		{desc: "size", got: &size, want: 0},
+		// From 1 item to 5 passes 2 and 4
+		{desc: "growths", got: &growths, want: 2},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := tC.got.Value(); got != tC.want {
+				t.Errorf("got %d; want %d", got, tC.want)
+			}
+		})
+	}
+
+	// Growing back to a size already reached is not a new growth
+	for i := 0; i < 5; i++ {
+		m.Add(i)
+	}
+	if growths.Value() != 2 {
+		t.Errorf("got %d growths; want 2", growths.Value())
+	}
+}
+
+func TestNilMetrics(t *testing.T) {
+	s := set.NewSet([]string{})
+	m := Wrap[string](&s, Metrics{})
+	m.Add("a")
+	if !m.Contains("a") || m.Len() != 1 {
+		t.Errorf("got %v; want {a}", m.Slice())
+	}
+	if err := m.Remove("b"); err == nil {
+		t.Errorf("removing a missing item should pass on the wrapped set's error")
+	}
+	if m.Unwrap() != set.Interface[string](&s) {
+		t.Errorf("Unwrap should return the wrapped set")
+	}
+}
+
+func TestFuncs(t *testing.T) {
+	var hits float64
+	var size float64
+	s := set.NewSet([]int{})
+	m := Wrap[int](&s, Metrics{
+		Hits: CounterFunc(func(d int64) { hits += float64(d) }),
+		Size: GaugeFunc(func(v int64) { size = float64(v) }),
+	})
+	m.Add(7)
+	m.Contains(7)
+	if hits != 1 || size != 1 {
+		t.Errorf("got hits %v and size %v; want 1 and 1", hits, size)
+	}
+}
+
+func TestExpvar(t *testing.T) {
+	s := set.NewSet([]int{})
+	m := Wrap[int](&s, Expvar("setmetrics_test"))
+	m.Add(1)
+	m.Contains(2)
+
+	published := expvar.Get("setmetrics_test").(*expvar.Map)
+	if got := published.Get("adds").String(); got != "1" {
+		t.Errorf("adds: got %s; want 1", got)
+	}
+	if got := published.Get("misses").String(); got != "1" {
+		t.Errorf("misses: got %s; want 1", got)
+	}
+}
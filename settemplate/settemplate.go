@@ -0,0 +1,58 @@
+// settemplate provides text/template functions for working with sets of one element
+// type. Register them with
+//
+//	tmpl := template.New("report").Funcs(settemplate.FuncMap[string]())
+//
+// and then use them inside the template:
+//
+//	{{ if hasElement .Admins .User }}admin{{ end }}
+//	{{ range sortedList (intersect .Wanted .Available) }}{{ . }} {{ end }}
+//
+// The map can be converted to an html/template.FuncMap as well.
+package settemplate
+
+import (
+	"text/template"
+
+	"github.com/natemcintosh/set"
+	"golang.org/x/exp/constraints"
+)
+
+// FuncMap returns the template functions for sets of `T`:
+//
+//   - setOf: builds a set from its arguments
+//   - hasElement: true if the set holds the item
+//   - setLen: the number of items in the set
+//   - union, intersect, difference, symmetricDifference: the set algebra, returning a
+//     new set
+//   - isSubset: true if every item of the first set is in the second
+//   - sortedList: the items of the set as an ascending slice, ready for range
+func FuncMap[T constraints.Ordered]() template.FuncMap {
+	return template.FuncMap{
+		"setOf": func(items ...T) set.Set[T] {
+			return set.NewSet(items)
+		},
+		"hasElement": func(s set.Set[T], item T) bool {
+			return s.Contains(item)
+		},
+		"setLen": func(s set.Set[T]) int {
+			return s.Len()
+		},
+		"union": func(s, t set.Set[T]) set.Set[T] {
+			return s.Union(t)
+		},
+		"intersect": func(s, t set.Set[T]) set.Set[T] {
+			return s.Intersection(t)
+		},
+		"difference": func(s, t set.Set[T]) set.Set[T] {
+			return s.Difference(t)
+		},
+		"symmetricDifference": func(s, t set.Set[T]) set.Set[T] {
+			return s.SymmetricDifference(t)
+		},
+		"isSubset": func(s, t set.Set[T]) bool {
+			return s.IsSubsetOf(t)
+		},
+		"sortedList": set.SortedSlice[T],
+	}
+}
@@ -0,0 +1,65 @@
+package settemplate
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/natemcintosh/set"
+)
+
+func TestFuncMap(t *testing.T) {
+	data := struct {
+		Wanted    set.Set[string]
+		Available set.Set[string]
+		User      string
+	}{
+		Wanted:    set.NewSet([]string{"b", "a", "c"}),
+		Available: set.NewSet([]string{"c", "a", "d"}),
+		User:      "d",
+	}
+
+	testCases := []struct {
+		desc string
+		tmpl string
+		want string
+	}{
+		{
+			desc: "hasElement",
+			tmpl: `{{ hasElement .Available .User }} {{ hasElement .Wanted .User }}`,
+			want: "true false",
+		},
+		{
+			desc: "sortedList of intersect",
+			tmpl: `{{ range sortedList (intersect .Wanted .Available) }}{{ . }} {{ end }}`,
+			want: "a c ",
+		},
+		{
+			desc: "union",
+			tmpl: `{{ sortedList (union .Wanted .Available) }}`,
+			want: "[a b c d]",
+		},
+		{
+			desc: "difference",
+			tmpl: `{{ sortedList (difference .Wanted .Available) }}`,
+			want: "[b]",
+		},
+		{
+			desc: "setOf and isSubset",
+			tmpl: `{{ isSubset (setOf "a" "b") .Wanted }} {{ setLen (setOf "a" "a") }}`,
+			want: "true 1",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			tmpl := template.Must(template.New(tC.desc).Funcs(FuncMap[string]()).Parse(tC.tmpl))
+			var b strings.Builder
+			if err := tmpl.Execute(&b, data); err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if got := b.String(); got != tC.want {
+				t.Errorf("got %q; want %q", got, tC.want)
+			}
+		})
+	}
+}
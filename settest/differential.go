@@ -0,0 +1,172 @@
+package settest
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/natemcintosh/set"
+)
+
+// Runner checks a candidate set against a reference by running the same random
+// operations on both, and comparing every answer. It is made by `Differential`, and its
+// fields can be changed before calling `Run`.
+type Runner struct {
+	ref, candidate func() set.Interface[int]
+
+	// Runs is how many fresh pairs of sets are tried. Defaults to 20.
+	Runs int
+	// Steps is how many operations are run on each pair. Defaults to 500.
+	Steps int
+	// Values is the range the items are drawn from. A narrow range means items are
+	// often added twice or removed when missing. Defaults to [-200, 200], which crosses
+	// several 64 bit word boundaries either side of zero.
+	Values Range
+	// Seed seeds the random operations, so a failure can be replayed. Defaults to 1.
+	Seed int64
+}
+
+// Differential will return a Runner that checks sets made by `candidate` against sets
+// made by `ref`, such as a new backend against `set.Set`. Both functions must return a
+// new, empty set each time they are called.
+//
+//	err := settest.Differential(
+//		func() set.Interface[int] { s := set.NewSet([]int{}); return &s },
+//		func() set.Interface[int] { return mybackend.New() },
+//	).Run()
+func Differential(ref, candidate func() set.Interface[int]) *Runner {
+	return &Runner{ref: ref, candidate: candidate}
+}
+
+// history keeps the last few operations run, to show what led up to a failure
+type history struct {
+	ops []string
+}
+
+func (h *history) add(format string, args ...any) {
+	const keep = 10
+	if len(h.ops) == keep {
+		h.ops = h.ops[1:]
+	}
+	h.ops = append(h.ops, fmt.Sprintf(format, args...))
+}
+
+func (h *history) String() string {
+	return strings.Join(h.ops, ", ")
+}
+
+// Run carries out the random operations, and returns an error describing the first
+// place the candidate and reference disagree, or nil if they never do. The error gives
+// the seed, run and step, and the last few operations before it.
+func (r *Runner) Run() error {
+	runs, steps, values, seed := r.Runs, r.Steps, r.Values, r.Seed
+	if runs == 0 {
+		runs = 20
+	}
+	if steps == 0 {
+		steps = 500
+	}
+	if values == (Range{}) {
+		values = Range{-200, 200}
+	}
+	if seed == 0 {
+		seed = 1
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	for run := 0; run < runs; run++ {
+		ref, cand := r.ref(), r.candidate()
+		var h history
+		for step := 0; step < steps; step++ {
+			if err := r.step(rng, values, ref, cand, &h); err != nil {
+				return fmt.Errorf("settest: seed %d, run %d, step %d: %w (after %v)", seed, run, step, err, &h)
+			}
+		}
+		if err := same_items(ref, cand); err != nil {
+			return fmt.Errorf("settest: seed %d, run %d, at the end: %w (after %v)", seed, run, err, &h)
+		}
+	}
+	return nil
+}
+
+// step runs one random operation on both sets, and checks they agree on it and on
+// their length afterwards
+func (r *Runner) step(
+	rng *rand.Rand,
+	values Range,
+	ref, cand set.Interface[int],
+	h *history,
+) error {
+	v := values.pick(rng)
+	switch op := rng.Intn(100); {
+	case op < 40:
+		h.add("Add(%d)", v)
+		ref.Add(v)
+		cand.Add(v)
+	case op < 55:
+		h.add("Remove(%d)", v)
+		ref_err, cand_err := ref.Remove(v), cand.Remove(v)
+		if (ref_err == nil) != (cand_err == nil) {
+			return fmt.Errorf("Remove(%d) returned error %v, want %v", v, cand_err, ref_err)
+		}
+	case op < 65:
+		h.add("Discard(%d)", v)
+		ref.Discard(v)
+		cand.Discard(v)
+	case op < 90:
+		h.add("Contains(%d)", v)
+		if got, want := cand.Contains(v), ref.Contains(v); got != want {
+			return fmt.Errorf("Contains(%d) returned %v, want %v", v, got, want)
+		}
+	case op < 97:
+		// Pop may take any item, so take the same one out of the reference
+		h.add("Pop()")
+		popped, err := cand.Pop()
+		if (err == nil) == ref.IsEmpty() {
+			return fmt.Errorf("Pop returned error %v with %d items", err, ref.Len())
+		}
+		if err == nil && ref.Remove(popped) != nil {
+			return fmt.Errorf("Pop returned %d, which is not in the set", popped)
+		}
+	case op < 98:
+		h.add("Clear()")
+		ref.Clear()
+		cand.Clear()
+	default:
+		h.add("compare items")
+		if err := same_items(ref, cand); err != nil {
+			return err
+		}
+	}
+
+	if got, want := cand.Len(), ref.Len(); got != want {
+		return fmt.Errorf("Len returned %d, want %d", got, want)
+	}
+	if got, want := cand.IsEmpty(), ref.IsEmpty(); got != want {
+		return fmt.Errorf("IsEmpty returned %v, want %v", got, want)
+	}
+	return nil
+}
+
+// same_items checks that `Slice` and `Iterate` give the same items from both sets
+func same_items(ref, cand set.Interface[int]) error {
+	want := set.NewSet(ref.Slice())
+
+	slice := cand.Slice()
+	got := set.NewSet(slice)
+	if len(slice) != want.Len() || !got.Equals(want) {
+		return fmt.Errorf("Slice returned %v, %s", slice, set.DiffString(got, want))
+	}
+
+	iterated := set.NewSet([]int{})
+	count := 0
+	cand.Iterate(func(item int) bool {
+		iterated.Add(item)
+		count++
+		return true
+	})
+	if count != want.Len() || !iterated.Equals(want) {
+		return fmt.Errorf("Iterate visited %d items, %s", count, set.DiffString(iterated, want))
+	}
+	return nil
+}
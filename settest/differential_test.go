@@ -0,0 +1,62 @@
+package settest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/natemcintosh/set"
+	"github.com/natemcintosh/set/bitset"
+)
+
+func new_hash() set.Interface[int] {
+	s := set.NewSet([]int{})
+	return &s
+}
+
+// forgetful is a broken set that loses every item above 100 that is added to it
+type forgetful struct {
+	set.Set[int]
+}
+
+func (f *forgetful) Add(item int) {
+	if item <= 100 {
+		f.Set.Add(item)
+	}
+}
+
+func TestDifferential(t *testing.T) {
+	bits := func() set.Interface[int] {
+		s := bitset.NewSet([]int{})
+		return &s
+	}
+	if err := Differential(new_hash, bits).Run(); err != nil {
+		t.Errorf("bitset should match the hash set, got %v", err)
+	}
+
+	broken := func() set.Interface[int] {
+		return &forgetful{Set: set.NewSet([]int{})}
+	}
+	err := Differential(new_hash, broken).Run()
+	if err == nil {
+		t.Fatalf("the broken set should be caught")
+	}
+	if !strings.Contains(err.Error(), "seed 1") {
+		t.Errorf("got %q; want the error to name the seed", err)
+	}
+}
+
+func TestDifferentialOptions(t *testing.T) {
+	calls := 0
+	counting := func() set.Interface[int] {
+		calls++
+		return new_hash()
+	}
+	r := Differential(new_hash, counting)
+	r.Runs, r.Steps, r.Values, r.Seed = 3, 10, Range{0, 5}, 42
+	if err := r.Run(); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if calls != 3 {
+		t.Errorf("got %d sets made; want 3", calls)
+	}
+}
@@ -0,0 +1,101 @@
+// settest makes random sets for property-based tests and fuzzers of code built on
+// `github.com/natemcintosh/set`. Every generator takes its own `*rand.Rand`, so a failing
+// case can be made again from the seed.
+package settest
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	"github.com/natemcintosh/set"
+	"github.com/natemcintosh/set/bitset"
+)
+
+// Range is the integers from Lo to Hi inclusive
+type Range struct {
+	Lo, Hi int
+}
+
+// Len returns how many integers are in the range
+func (r Range) Len() int {
+	return r.Hi - r.Lo + 1
+}
+
+// pick returns a random integer in the range
+func (r Range) pick(rng *rand.Rand) int {
+	return r.Lo + rng.Intn(r.Len())
+}
+
+// random_ints returns between `sizes.Lo` and `sizes.Hi` distinct integers from `values`.
+// If `values` doesn't hold enough integers, all of them are returned. It panics if
+// either range is empty, or `sizes` goes below 0.
+func random_ints(rng *rand.Rand, sizes, values Range) []int {
+	if sizes.Lo < 0 || sizes.Len() < 1 {
+		panic(fmt.Sprintf("settest: invalid size range [%d, %d]", sizes.Lo, sizes.Hi))
+	}
+	if values.Len() < 1 {
+		panic(fmt.Sprintf("settest: invalid value range [%d, %d]", values.Lo, values.Hi))
+	}
+	span := values.Len()
+	n := min(sizes.pick(rng), span)
+
+	// Floyd's algorithm picks `n` distinct offsets into `values` with exactly `n` draws,
+	// however close `n` is to the size of the range
+	seen := make(map[int]struct{}, n)
+	result := make([]int, 0, n)
+	for j := span - n; j < span; j++ {
+		offset := rng.Intn(j + 1)
+		if _, ok := seen[offset]; ok {
+			offset = j
+		}
+		seen[offset] = struct{}{}
+		result = append(result, values.Lo+offset)
+	}
+	return result
+}
+
+// Random returns a set of between `sizes.Lo` and `sizes.Hi` items, drawn without
+// replacement from `values`. The size is chosen uniformly, then capped at the number
+// of values available. Density is set by how the sizes compare to `values.Len()`: a
+// size equal to it fills the range, a much smaller one leaves it sparse. It panics if
+// either range is empty, or `sizes` goes below 0.
+func Random(rng *rand.Rand, sizes, values Range) set.Set[int] {
+	return set.NewSet(random_ints(rng, sizes, values))
+}
+
+// RandomStrings is the same as `Random`, but each item is the decimal string of an
+// integer drawn from `values`
+func RandomStrings(rng *rand.Rand, sizes, values Range) set.Set[string] {
+	ints := random_ints(rng, sizes, values)
+	result := make([]string, len(ints))
+	for i, v := range ints {
+		result[i] = strconv.Itoa(v)
+	}
+	return set.NewSet(result)
+}
+
+// RandomBitset is the same as `Random`, but returns a `bitset.Set`. A dense range gives
+// full words, and a sparse one gives words with only a bit or two set.
+func RandomBitset(rng *rand.Rand, sizes, values Range) bitset.Set {
+	return bitset.NewSet(random_ints(rng, sizes, values))
+}
+
+// RandomDensity returns a bitset in which every integer of `values` is present with
+// probability `density`, from 0 to 1. Unlike `RandomBitset` the size isn't fixed, but
+// the items are spread evenly through the range.
+func RandomDensity(rng *rand.Rand, values Range, density float64) bitset.Set {
+	if values.Len() < 1 {
+		panic(fmt.Sprintf("settest: invalid value range [%d, %d]", values.Lo, values.Hi))
+	}
+	result := bitset.NewSet([]int{})
+	for v := values.Lo; ; v++ {
+		if rng.Float64() < density {
+			result.Add(v)
+		}
+		if v == values.Hi {
+			break
+		}
+	}
+	return result
+}
@@ -0,0 +1,90 @@
+package settest
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func TestRandom(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		sizes  Range
+		values Range
+	}{
+		{desc: "empty", sizes: Range{0, 0}, values: Range{0, 100}},
+		{desc: "sparse", sizes: Range{10, 20}, values: Range{-1_000_000, 1_000_000}},
+		{desc: "dense", sizes: Range{90, 100}, values: Range{0, 99}},
+		{desc: "more than the range holds", sizes: Range{50, 60}, values: Range{-5, 5}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			for i := 0; i < 20; i++ {
+				s := Random(rng, tC.sizes, tC.values)
+				want_lo, want_hi := min(tC.sizes.Lo, tC.values.Len()), min(tC.sizes.Hi, tC.values.Len())
+				if s.Len() < want_lo || s.Len() > want_hi {
+					t.Fatalf("got %d items; want between %d and %d", s.Len(), want_lo, want_hi)
+				}
+				s.Iterate(func(item int) bool {
+					if item < tC.values.Lo || item > tC.values.Hi {
+						t.Errorf("got item %d outside %v", item, tC.values)
+					}
+					return true
+				})
+
+				strs := RandomStrings(rng, tC.sizes, tC.values)
+				strs.Iterate(func(item string) bool {
+					if _, err := strconv.Atoi(item); err != nil {
+						t.Errorf("got item %q; want an integer", item)
+					}
+					return true
+				})
+
+				b := RandomBitset(rng, tC.sizes, tC.values)
+				if b.Len() < want_lo || b.Len() > want_hi {
+					t.Fatalf("got %d items in the bitset; want between %d and %d", b.Len(), want_lo, want_hi)
+				}
+			}
+		})
+	}
+}
+
+func TestRandomRepeatable(t *testing.T) {
+	a := Random(rand.New(rand.NewSource(42)), Range{50, 100}, Range{0, 1000})
+	b := Random(rand.New(rand.NewSource(42)), Range{50, 100}, Range{0, 1000})
+	if !a.Equals(b) {
+		t.Errorf("the same seed should give the same set, got %v and %v", a, b)
+	}
+}
+
+func TestRandomDensity(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	values := Range{-5000, 4999}
+	testCases := []struct {
+		desc    string
+		density float64
+		lo, hi  int
+	}{
+		{desc: "none", density: 0, lo: 0, hi: 0},
+		{desc: "half", density: 0.5, lo: 4500, hi: 5500},
+		{desc: "all", density: 1, lo: 10_000, hi: 10_000},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := RandomDensity(rng, values, tC.density)
+			if s.Len() < tC.lo || s.Len() > tC.hi {
+				t.Errorf("got %d items; want between %d and %d", s.Len(), tC.lo, tC.hi)
+			}
+		})
+	}
+}
+
+func TestRandomPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("an empty value range should panic")
+		}
+	}()
+	Random(rand.New(rand.NewSource(1)), Range{1, 2}, Range{5, 4})
+}
@@ -0,0 +1,77 @@
+package set
+
+// These functions take and return slices, for callers that start and end with slices
+// and only need a set along the way. They keep items in the order they first appear, so
+// the output is the same every time.
+
+// UniqueSlice returns the items of `s` with duplicates removed, keeping the first copy
+// of each
+func UniqueSlice[T comparable, S ~[]T](s S) S {
+	seen := make(map[T]struct{}, len(s))
+	result := make(S, 0, len(s))
+	for _, v := range s {
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// UnionSlices returns every item found in any of `slices`, once each, in the order they
+// first appear
+func UnionSlices[T comparable, S ~[]T](slices ...S) S {
+	total := 0
+	for _, s := range slices {
+		total += len(s)
+	}
+
+	seen := make(map[T]struct{}, total)
+	result := make(S, 0)
+	for _, s := range slices {
+		for _, v := range s {
+			if _, ok := seen[v]; !ok {
+				seen[v] = struct{}{}
+				result = append(result, v)
+			}
+		}
+	}
+	return result
+}
+
+// IntersectSlices returns the items found in every one of `slices`, once each, in the
+// order they appear in the first. With no slices, the result is empty.
+func IntersectSlices[T comparable, S ~[]T](slices ...S) S {
+	if len(slices) == 0 {
+		return S{}
+	}
+
+	common := NewSet(slices[0])
+	for _, s := range slices[1:] {
+		common.RetainSlice(s)
+	}
+	return keep_in_order(slices[0], common)
+}
+
+// DifferenceSlices returns the items of `s` that are in none of `others`, once each, in
+// the order they appear in `s`
+func DifferenceSlices[T comparable, S ~[]T](s S, others ...S) S {
+	kept := NewSet(s)
+	for _, o := range others {
+		for _, v := range o {
+			kept.Discard(v)
+		}
+	}
+	return keep_in_order(s, kept)
+}
+
+// keep_in_order returns the items of `s` that are in `keep`, once each, in order
+func keep_in_order[T comparable, S ~[]T](s S, keep Set[T]) S {
+	result := make(S, 0, keep.Len())
+	for _, v := range s {
+		if keep.TryRemove(v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,45 @@
+package set
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestSliceFunctions(t *testing.T) {
+	a := []int{3, 1, 3, 2, 5, 1}
+	b := []int{5, 4, 1, 4}
+	c := []int{1, 5, 9}
+
+	testCases := []struct {
+		desc string
+		got  []int
+		want []int
+	}{
+		{desc: "unique", got: UniqueSlice(a), want: []int{3, 1, 2, 5}},
+		{desc: "unique empty", got: UniqueSlice([]int{}), want: []int{}},
+		{desc: "union", got: UnionSlices(a, b, c), want: []int{3, 1, 2, 5, 4, 9}},
+		{desc: "union of none", got: UnionSlices[int, []int](), want: []int{}},
+		{desc: "intersect", got: IntersectSlices(a, b, c), want: []int{1, 5}},
+		{desc: "intersect one", got: IntersectSlices(a), want: []int{3, 1, 2, 5}},
+		{desc: "intersect none", got: IntersectSlices[int, []int](), want: []int{}},
+		{desc: "difference", got: DifferenceSlices(a, b), want: []int{3, 2}},
+		{desc: "difference of many", got: DifferenceSlices(a, b, []int{3}), want: []int{2}},
+		{desc: "difference of nothing", got: DifferenceSlices(a), want: []int{3, 1, 2, 5}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if !slices.Equal(tC.got, tC.want) {
+				t.Errorf("got %v; want %v", tC.got, tC.want)
+			}
+		})
+	}
+}
+
+func TestSliceFunctionsKeepType(t *testing.T) {
+	type ids []string
+	got := UniqueSlice(ids{"a", "b", "a"})
+	if want := (ids{"a", "b"}); !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
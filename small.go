@@ -0,0 +1,364 @@
+package set
+
+import (
+	"fmt"
+	"strings"
+)
+
+// small_set_capacity is how many items a SmallSet holds inline before it moves them
+// into a map
+const small_set_capacity = 8
+
+// SmallSet is a set for the very common case of only holding a handful of items. Up to
+// 8 items are kept in a fixed size array and found with a linear scan, which avoids
+// allocating a map at all. Once it grows past that, the items are moved into a map and
+// it behaves just like Set. The zero value is an empty set, ready to use.
+type SmallSet[T comparable] struct {
+	items [small_set_capacity]T
+	n     int
+
+	// large is nil until the set has grown past small_set_capacity
+	large map[T]struct{}
+}
+
+// NewSmallSet will return a SmallSet object from an input slice, or anything that has a
+// slice as the underlying data type
+func NewSmallSet[T comparable, S ~[]T](data S) SmallSet[T] {
+	var result SmallSet[T]
+	for _, v := range data {
+		result.Add(v)
+	}
+	return result
+}
+
+// each calls `f` on every item, stopping early if `f` returns false
+func (s *SmallSet[T]) each(f func(T) bool) {
+	if s.large != nil {
+		for v := range s.large {
+			if !f(v) {
+				return
+			}
+		}
+		return
+	}
+	for _, v := range s.items[:s.n] {
+		if !f(v) {
+			return
+		}
+	}
+}
+
+func (s SmallSet[T]) String() string {
+	var b strings.Builder
+	last_index := s.Len() - 1
+	index := -1
+	b.WriteString("{")
+	s.each(func(v T) bool {
+		index += 1
+
+		if index < last_index {
+			b.WriteString(fmt.Sprintf("%v, ", v))
+		} else {
+			b.WriteString(fmt.Sprintf("%v", v))
+		}
+		return true
+	})
+	b.WriteString("}")
+
+	return b.String()
+}
+
+// IsSmall returns true if the items are still stored inline, without a map
+func (s *SmallSet[T]) IsSmall() bool {
+	return s.large == nil
+}
+
+// ToSet will return a Set holding a copy of the items
+func (s *SmallSet[T]) ToSet() Set[T] {
+	result := make(map[T]struct{}, s.Len())
+	s.each(func(v T) bool {
+		result[v] = struct{}{}
+		return true
+	})
+	return Set[T]{data: result}
+}
+
+// Slice will return all the items in the set as a slice. They are not guaranteed in any
+// particular order.
+func (s *SmallSet[T]) Slice() []T {
+	result := make([]T, 0, s.Len())
+	s.each(func(v T) bool {
+		result = append(result, v)
+		return true
+	})
+	return result
+}
+
+// Iterate calls `f` on every item in the set, in no particular order. It stops early if
+// `f` returns false.
+func (s *SmallSet[T]) Iterate(f func(item T) bool) {
+	s.each(f)
+}
+
+// Contains will return true if the set contains the item. If the set is empty, returns
+// false
+func (s *SmallSet[T]) Contains(item T) bool {
+	if s.large != nil {
+		_, ok := s.large[item]
+		return ok
+	}
+	for _, v := range s.items[:s.n] {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the length of the SmallSet
+func (s *SmallSet[T]) Len() int {
+	if s.large != nil {
+		return len(s.large)
+	}
+	return s.n
+}
+
+// IsEmpty returns true if the set is empty
+func (s *SmallSet[T]) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+// Add will add a new item to `s`. If it already exists, it is ignored
+func (s *SmallSet[T]) Add(item T) {
+	if s.large != nil {
+		s.large[item] = struct{}{}
+		return
+	}
+	if s.Contains(item) {
+		return
+	}
+	if s.n < small_set_capacity {
+		s.items[s.n] = item
+		s.n += 1
+		return
+	}
+
+	// Out of room, so move everything into a map
+	s.large = make(map[T]struct{}, 2*small_set_capacity)
+	for _, v := range s.items[:s.n] {
+		s.large[v] = struct{}{}
+	}
+	s.large[item] = struct{}{}
+	s.items = [small_set_capacity]T{}
+	s.n = 0
+}
+
+// Remove removes an item from the set. Returns a *NotFoundError if the item doesn't
+// exist. See `Discard` for method that does not return an error
+func (s *SmallSet[T]) Remove(item T) error {
+	if !s.Contains(item) {
+		return &NotFoundError[T]{Item: item}
+	}
+
+	s.Discard(item)
+	return nil
+}
+
+// TryRemove removes an item from the set, and returns true if it was there
+func (s *SmallSet[T]) TryRemove(item T) bool {
+	if !s.Contains(item) {
+		return false
+	}
+
+	s.Discard(item)
+	return true
+}
+
+// Discard removes an item from the set. If it doesn't exist, it is ignored
+func (s *SmallSet[T]) Discard(item T) {
+	if s.large != nil {
+		delete(s.large, item)
+		return
+	}
+	for idx, v := range s.items[:s.n] {
+		if v == item {
+			// Order doesn't matter, so fill the hole with the last item
+			s.n -= 1
+			s.items[idx] = s.items[s.n]
+			var zero T
+			s.items[s.n] = zero
+			return
+		}
+	}
+}
+
+// Pop will remove and return an arbitrary item from the set. If the set is empty,
+// it will return an error
+func (s *SmallSet[T]) Pop() (item T, err error) {
+	if s.IsEmpty() {
+		return item, ErrElementNotFound
+	}
+
+	// Get the first item
+	s.each(func(v T) bool {
+		item = v
+		return false
+	})
+
+	// Discard it
+	s.Discard(item)
+
+	return item, nil
+}
+
+// Clear will remove all items from the set. The set goes back to storing its items
+// inline.
+func (s *SmallSet[T]) Clear() {
+	*s = SmallSet[T]{}
+}
+
+// Copy makes a deep copy as quickly as possible
+func (s *SmallSet[T]) Copy() SmallSet[T] {
+	result := *s
+	if s.large != nil {
+		result.large = make(map[T]struct{}, len(s.large))
+		for v := range s.large {
+			result.large[v] = struct{}{}
+		}
+	}
+	return result
+}
+
+// Equals will return true if `s` and `t` are
+// - the same length
+// - contain the same elements
+func (s *SmallSet[T]) Equals(t SmallSet[T]) bool {
+	if s.Len() != t.Len() {
+		return false
+	}
+	return s.IsSubsetOf(t)
+}
+
+// Union will create a new SmallSet, and fill it with the union of `s` and `t`
+func (s *SmallSet[T]) Union(t SmallSet[T]) SmallSet[T] {
+	result := s.Copy()
+	result.UnionInPlace(t)
+	return result
+}
+
+// UnionInPlace will add all the items in set `t` to set `s`
+func (s *SmallSet[T]) UnionInPlace(t SmallSet[T]) {
+	t.each(func(v T) bool {
+		s.Add(v)
+		return true
+	})
+}
+
+// Intersection will create a new SmallSet, and fill it with the intersection of `s`
+// and `t`
+func (s *SmallSet[T]) Intersection(t SmallSet[T]) SmallSet[T] {
+	var result SmallSet[T]
+	s.each(func(v T) bool {
+		if t.Contains(v) {
+			result.Add(v)
+		}
+		return true
+	})
+	return result
+}
+
+// IntersectionInPlace will remove any items from `s` that are not in `t`
+func (s *SmallSet[T]) IntersectionInPlace(t SmallSet[T]) {
+	for _, v := range s.Slice() {
+		if !t.Contains(v) {
+			s.Discard(v)
+		}
+	}
+}
+
+// IsDisjoint will return true if the set has no elements in common with `t`. Sets are
+// disjoint if and only if their intersection is the empty set
+func (s *SmallSet[T]) IsDisjoint(t SmallSet[T]) bool {
+	result := true
+	s.each(func(v T) bool {
+		if t.Contains(v) {
+			result = false
+		}
+		return result
+	})
+	return result
+}
+
+// IsSubsetOf tests whether every element in `s` is in `t`
+func (s *SmallSet[T]) IsSubsetOf(t SmallSet[T]) bool {
+	result := true
+	s.each(func(v T) bool {
+		if !t.Contains(v) {
+			result = false
+		}
+		return result
+	})
+	return result
+}
+
+// IsProperSubsetOf tests whether every element in `s` is in `t`, but that
+// `s.Equals(t) == false`
+func (s *SmallSet[T]) IsProperSubsetOf(t SmallSet[T]) bool {
+	return s.Len() < t.Len() && s.IsSubsetOf(t)
+}
+
+// IsSuperSetOf tests whether every element in `t` is in `s`
+func (s *SmallSet[T]) IsSuperSetOf(t SmallSet[T]) bool {
+	return t.IsSubsetOf(*s)
+}
+
+// IsProperSuperSetOf tests whether every element in `t` is in `s`, but that
+// `s.Equals(t) == false`
+func (s *SmallSet[T]) IsProperSuperSetOf(t SmallSet[T]) bool {
+	return s.Len() > t.Len() && t.IsSubsetOf(*s)
+}
+
+// Difference returns a new set with elements in `s` that are not in `t`
+func (s *SmallSet[T]) Difference(t SmallSet[T]) SmallSet[T] {
+	var result SmallSet[T]
+	s.each(func(v T) bool {
+		if !t.Contains(v) {
+			result.Add(v)
+		}
+		return true
+	})
+	return result
+}
+
+// DifferenceInPlace removes any elements in `s` that are in `t`
+func (s *SmallSet[T]) DifferenceInPlace(t SmallSet[T]) {
+	t.each(func(v T) bool {
+		s.Discard(v)
+		return true
+	})
+}
+
+// SymmetricDifference returns a new set with elements in either `s` or `t`, but not both
+func (s *SmallSet[T]) SymmetricDifference(t SmallSet[T]) SmallSet[T] {
+	result := s.Difference(t)
+	t.each(func(v T) bool {
+		if !s.Contains(v) {
+			result.Add(v)
+		}
+		return true
+	})
+	return result
+}
+
+// SymmetricDifferenceInPlace removes any elements in `s` that are in `t`, and adds any
+// elements in `t` that are not in `s`
+func (s *SmallSet[T]) SymmetricDifferenceInPlace(t SmallSet[T]) {
+	t.each(func(v T) bool {
+		if s.Contains(v) {
+			s.Discard(v)
+		} else {
+			s.Add(v)
+		}
+		return true
+	})
+}
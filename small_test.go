@@ -0,0 +1,159 @@
+package set
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSmallSetAdd(t *testing.T) {
+	var s SmallSet[int]
+	for i := 0; i < small_set_capacity; i++ {
+		s.Add(i)
+		s.Add(i)
+	}
+	if !s.IsSmall() {
+		t.Errorf("set with %d items should still be small", s.Len())
+	}
+	if s.Len() != small_set_capacity {
+		t.Errorf("got length %d, want %d", s.Len(), small_set_capacity)
+	}
+
+	// One more should move it into a map, without losing anything
+	s.Add(100)
+	if s.IsSmall() {
+		t.Errorf("set with %d items should no longer be small", s.Len())
+	}
+	want := NewSet([]int{0, 1, 2, 3, 4, 5, 6, 7, 100})
+	if got := s.ToSet(); !got.Equals(want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestSmallSetRemove(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		s        SmallSet[string]
+		v        string
+		want     SmallSet[string]
+		want_err error
+	}{
+		{
+			desc:     "valid remove",
+			s:        NewSmallSet([]string{"a", "b", "c"}),
+			v:        "a",
+			want:     NewSmallSet([]string{"b", "c"}),
+			want_err: nil,
+		},
+		{
+			desc:     "invalid remove",
+			s:        NewSmallSet([]string{"a", "b", "c"}),
+			v:        "d",
+			want:     NewSmallSet([]string{"a", "b", "c"}),
+			want_err: ErrElementNotFound,
+		},
+		{
+			desc:     "remove from large",
+			s:        NewSmallSet([]string{"a", "b", "c", "d", "e", "f", "g", "h", "i"}),
+			v:        "i",
+			want:     NewSmallSet([]string{"a", "b", "c", "d", "e", "f", "g", "h"}),
+			want_err: nil,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			err := tC.s.Remove(tC.v)
+			if !errors.Is(err, tC.want_err) {
+				t.Errorf("got error %v, want %v", err, tC.want_err)
+			}
+			if !tC.s.Equals(tC.want) {
+				t.Errorf("got %v, want %v", tC.s, tC.want)
+			}
+		})
+	}
+}
+
+func TestSmallSetOperations(t *testing.T) {
+	testCases := []struct {
+		desc string
+		in1  []int
+		in2  []int
+	}{
+		{
+			desc: "both small",
+			in1:  []int{1, 2, 3},
+			in2:  []int{2, 3, 4},
+		},
+		{
+			desc: "one large",
+			in1:  []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			in2:  []int{2, 3, 4},
+		},
+		{
+			desc: "union becomes large",
+			in1:  []int{1, 2, 3, 4, 5},
+			in2:  []int{6, 7, 8, 9, 10},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s1, s2 := NewSmallSet(tC.in1), NewSmallSet(tC.in2)
+			h1, h2 := NewSet(tC.in1), NewSet(tC.in2)
+
+			union := s1.Union(s2)
+			if got, want := union.ToSet(), h1.Union(h2); !got.Equals(want) {
+				t.Errorf("union: got %v; want %v", got, want)
+			}
+			intersection := s1.Intersection(s2)
+			if got, want := intersection.ToSet(), h1.Intersection(h2); !got.Equals(want) {
+				t.Errorf("intersection: got %v; want %v", got, want)
+			}
+			difference := s1.Difference(s2)
+			if got, want := difference.ToSet(), h1.Difference(h2); !got.Equals(want) {
+				t.Errorf("difference: got %v; want %v", got, want)
+			}
+			sym_diff := s1.SymmetricDifference(s2)
+			if got, want := sym_diff.ToSet(), h1.SymmetricDifference(h2); !got.Equals(want) {
+				t.Errorf("symmetric difference: got %v; want %v", got, want)
+			}
+			if s1.IsDisjoint(s2) != h1.IsDisjoint(h2) {
+				t.Errorf("IsDisjoint disagrees for %v and %v", s1, s2)
+			}
+			if s1.IsSubsetOf(s2) != h1.IsSubsetOf(h2) {
+				t.Errorf("IsSubsetOf disagrees for %v and %v", s1, s2)
+			}
+		})
+	}
+}
+
+func TestSmallSetCopy(t *testing.T) {
+	s := NewSmallSet([]int{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	c := s.Copy()
+	c.Discard(1)
+	if !s.Contains(1) {
+		t.Errorf("changing the copy should not change the original")
+	}
+}
+
+func BenchmarkNewSmallSet(b *testing.B) {
+	in := []int{1, 2, 3}
+	for i := 0; i < b.N; i++ {
+		NewSmallSet(in)
+	}
+}
+
+func BenchmarkSmallSetContains(b *testing.B) {
+	s := NewSmallSet([]string{"a", "b", "c"})
+	for i := 0; i < b.N; i++ {
+		s.Contains("c")
+	}
+}
+
+func TestSmallSetTryRemove(t *testing.T) {
+	s := NewSmallSet([]string{"a"})
+	if !s.TryRemove("a") {
+		t.Errorf("removing a present item should return true")
+	}
+	if s.TryRemove("a") {
+		t.Errorf("removing a missing item should return false")
+	}
+}
@@ -0,0 +1,53 @@
+package set
+
+import (
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+)
+
+// NewSetFromSorted will return a Set holding `data`, which should be in ascending order.
+// Duplicates in sorted input sit next to each other, so they are skipped with a plain
+// comparison rather than hashing them again, and the map is sized to the exact number
+// of distinct items. Unsorted input still gives the right set, with a map that may need
+// to grow.
+func NewSetFromSorted[T constraints.Ordered, S ~[]T](data S) Set[T] {
+	distinct := 0
+	for idx, v := range data {
+		if idx == 0 || v != data[idx-1] {
+			distinct++
+		}
+	}
+
+	result := make(map[T]struct{}, distinct)
+	for idx, v := range data {
+		if idx == 0 || v != data[idx-1] {
+			result[v] = struct{}{}
+		}
+	}
+
+	return Set[T]{data: result}
+}
+
+// NewSetPopSmallest will return a Set holding `data`, whose Pop always takes the
+// smallest item. See `SetPopOrder`.
+func NewSetPopSmallest[T constraints.Ordered, S ~[]T](data S) Set[T] {
+	result := NewSet(data)
+	result.SetPopOrder(func(a, b T) bool { return a < b })
+	return result
+}
+
+// SortedSlice will return all the items in the set as a slice, in ascending order
+func SortedSlice[T constraints.Ordered](s Set[T]) []T {
+	result := s.Slice()
+	slices.Sort(result)
+	return result
+}
+
+// SortedString formats the set the same way as `String`, but with the items in
+// ascending order, so the output is the same every time. Use it for logs and golden
+// tests, where the map ordering of `String` makes diffs noisy. The same string limit
+// applies, so only the smallest items are printed, and the rest are summed up as
+// "… and N more".
+func SortedString[T constraints.Ordered](s Set[T]) string {
+	return render_items(s, SortedSlice(s), s.string_limit())
+}
@@ -0,0 +1,99 @@
+package set
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestSortedString(t *testing.T) {
+	testCases := []struct {
+		desc string
+		s    Set[int]
+		want string
+	}{
+		{desc: "empty", s: NewSet([]int{}), want: "{}"},
+		{desc: "one", s: NewSet([]int{4}), want: "{4}"},
+		{desc: "several", s: NewSet([]int{10, -3, 2, 2}), want: "{-3, 2, 10}"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got := SortedString(tC.s); got != tC.want {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+
+	words := NewSet([]string{"pear", "apple", "fig"})
+	if got, want := SortedString(words), "{apple, fig, pear}"; got != want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestSortedStringLimit(t *testing.T) {
+	old_limit := DefaultStringLimit
+	defer func() { DefaultStringLimit = old_limit }()
+	DefaultStringLimit = 2
+
+	s := NewSet([]int{5, 3, 9, 1})
+	if got, want := SortedString(s), "{1, 3, … and 2 more}"; got != want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	// The limit of the set wins over the default
+	s.SetStringLimit(3)
+	if got, want := SortedString(s), "{1, 3, 5, … and 1 more}"; got != want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+	s.SetStringLimit(-1)
+	if got, want := SortedString(s), "{1, 3, 5, 9}"; got != want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestSortedSlice(t *testing.T) {
+	s := NewSet([]float64{2.5, -1, 0})
+	want := []float64{-1, 0, 2.5}
+	if got := SortedSlice(s); !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestNewSetFromSorted(t *testing.T) {
+	testCases := []struct {
+		desc string
+		data []int
+	}{
+		{desc: "empty", data: []int{}},
+		{desc: "one", data: []int{4}},
+		{desc: "sorted with duplicates", data: []int{-3, -3, 0, 1, 1, 1, 7}},
+		{desc: "not sorted", data: []int{5, 1, 5, 3, 1}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			want := NewSet(tC.data)
+			if got := NewSetFromSorted(tC.data); !got.Equals(want) {
+				t.Errorf("got %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkNewSetFromSorted(b *testing.B) {
+	// Every item appears twice in a row
+	data := make([]int, 0, 20_000)
+	for i := 0; i < 10_000; i++ {
+		data = append(data, i, i)
+	}
+
+	b.Run("NewSet", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewSet(data)
+		}
+	})
+	b.Run("NewSetFromSorted", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewSetFromSorted(data)
+		}
+	})
+}
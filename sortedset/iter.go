@@ -0,0 +1,19 @@
+//go:build go1.23
+
+// Range returns a range-over-func iterator, which needs go1.23 or later. Older versions
+// can use IterateRange instead.
+
+package sortedset
+
+import (
+	"iter"
+)
+
+// Range returns an iterator over the items from `lo` up to but not including `hi`, in
+// ascending order, such as every timestamp in a window. It visits the same nodes as
+// `IterateRange`.
+func (s *Set[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.IterateRange(lo, hi, yield)
+	}
+}
@@ -0,0 +1,33 @@
+//go:build go1.23
+
+package sortedset
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestRange(t *testing.T) {
+	s := NewSet([]int{1, 3, 5, 7, 9})
+
+	got := []int{}
+	for v := range s.Range(3, 9) {
+		got = append(got, v)
+	}
+	if want := []int{3, 5, 7}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	// Breaking out of the loop stops the iterator
+	got = got[:0]
+	for v := range s.Range(0, 100) {
+		if v > 4 {
+			break
+		}
+		got = append(got, v)
+	}
+	if want := []int{1, 3}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,432 @@
+// sortedset is a set of ordered items kept in ascending order. It is backed by a treap,
+// a binary search tree balanced by giving each node a random priority, so adding,
+// removing and looking up items are O(log n) on average, and iteration is always in
+// order. Use it over `github.com/natemcintosh/set` when you need the neighbours of an
+// item, or the items in order, rather than only membership. Floats that are NaN can't be
+// ordered, and must not be added.
+package sortedset
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/natemcintosh/set"
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	// This error is returned when you try to remove an item from a set that doesn't
+	// exist. It is set.ErrElementNotFound, so errors.Is works the same for every set.
+	ErrElementNotFound = set.ErrElementNotFound
+	// This error is returned by `Merge` when the items of the two sets are interleaved
+	ErrRangesOverlap = errors.New("ranges of the sets overlap")
+)
+
+type Set[T constraints.Ordered] struct {
+	root *node[T]
+}
+
+// node is one item of the treap. Every node's priority is at least that of its
+// children, and `size` counts the node and everything under it.
+type node[T constraints.Ordered] struct {
+	item        T
+	priority    uint32
+	size        int
+	left, right *node[T]
+}
+
+// size_of returns the number of items under `n`, which may be nil
+func size_of[T constraints.Ordered](n *node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// update recounts the size of `n` after its children have changed
+func (n *node[T]) update() {
+	n.size = 1 + size_of(n.left) + size_of(n.right)
+}
+
+// split_node splits the tree under `n` into the items less than `pivot`, and the items
+// greater than or equal to it
+func split_node[T constraints.Ordered](n *node[T], pivot T) (below, at_or_above *node[T]) {
+	if n == nil {
+		return nil, nil
+	}
+	if n.item < pivot {
+		l, r := split_node(n.right, pivot)
+		n.right = l
+		n.update()
+		return n, r
+	}
+	l, r := split_node(n.left, pivot)
+	n.left = r
+	n.update()
+	return l, n
+}
+
+// join_nodes joins two trees, where every item under `lo` is less than every item under
+// `hi`
+func join_nodes[T constraints.Ordered](lo, hi *node[T]) *node[T] {
+	if lo == nil {
+		return hi
+	}
+	if hi == nil {
+		return lo
+	}
+	if lo.priority >= hi.priority {
+		lo.right = join_nodes(lo.right, hi)
+		lo.update()
+		return lo
+	}
+	hi.left = join_nodes(lo, hi.left)
+	hi.update()
+	return hi
+}
+
+// insert adds `item`, which must not already be in the tree, and returns the new root
+func insert[T constraints.Ordered](n *node[T], item T, priority uint32) *node[T] {
+	if n == nil || priority > n.priority {
+		l, r := split_node(n, item)
+		result := &node[T]{item: item, priority: priority, left: l, right: r}
+		result.update()
+		return result
+	}
+	if item < n.item {
+		n.left = insert(n.left, item, priority)
+	} else {
+		n.right = insert(n.right, item, priority)
+	}
+	n.update()
+	return n
+}
+
+// remove takes `item` out of the tree, and returns the new root and whether it was found
+func remove[T constraints.Ordered](n *node[T], item T) (*node[T], bool) {
+	if n == nil {
+		return nil, false
+	}
+	if item == n.item {
+		return join_nodes(n.left, n.right), true
+	}
+
+	var found bool
+	if item < n.item {
+		n.left, found = remove(n.left, item)
+	} else {
+		n.right, found = remove(n.right, item)
+	}
+	if found {
+		n.update()
+	}
+	return n, found
+}
+
+// in_order calls `f` on every item under `n` in ascending order, and returns false if
+// `f` asked to stop
+func in_order[T constraints.Ordered](n *node[T], f func(item T) bool) bool {
+	for n != nil {
+		if !in_order(n.left, f) || !f(n.item) {
+			return false
+		}
+		n = n.right
+	}
+	return true
+}
+
+// in_range calls `f` on the items under `n` from `lo` up to but not including `hi`, in
+// ascending order, skipping the subtrees that lie outside. It returns false if `f` asked
+// to stop.
+func in_range[T constraints.Ordered](n *node[T], lo, hi T, f func(item T) bool) bool {
+	for n != nil {
+		switch {
+		case n.item < lo:
+			n = n.right
+		case !(n.item < hi):
+			n = n.left
+		default:
+			if !in_range(n.left, lo, hi, f) || !f(n.item) {
+				return false
+			}
+			n = n.right
+		}
+	}
+	return true
+}
+
+// copy_node returns a deep copy of the tree under `n`
+func copy_node[T constraints.Ordered](n *node[T]) *node[T] {
+	if n == nil {
+		return nil
+	}
+	result := *n
+	result.left = copy_node(n.left)
+	result.right = copy_node(n.right)
+	return &result
+}
+
+// NewSet will return a Set holding the items of `data`, which may be in any order
+func NewSet[T constraints.Ordered, S ~[]T](data S) Set[T] {
+	var result Set[T]
+	for _, v := range data {
+		result.Add(v)
+	}
+	return result
+}
+
+// String prints the items in ascending order, such as "{1, 2, 3}"
+func (s Set[T]) String() string {
+	var b strings.Builder
+	b.WriteRune('{')
+	first := true
+	s.Iterate(func(item T) bool {
+		if !first {
+			b.WriteString(", ")
+		}
+		first = false
+		fmt.Fprintf(&b, "%v", item)
+		return true
+	})
+	b.WriteRune('}')
+	return b.String()
+}
+
+// Slice will return all the items in the set as a slice, in ascending order
+func (s *Set[T]) Slice() []T {
+	result := make([]T, 0, s.Len())
+	s.Iterate(func(item T) bool {
+		result = append(result, item)
+		return true
+	})
+	return result
+}
+
+// Iterate calls `f` on every item in the set, in ascending order. It stops early if `f`
+// returns false.
+func (s *Set[T]) Iterate(f func(item T) bool) {
+	in_order(s.root, f)
+}
+
+// IterateRange calls `f` on every item from `lo` up to but not including `hi`, in
+// ascending order. It stops early if `f` returns false. Only the part of the tree
+// holding the range is visited, so a narrow range of a large set is cheap.
+func (s *Set[T]) IterateRange(lo, hi T, f func(item T) bool) {
+	in_range(s.root, lo, hi, f)
+}
+
+// Contains will return true if the set contains the item
+func (s *Set[T]) Contains(item T) bool {
+	n := s.root
+	for n != nil {
+		switch {
+		case item < n.item:
+			n = n.left
+		case n.item < item:
+			n = n.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of items in the set
+func (s *Set[T]) Len() int {
+	return size_of(s.root)
+}
+
+// IsEmpty returns true if the set is empty
+func (s *Set[T]) IsEmpty() bool {
+	return s.root == nil
+}
+
+// Add will add an item to the set. If it is already there, nothing changes.
+func (s *Set[T]) Add(item T) {
+	if !s.Contains(item) {
+		s.root = insert(s.root, item, rand.Uint32())
+	}
+}
+
+// Remove will remove an item from the set. If the item is not in the set, returns a
+// *set.NotFoundError
+func (s *Set[T]) Remove(item T) error {
+	if !s.TryRemove(item) {
+		return &set.NotFoundError[T]{Item: item}
+	}
+	return nil
+}
+
+// TryRemove will remove an item from the set, and return true if it was there
+func (s *Set[T]) TryRemove(item T) bool {
+	var found bool
+	s.root, found = remove(s.root, item)
+	return found
+}
+
+// Discard will remove an item from the set if it is there
+func (s *Set[T]) Discard(item T) {
+	s.TryRemove(item)
+}
+
+// Pop will remove and return the smallest item in the set. If the set is empty, returns
+// ErrElementNotFound, as the other sets do
+func (s *Set[T]) Pop() (item T, err error) {
+	item, ok := s.Min()
+	if !ok {
+		return item, ErrElementNotFound
+	}
+	s.Discard(item)
+	return item, nil
+}
+
+// Clear will remove all items from the set
+func (s *Set[T]) Clear() {
+	s.root = nil
+}
+
+// Copy will return a new set holding the same items as `s`
+func (s *Set[T]) Copy() Set[T] {
+	return Set[T]{root: copy_node(s.root)}
+}
+
+// Equals will return true if `s` and `t` contain the same items
+func (s *Set[T]) Equals(t Set[T]) bool {
+	return s.Len() == t.Len() && slices.Equal(s.Slice(), t.Slice())
+}
+
+// Min returns the smallest item in the set. The bool is false if the set is empty.
+func (s *Set[T]) Min() (item T, ok bool) {
+	n := s.root
+	if n == nil {
+		return item, false
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.item, true
+}
+
+// Max returns the largest item in the set. The bool is false if the set is empty.
+func (s *Set[T]) Max() (item T, ok bool) {
+	n := s.root
+	if n == nil {
+		return item, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.item, true
+}
+
+// At returns the item at index `i` of the set in ascending order, counting from 0, such
+// as the first item of a page or the median. It is O(log n) on average, using the size
+// kept in each node. Like indexing a slice, it panics if `i` is out of range.
+func (s *Set[T]) At(i int) T {
+	if i < 0 || i >= s.Len() {
+		panic(fmt.Sprintf("sortedset: index %d out of range [0, %d)", i, s.Len()))
+	}
+	n := s.root
+	for {
+		left := size_of(n.left)
+		switch {
+		case i < left:
+			n = n.left
+		case i == left:
+			return n.item
+		default:
+			i -= left + 1
+			n = n.right
+		}
+	}
+}
+
+// IndexOf returns the index of `x` in the set in ascending order, so that
+// `s.At(s.IndexOf(x)) == x`. Returns -1 if `x` is not in the set.
+func (s *Set[T]) IndexOf(x T) int {
+	before := 0
+	for n := s.root; n != nil; {
+		switch {
+		case x < n.item:
+			n = n.left
+		case n.item < x:
+			before += size_of(n.left) + 1
+			n = n.right
+		default:
+			return before + size_of(n.left)
+		}
+	}
+	return -1
+}
+
+// Split moves the items of `s` into two new sets: those less than `pivot`, and those
+// greater than or equal to it. The tree is cut along a single path, so it is O(log n) on
+// average, and no items are copied. `s` is left empty.
+func (s *Set[T]) Split(pivot T) (below, at_or_above Set[T]) {
+	lo, hi := split_node(s.root, pivot)
+	s.root = nil
+	return Set[T]{root: lo}, Set[T]{root: hi}
+}
+
+// Merge moves every item of `t` into `s`, when all the items of one set are less than
+// all the items of the other, such as joining back the two halves from `Split`. The
+// trees are joined along a single path, so it is O(log n) on average. `t` is left
+// empty. Returns ErrRangesOverlap, and changes neither set, if the items of the two sets
+// are interleaved.
+func (s *Set[T]) Merge(t *Set[T]) error {
+	s_min, s_ok := s.Min()
+	s_max, _ := s.Max()
+	t_min, t_ok := t.Min()
+	t_max, _ := t.Max()
+
+	switch {
+	case !s_ok || !t_ok:
+		s.root = join_nodes(s.root, t.root)
+	case s_max < t_min:
+		s.root = join_nodes(s.root, t.root)
+	case t_max < s_min:
+		s.root = join_nodes(t.root, s.root)
+	default:
+		return ErrRangesOverlap
+	}
+	t.root = nil
+	return nil
+}
+
+// Floor returns the largest item in the set that is less than or equal to `x`, such as
+// snapping a timestamp back to the last member at or before it. The bool is false if
+// every item is greater than `x`.
+func (s *Set[T]) Floor(x T) (item T, ok bool) {
+	for n := s.root; n != nil; {
+		if x < n.item {
+			n = n.left
+			continue
+		}
+		item, ok = n.item, true
+		if item == x {
+			break
+		}
+		n = n.right
+	}
+	return item, ok
+}
+
+// Ceiling returns the smallest item in the set that is greater than or equal to `x`.
+// The bool is false if every item is less than `x`.
+func (s *Set[T]) Ceiling(x T) (item T, ok bool) {
+	for n := s.root; n != nil; {
+		if n.item < x {
+			n = n.right
+			continue
+		}
+		item, ok = n.item, true
+		if item == x {
+			break
+		}
+		n = n.left
+	}
+	return item, ok
+}
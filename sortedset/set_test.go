@@ -0,0 +1,366 @@
+package sortedset
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/natemcintosh/set"
+	"golang.org/x/exp/slices"
+)
+
+// check_tree checks that the tree under `n` is in order, that every node's priority is
+// at least that of its children, and that the sizes are right. It returns the size.
+func check_tree(t *testing.T, n *node[int], lo, hi *int) int {
+	if n == nil {
+		return 0
+	}
+	if (lo != nil && n.item <= *lo) || (hi != nil && n.item >= *hi) {
+		t.Fatalf("%v is out of order", n.item)
+	}
+	for _, child := range []*node[int]{n.left, n.right} {
+		if child != nil && child.priority > n.priority {
+			t.Fatalf("%v has a higher priority than its parent %v", child.item, n.item)
+		}
+	}
+	size := 1 + check_tree(t, n.left, lo, &n.item) + check_tree(t, n.right, &n.item, hi)
+	if n.size != size {
+		t.Fatalf("%v has size %d; want %d", n.item, n.size, size)
+	}
+	return size
+}
+
+func TestNewSet(t *testing.T) {
+	testCases := []struct {
+		desc string
+		data []int
+		want []int
+	}{
+		{desc: "empty", data: []int{}, want: []int{}},
+		{desc: "one", data: []int{5}, want: []int{5}},
+		{desc: "jumbled with duplicates", data: []int{3, -1, 3, 10, 0, -1}, want: []int{-1, 0, 3, 10}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := NewSet(tC.data)
+			if got := s.Slice(); !slices.Equal(got, tC.want) {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+			if s.Len() != len(tC.want) {
+				t.Errorf("got length %d; want %d", s.Len(), len(tC.want))
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	s := NewSet([]string{"pear", "apple", "fig"})
+	if got, want := s.String(), "{apple, fig, pear}"; got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+	var empty Set[int]
+	if got, want := empty.String(), "{}"; got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
+
+func TestAddRemove(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var s Set[int]
+	want := make(map[int]bool)
+	for i := 0; i < 2000; i++ {
+		v := rng.Intn(500)
+		if rng.Intn(3) == 0 {
+			if got := s.TryRemove(v); got != want[v] {
+				t.Fatalf("TryRemove(%d): got %v; want %v", v, got, want[v])
+			}
+			delete(want, v)
+		} else {
+			s.Add(v)
+			want[v] = true
+		}
+	}
+	check_tree(t, s.root, nil, nil)
+
+	want_items := make([]int, 0, len(want))
+	for v := range want {
+		want_items = append(want_items, v)
+	}
+	slices.Sort(want_items)
+	if got := s.Slice(); !slices.Equal(got, want_items) {
+		t.Errorf("got %v; want %v", got, want_items)
+	}
+	for v := -1; v <= 500; v++ {
+		if s.Contains(v) != want[v] {
+			t.Errorf("Contains(%d): got %v; want %v", v, s.Contains(v), want[v])
+		}
+	}
+}
+
+func TestRemove(t *testing.T) {
+	s := NewSet([]int{1, 2})
+	if err := s.Remove(1); err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+	err := s.Remove(1)
+	if !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrElementNotFound)
+	}
+	var not_found *set.NotFoundError[int]
+	if !errors.As(err, &not_found) || not_found.Item != 1 {
+		t.Errorf("got error %v, want a *set.NotFoundError[int] for 1", err)
+	}
+	s.Discard(2)
+	s.Discard(3)
+	if !s.IsEmpty() {
+		t.Errorf("got %v; want an empty set", s)
+	}
+}
+
+func TestPop(t *testing.T) {
+	s := NewSet([]int{5, -2, 7})
+	for _, want := range []int{-2, 5, 7} {
+		got, err := s.Pop()
+		if err != nil || got != want {
+			t.Errorf("got %v, %v; want %v, nil", got, err, want)
+		}
+	}
+	if _, err := s.Pop(); !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("got error %v, want %v", err, ErrElementNotFound)
+	}
+}
+
+func TestCopy(t *testing.T) {
+	s := NewSet([]int{1, 2, 3})
+	c := s.Copy()
+	c.Add(4)
+	c.Discard(1)
+	if want := NewSet([]int{1, 2, 3}); !s.Equals(want) {
+		t.Errorf("changing the copy changed the original, got %v", s)
+	}
+	if want := NewSet([]int{2, 3, 4}); !c.Equals(want) {
+		t.Errorf("got %v; want %v", c, want)
+	}
+	s.Clear()
+	if !s.IsEmpty() || c.Len() != 3 {
+		t.Errorf("Clear should only empty the original")
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	s := NewSet([]float64{2.5, -1, 8})
+	if got, ok := s.Min(); !ok || got != -1 {
+		t.Errorf("Min: got %v, %v; want -1, true", got, ok)
+	}
+	if got, ok := s.Max(); !ok || got != 8 {
+		t.Errorf("Max: got %v, %v; want 8, true", got, ok)
+	}
+
+	var empty Set[float64]
+	if _, ok := empty.Min(); ok {
+		t.Errorf("Min of an empty set should not be ok")
+	}
+	if _, ok := empty.Max(); ok {
+		t.Errorf("Max of an empty set should not be ok")
+	}
+}
+
+func TestFloorCeiling(t *testing.T) {
+	s := NewSet([]int{10, 20, 30, 40})
+	testCases := []struct {
+		desc         string
+		x            int
+		want_floor   int
+		ok_floor     bool
+		want_ceiling int
+		ok_ceiling   bool
+	}{
+		{desc: "below everything", x: 5, ok_floor: false, want_ceiling: 10, ok_ceiling: true},
+		{desc: "member", x: 20, want_floor: 20, ok_floor: true, want_ceiling: 20, ok_ceiling: true},
+		{desc: "between", x: 25, want_floor: 20, ok_floor: true, want_ceiling: 30, ok_ceiling: true},
+		{desc: "smallest", x: 10, want_floor: 10, ok_floor: true, want_ceiling: 10, ok_ceiling: true},
+		{desc: "above everything", x: 41, want_floor: 40, ok_floor: true, ok_ceiling: false},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			if got, ok := s.Floor(tC.x); ok != tC.ok_floor || (ok && got != tC.want_floor) {
+				t.Errorf("Floor: got %v, %v; want %v, %v", got, ok, tC.want_floor, tC.ok_floor)
+			}
+			if got, ok := s.Ceiling(tC.x); ok != tC.ok_ceiling || (ok && got != tC.want_ceiling) {
+				t.Errorf("Ceiling: got %v, %v; want %v, %v", got, ok, tC.want_ceiling, tC.ok_ceiling)
+			}
+		})
+	}
+
+	var empty Set[int]
+	if _, ok := empty.Floor(1); ok {
+		t.Errorf("Floor of an empty set should not be ok")
+	}
+	if _, ok := empty.Ceiling(1); ok {
+		t.Errorf("Ceiling of an empty set should not be ok")
+	}
+}
+
+func TestIterateRange(t *testing.T) {
+	data := make([]int, 0, 100)
+	for i := 0; i < 100; i++ {
+		data = append(data, 2*i)
+	}
+	s := NewSet(data)
+
+	testCases := []struct {
+		desc   string
+		lo, hi int
+		want   []int
+	}{
+		{desc: "empty range", lo: 10, hi: 10, want: []int{}},
+		{desc: "backwards range", lo: 20, hi: 10, want: []int{}},
+		{desc: "lo is a member, hi is excluded", lo: 10, hi: 16, want: []int{10, 12, 14}},
+		{desc: "between members", lo: 11, hi: 17, want: []int{12, 14, 16}},
+		{desc: "past the end", lo: 195, hi: 1000, want: []int{196, 198}},
+		{desc: "before the start", lo: -10, hi: 3, want: []int{0, 2}},
+		{desc: "nothing in range", lo: 1000, hi: 2000, want: []int{}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got := []int{}
+			s.IterateRange(tC.lo, tC.hi, func(item int) bool {
+				got = append(got, item)
+				return true
+			})
+			if !slices.Equal(got, tC.want) {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+
+	calls := 0
+	s.IterateRange(0, 100, func(int) bool {
+		calls++
+		return calls < 3
+	})
+	if calls != 3 {
+		t.Errorf("IterateRange kept going after f returned false, called %d times", calls)
+	}
+}
+
+func TestAtIndexOf(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	var s Set[int]
+	for i := 0; i < 500; i++ {
+		s.Add(rng.Intn(2000) - 1000)
+	}
+	want := s.Slice()
+
+	for i, v := range want {
+		if got := s.At(i); got != v {
+			t.Errorf("At(%d): got %v; want %v", i, got, v)
+		}
+		if got := s.IndexOf(v); got != i {
+			t.Errorf("IndexOf(%d): got %v; want %v", v, got, i)
+		}
+	}
+	for _, v := range []int{-2000, 2000} {
+		if got := s.IndexOf(v); got != -1 {
+			t.Errorf("IndexOf(%d): got %v; want -1", v, got)
+		}
+	}
+}
+
+func TestAtPanics(t *testing.T) {
+	s := NewSet([]int{1, 2, 3})
+	for _, i := range []int{-1, 3} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("At(%d) should panic", i)
+				}
+			}()
+			s.At(i)
+		}()
+	}
+}
+
+func TestSplit(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		pivot      int
+		want_below []int
+		want_above []int
+	}{
+		{desc: "member", pivot: 30, want_below: []int{10, 20}, want_above: []int{30, 40, 50}},
+		{desc: "between members", pivot: 25, want_below: []int{10, 20}, want_above: []int{30, 40, 50}},
+		{desc: "below everything", pivot: 0, want_below: []int{}, want_above: []int{10, 20, 30, 40, 50}},
+		{desc: "above everything", pivot: 100, want_below: []int{10, 20, 30, 40, 50}, want_above: []int{}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := NewSet([]int{10, 20, 30, 40, 50})
+			below, above := s.Split(tC.pivot)
+			if got := below.Slice(); !slices.Equal(got, tC.want_below) {
+				t.Errorf("below: got %v; want %v", got, tC.want_below)
+			}
+			if got := above.Slice(); !slices.Equal(got, tC.want_above) {
+				t.Errorf("at or above: got %v; want %v", got, tC.want_above)
+			}
+			if !s.IsEmpty() {
+				t.Errorf("Split should leave the set empty, got %v", s)
+			}
+			check_tree(t, below.root, nil, nil)
+			check_tree(t, above.root, nil, nil)
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		s, t     []int
+		want     []int
+		want_err error
+	}{
+		{desc: "t after s", s: []int{1, 2, 3}, t: []int{4, 5}, want: []int{1, 2, 3, 4, 5}},
+		{desc: "t before s", s: []int{4, 5}, t: []int{1, 2, 3}, want: []int{1, 2, 3, 4, 5}},
+		{desc: "s empty", s: []int{}, t: []int{1, 2}, want: []int{1, 2}},
+		{desc: "t empty", s: []int{1, 2}, t: []int{}, want: []int{1, 2}},
+		{desc: "interleaved", s: []int{1, 3}, t: []int{2, 4}, want: []int{1, 3}, want_err: ErrRangesOverlap},
+		{desc: "shared item", s: []int{1, 2}, t: []int{2, 3}, want: []int{1, 2}, want_err: ErrRangesOverlap},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s, other := NewSet(tC.s), NewSet(tC.t)
+			err := s.Merge(&other)
+			if !errors.Is(err, tC.want_err) {
+				t.Fatalf("got error %v, want %v", err, tC.want_err)
+			}
+			if got := s.Slice(); !slices.Equal(got, tC.want) {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+			if err == nil && !other.IsEmpty() {
+				t.Errorf("Merge should leave the other set empty, got %v", other)
+			}
+			if err != nil && other.Len() != len(tC.t) {
+				t.Errorf("a failed Merge should leave the other set alone, got %v", other)
+			}
+			check_tree(t, s.root, nil, nil)
+		})
+	}
+}
+
+func TestSplitMergeRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	var s Set[int]
+	for i := 0; i < 1000; i++ {
+		s.Add(rng.Intn(10_000))
+	}
+	want := s.Slice()
+
+	below, above := s.Split(5000)
+	if err := below.Merge(&above); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if got := below.Slice(); !slices.Equal(got, want) {
+		t.Errorf("splitting and merging back should give the same set")
+	}
+	check_tree(t, below.root, nil, nil)
+}
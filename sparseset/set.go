@@ -0,0 +1,209 @@
+// sparseset is a set of small non-negative ints, such as entity IDs, built from a dense
+// array of the items and a sparse array mapping each item to its place in the dense
+// one. Adding, removing and looking up items are O(1), clearing is O(1), and iterating
+// walks the packed dense array, so it costs O(n) in the number of items rather than in
+// the largest item, and stays cache friendly. Use it over `github.com/natemcintosh/set`
+// or `github.com/natemcintosh/set/bitset` when the items are IDs handed out from zero
+// and the set is walked far more often than it changes, as in an entity component
+// system. The sparse array grows to the largest item ever added, so it is a poor fit for
+// a few large numbers.
+package sparseset
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/natemcintosh/set"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	// This error is returned when you try to remove an item from a set that doesn't
+	// exist. It is set.ErrElementNotFound, so errors.Is works the same for every set.
+	ErrElementNotFound = set.ErrElementNotFound
+)
+
+type Set struct {
+	// dense holds the items, packed, in no particular order
+	dense []int
+	// sparse maps an item to its index in `dense`. Entries for items not in the set are
+	// left stale, so an entry only counts if `dense` points back at the item.
+	sparse []int
+}
+
+// NewSet will return a Set holding the items of `data`. It panics if any item is
+// negative.
+func NewSet[S ~[]int](data S) Set {
+	return NewSetWithCapacity(data, 0)
+}
+
+// NewSetWithCapacity will return a Set holding the items of `data`, with room for every
+// item below `universe` before the sparse array has to grow. It panics if any item is
+// negative.
+func NewSetWithCapacity[S ~[]int](data S, universe int) Set {
+	result := Set{sparse: make([]int, max(universe, 0))}
+	for _, v := range data {
+		result.Add(v)
+	}
+	return result
+}
+
+// String prints the items in ascending order, such as "{1, 2, 3}"
+func (s Set) String() string {
+	items := s.Slice()
+	slices.Sort(items)
+	var b strings.Builder
+	b.WriteRune('{')
+	for i, v := range items {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%d", v)
+	}
+	b.WriteRune('}')
+	return b.String()
+}
+
+// Slice will return a copy of the items in the set, in the order they sit in the dense
+// array
+func (s *Set) Slice() []int {
+	return slices.Clone(s.dense)
+}
+
+// Dense returns the dense array itself, without copying it. It is only valid until the
+// set next changes, and must not be modified. Walking it directly is the fastest way to
+// visit every item.
+func (s *Set) Dense() []int {
+	return s.dense
+}
+
+// IndexOf returns the position of `item` in the dense array, and true, or false if the
+// item is not in the set. Removing an item moves the last item into its place, so data
+// kept in a slice alongside the set can be kept in step by making the same move.
+func (s *Set) IndexOf(item int) (int, bool) {
+	if item < 0 || item >= len(s.sparse) {
+		return 0, false
+	}
+	i := s.sparse[item]
+	if i < len(s.dense) && s.dense[i] == item {
+		return i, true
+	}
+	return 0, false
+}
+
+// Iterate calls `f` on every item in the set, walking the dense array from the end. It
+// stops early if `f` returns false. Because removing an item only moves an item that
+// has already been visited, `f` may remove the item it was called with, or any item
+// already visited, and every other item will still be visited exactly once. Items
+// added by `f` are not visited.
+func (s *Set) Iterate(f func(item int) bool) {
+	for i := len(s.dense) - 1; i >= 0; i-- {
+		// Removals during an earlier call may have shrunk the array past `i`
+		if i >= len(s.dense) {
+			continue
+		}
+		if !f(s.dense[i]) {
+			return
+		}
+	}
+}
+
+// Contains will return true if the set contains the item. Negative items are never in
+// the set.
+func (s *Set) Contains(item int) bool {
+	_, ok := s.IndexOf(item)
+	return ok
+}
+
+// Len returns the number of items in the set
+func (s *Set) Len() int {
+	return len(s.dense)
+}
+
+// IsEmpty returns true if the set is empty
+func (s *Set) IsEmpty() bool {
+	return len(s.dense) == 0
+}
+
+// Add will add an item to the end of the dense array. If it is already there, nothing
+// changes. It panics if `item` is negative, as with indexing a slice.
+func (s *Set) Add(item int) {
+	if item < 0 {
+		panic(fmt.Sprintf("sparseset: cannot add negative item %d", item))
+	}
+	if s.Contains(item) {
+		return
+	}
+	if item >= len(s.sparse) {
+		// Grow at least twice over, so a run of increasing IDs is amortized O(1)
+		grown := make([]int, max(item+1, 2*len(s.sparse)))
+		copy(grown, s.sparse)
+		s.sparse = grown
+	}
+	s.sparse[item] = len(s.dense)
+	s.dense = append(s.dense, item)
+}
+
+// Remove will remove an item from the set. If the item is not in the set, returns a
+// *set.NotFoundError
+func (s *Set) Remove(item int) error {
+	if !s.TryRemove(item) {
+		return &set.NotFoundError[int]{Item: item}
+	}
+	return nil
+}
+
+// TryRemove will remove an item from the set, and return true if it was there. The last
+// item of the dense array is moved into the removed item's place.
+func (s *Set) TryRemove(item int) bool {
+	i, ok := s.IndexOf(item)
+	if !ok {
+		return false
+	}
+	last := s.dense[len(s.dense)-1]
+	s.dense[i] = last
+	s.sparse[last] = i
+	s.dense = s.dense[:len(s.dense)-1]
+	return true
+}
+
+// Discard will remove an item from the set if it is there
+func (s *Set) Discard(item int) {
+	s.TryRemove(item)
+}
+
+// Pop will remove and return the last item of the dense array, which is the most
+// recently added item if nothing has been removed since. If the set is empty, returns
+// ErrElementNotFound
+func (s *Set) Pop() (item int, err error) {
+	if s.IsEmpty() {
+		return item, ErrElementNotFound
+	}
+	item = s.dense[len(s.dense)-1]
+	s.dense = s.dense[:len(s.dense)-1]
+	return item, nil
+}
+
+// Clear will remove all items from the set in O(1). The memory of both arrays is kept
+// for reuse.
+func (s *Set) Clear() {
+	s.dense = s.dense[:0]
+}
+
+// Copy will return a new set holding the same items as `s`, in the same dense order
+func (s *Set) Copy() Set {
+	return Set{dense: slices.Clone(s.dense), sparse: slices.Clone(s.sparse)}
+}
+
+// Equals will return true if `s` and `t` contain the same items, in any order
+func (s *Set) Equals(t Set) bool {
+	if s.Len() != t.Len() {
+		return false
+	}
+	for _, v := range s.dense {
+		if !t.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
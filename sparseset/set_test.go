@@ -0,0 +1,178 @@
+package sparseset
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/natemcintosh/set"
+	"golang.org/x/exp/slices"
+)
+
+func sorted(s Set) []int {
+	result := s.Slice()
+	slices.Sort(result)
+	return result
+}
+
+func TestNewSet(t *testing.T) {
+	testCases := []struct {
+		desc string
+		in   []int
+		want []int
+	}{
+		{desc: "empty", in: []int{}, want: []int{}},
+		{desc: "duplicates", in: []int{3, 1, 3, 0, 1}, want: []int{0, 1, 3}},
+		{desc: "large id", in: []int{100_000, 2}, want: []int{2, 100_000}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := NewSet(tC.in)
+			if got := sorted(s); !slices.Equal(got, tC.want) {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+			if s.Len() != len(tC.want) {
+				t.Errorf("got length %d; want %d", s.Len(), len(tC.want))
+			}
+		})
+	}
+}
+
+func TestAddNegative(t *testing.T) {
+	s := NewSet([]int{})
+	defer func() {
+		if recover() == nil {
+			t.Errorf("adding a negative item should panic")
+		}
+	}()
+	s.Add(-1)
+}
+
+func TestContains(t *testing.T) {
+	s := NewSetWithCapacity([]int{0, 5, 64}, 10)
+	testCases := []struct {
+		item int
+		want bool
+	}{
+		{item: -1, want: false},
+		{item: 0, want: true},
+		{item: 1, want: false},
+		{item: 5, want: true},
+		{item: 64, want: true},
+		{item: 1000, want: false},
+	}
+	for _, tC := range testCases {
+		if got := s.Contains(tC.item); got != tC.want {
+			t.Errorf("Contains(%d): got %v; want %v", tC.item, got, tC.want)
+		}
+	}
+
+	// A stale sparse entry must not count once its item is removed and the slot reused
+	s.Discard(5)
+	s.Add(7)
+	if s.Contains(5) {
+		t.Errorf("5 was removed but is still reported in %v", s)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	s := NewSet([]int{10, 20, 30, 40})
+	if err := s.Remove(20); err != nil {
+		t.Errorf("got error %v; want nil", err)
+	}
+	// The last item moves into the removed item's place
+	if got, want := s.Dense(), []int{10, 40, 30}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+	if i, ok := s.IndexOf(40); !ok || i != 1 {
+		t.Errorf("got index %d, %v; want 1, true", i, ok)
+	}
+	err := s.Remove(20)
+	if !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("got error %v; want %v", err, ErrElementNotFound)
+	}
+	var not_found *set.NotFoundError[int]
+	if !errors.As(err, &not_found) || not_found.Item != 20 {
+		t.Errorf("got error %v; want a *set.NotFoundError[int] for 20", err)
+	}
+	if s.TryRemove(-5) {
+		t.Errorf("TryRemove of a negative item should return false")
+	}
+}
+
+func TestPop(t *testing.T) {
+	s := NewSet([]int{4, 8})
+	for _, want := range []int{8, 4} {
+		got, err := s.Pop()
+		if err != nil || got != want {
+			t.Errorf("got %d, %v; want %d, nil", got, err, want)
+		}
+	}
+	if _, err := s.Pop(); !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("got error %v; want %v", err, ErrElementNotFound)
+	}
+}
+
+func TestIterateWhileRemoving(t *testing.T) {
+	items := []int{}
+	for i := 0; i < 100; i++ {
+		items = append(items, i*3)
+	}
+	s := NewSet(items)
+
+	// Remove every other item as it is visited; each item must still be seen once
+	seen := map[int]int{}
+	s.Iterate(func(item int) bool {
+		seen[item] += 1
+		if item%2 == 0 {
+			s.Discard(item)
+		}
+		return true
+	})
+	for _, v := range items {
+		if seen[v] != 1 {
+			t.Errorf("item %d was visited %d times; want 1", v, seen[v])
+		}
+		if got, want := s.Contains(v), v%2 == 1; got != want {
+			t.Errorf("Contains(%d): got %v; want %v", v, got, want)
+		}
+	}
+
+	// Removing every item as it is visited empties the set
+	s.Iterate(func(item int) bool {
+		s.Discard(item)
+		return true
+	})
+	if !s.IsEmpty() {
+		t.Errorf("set should be empty, got %v", s)
+	}
+}
+
+func TestClearAndCopy(t *testing.T) {
+	s := NewSet([]int{1, 2, 3})
+	c := s.Copy()
+	s.Clear()
+	if !s.IsEmpty() || s.Contains(1) {
+		t.Errorf("set should be empty after Clear, got %v", s)
+	}
+	if want := NewSet([]int{3, 2, 1}); !c.Equals(want) {
+		t.Errorf("got %v; want %v", c, want)
+	}
+	if got, want := c.String(), "{1, 2, 3}"; got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
+
+func BenchmarkIterate(b *testing.B) {
+	s := NewSet([]int{})
+	for i := 0; i < 10_000; i++ {
+		s.Add(i * 7)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		total := 0
+		s.Iterate(func(item int) bool {
+			total += item
+			return true
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package set
+
+import (
+	"io"
+
+	"github.com/natemcintosh/set/internal/binfile"
+)
+
+// The stream format version written by Encoder
+const stream_version = 1
+
+var stream_magic = [4]byte{'G', 'S', 'T', 'M'}
+
+// Each item in a stream is preceded by a tag, so the number of items doesn't need to
+// be known up front
+const (
+	stream_end  = 0
+	stream_item = 1
+)
+
+// Encoder writes items to a stream one at a time, so a set can be written out while it
+// is being built, or spilled to disk in pieces, using a fixed amount of memory. Items
+// are not checked for duplicates, since that would mean remembering them all. The item
+// type must have a boolean, integer, float, or string as its underlying type.
+type Encoder[T comparable] struct {
+	w *binfile.Writer
+}
+
+// NewEncoder will return an Encoder writing to `w`, with the stream header already
+// written. Returns an error if T can't be encoded.
+func NewEncoder[T comparable](w io.Writer) (*Encoder[T], error) {
+	kind, err := item_kind[T]()
+	if err != nil {
+		return nil, err
+	}
+
+	bw := binfile.NewWriter(w, stream_magic, stream_version)
+	bw.Byte(byte(kind))
+	return &Encoder[T]{w: bw}, bw.Err()
+}
+
+// Encode writes a single item
+func (e *Encoder[T]) Encode(item T) error {
+	e.w.Byte(stream_item)
+	write_item(e.w, item)
+	return e.w.Err()
+}
+
+// EncodeSet writes every item of `s`
+func (e *Encoder[T]) EncodeSet(s Set[T]) error {
+	for v := range s.data {
+		if err := e.Encode(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close ends the stream, writes its checksum, and flushes everything to the underlying
+// writer. It does not close the underlying writer.
+func (e *Encoder[T]) Close() error {
+	e.w.Byte(stream_end)
+	return e.w.Close()
+}
+
+// Decoder reads items written by an Encoder, one at a time
+type Decoder[T comparable] struct {
+	r    *binfile.Reader
+	done bool
+}
+
+// NewDecoder will return a Decoder reading from `r`. Returns an error if `r` does not
+// start with a stream header, or the stream holds a different kind of item.
+func NewDecoder[T comparable](r io.Reader) (*Decoder[T], error) {
+	br, err := binfile.NewReader(r, stream_magic, stream_version)
+	if err != nil {
+		return nil, err
+	}
+	if err := check_kind[T](br); err != nil {
+		return nil, err
+	}
+	return &Decoder[T]{r: br}, nil
+}
+
+// Decode reads the next item. At the end of the stream, the checksum is checked, and
+// io.EOF is returned if it matches.
+func (d *Decoder[T]) Decode() (item T, err error) {
+	if d.done {
+		return item, io.EOF
+	}
+
+	tag, err := d.r.ReadByte()
+	if err != nil {
+		return item, err
+	}
+	switch tag {
+	case stream_item:
+		return read_item[T](d.r)
+	case stream_end:
+		if err := d.r.Close(); err != nil {
+			return item, err
+		}
+		d.done = true
+		return item, io.EOF
+	default:
+		return item, ErrInvalidFile
+	}
+}
+
+// DecodeInto reads the rest of the stream, adding every item to `s`. Decoding several
+// streams into the same set re-joins a set that was spilled in pieces. Items are added
+// as they are read, so `s` may hold some of the stream if an error is returned.
+func (d *Decoder[T]) DecodeInto(s *Set[T]) error {
+	if s.data == nil {
+		s.data = make(map[T]struct{})
+	}
+	for {
+		item, err := d.Decode()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.Add(item)
+	}
+}
@@ -0,0 +1,117 @@
+package set
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestEncoderDecoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := NewEncoder[string](&buf)
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	for _, v := range []string{"a", "b", "a"} {
+		if err := enc.Encode(v); err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	dec, err := NewDecoder[string](&buf)
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	var got []string
+	for {
+		v, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		got = append(got, v)
+	}
+
+	// Duplicates are written as is
+	if len(got) != 3 || got[0] != "a" || got[1] != "b" || got[2] != "a" {
+		t.Errorf("got %v; want [a b a]", got)
+	}
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Errorf("got error %v, want %v", err, io.EOF)
+	}
+}
+
+func TestDecodeIntoMerges(t *testing.T) {
+	// Spill two halves of a set, and re-join them
+	spills := []Set[int]{NewSet([]int{1, 2, 3}), NewSet([]int{3, 4})}
+	var files []*bytes.Buffer
+	for _, s := range spills {
+		var buf bytes.Buffer
+		enc, err := NewEncoder[int](&buf)
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if err := enc.EncodeSet(s); err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if err := enc.Close(); err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		files = append(files, &buf)
+	}
+
+	var got Set[int]
+	for _, f := range files {
+		dec, err := NewDecoder[int](f)
+		if err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		if err := dec.DecodeInto(&got); err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+	}
+
+	want := NewSet([]int{1, 2, 3, 4})
+	if !got.Equals(want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	var buf bytes.Buffer
+	enc, _ := NewEncoder[int](&buf)
+	enc.Encode(42)
+	enc.Close()
+	good := buf.Bytes()
+
+	// The wrong item type is caught by the header
+	if _, err := NewDecoder[string](bytes.NewReader(good)); err == nil {
+		t.Errorf("decoding int items as strings should fail")
+	}
+
+	corrupt := bytes.Clone(good)
+	corrupt[len(corrupt)-1] ^= 0xff
+	dec, err := NewDecoder[int](bytes.NewReader(corrupt))
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	var s Set[int]
+	if err := dec.DecodeInto(&s); !errors.Is(err, ErrChecksum) {
+		t.Errorf("got error %v, want %v", err, ErrChecksum)
+	}
+
+	// A stream that stops without its end tag is cut short
+	dec, err = NewDecoder[int](bytes.NewReader(good[:len(good)-5]))
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if err := dec.DecodeInto(&s); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("got error %v, want %v", err, ErrInvalidFile)
+	}
+}
@@ -0,0 +1,130 @@
+package set
+
+import (
+	"sync"
+)
+
+// SyncSet is a Set that is safe to use from several goroutines at once. Every method
+// takes a lock, so readers share access and writers have it to themselves. Steps that
+// must happen together, such as removing one item and adding another, can be grouped
+// with `Update` so no reader ever sees only part of them. The zero value is an empty
+// set, ready to use. A SyncSet must not be copied after first use.
+type SyncSet[T comparable] struct {
+	mu sync.RWMutex
+	s  Set[T]
+}
+
+// NewSyncSet will return a SyncSet holding the items of `data`
+func NewSyncSet[T comparable, S ~[]T](data S) *SyncSet[T] {
+	return &SyncSet[T]{s: NewSet(data)}
+}
+
+// init makes the map of the zero value. The write lock must be held.
+func (ss *SyncSet[T]) init() {
+	if ss.s.data == nil {
+		ss.s.data = make(map[T]struct{})
+	}
+}
+
+func (ss *SyncSet[T]) String() string {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.String()
+}
+
+// Update runs `f` with the write lock held, passing it the underlying Set to change as
+// it likes. Other goroutines see the set either as it was before `f`, or as `f` left it,
+// and never in between. `f` must not keep `s` after it returns, or call any method of
+// the SyncSet, which would deadlock.
+func (ss *SyncSet[T]) Update(f func(s *Set[T])) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.init()
+	f(&ss.s)
+}
+
+// Snapshot returns a copy of the items in the set, which can be used without a lock
+func (ss *SyncSet[T]) Snapshot() Set[T] {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.Copy()
+}
+
+// Contains will return true if the set contains the item
+func (ss *SyncSet[T]) Contains(item T) bool {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.Contains(item)
+}
+
+// Add will add an item to the set
+func (ss *SyncSet[T]) Add(item T) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.init()
+	ss.s.Add(item)
+}
+
+// Remove removes an item from the set. Returns a *NotFoundError if the item doesn't
+// exist.
+func (ss *SyncSet[T]) Remove(item T) error {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.s.Remove(item)
+}
+
+// TryRemove removes an item from the set, and returns true if it was there
+func (ss *SyncSet[T]) TryRemove(item T) bool {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.s.TryRemove(item)
+}
+
+// Discard removes an item from the set if it is there
+func (ss *SyncSet[T]) Discard(item T) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.s.Discard(item)
+}
+
+// Pop will remove and return an arbitrary item from the set. If the set is empty, it
+// will return an error
+func (ss *SyncSet[T]) Pop() (T, error) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.s.Pop()
+}
+
+// Clear will remove all items from the set
+func (ss *SyncSet[T]) Clear() {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.s.Clear()
+}
+
+// Len returns the number of items in the set
+func (ss *SyncSet[T]) Len() int {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.Len()
+}
+
+// IsEmpty returns true if the set is empty
+func (ss *SyncSet[T]) IsEmpty() bool {
+	return ss.Len() == 0
+}
+
+// Slice will return all the items in the set as a slice, in no particular order
+func (ss *SyncSet[T]) Slice() []T {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.s.Slice()
+}
+
+// Iterate calls `f` on every item in the set, in no particular order, holding the read
+// lock throughout. It stops early if `f` returns false. `f` must not change the set.
+func (ss *SyncSet[T]) Iterate(f func(item T) bool) {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	ss.s.Iterate(f)
+}
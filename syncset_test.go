@@ -0,0 +1,71 @@
+package set
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSyncSet(t *testing.T) {
+	var ss SyncSet[int]
+	ss.Add(1)
+	ss.Add(2)
+	if !ss.Contains(1) || ss.Len() != 2 {
+		t.Errorf("got %v; want {1, 2}", ss.String())
+	}
+	if err := ss.Remove(1); err != nil {
+		t.Errorf("got error %v, want nil", err)
+	}
+	if ss.TryRemove(1) {
+		t.Errorf("removing 1 twice should return false")
+	}
+
+	snapshot := ss.Snapshot()
+	ss.Add(3)
+	if snapshot.Contains(3) {
+		t.Errorf("the snapshot should not change with the set")
+	}
+
+	ss.Clear()
+	if !ss.IsEmpty() {
+		t.Errorf("got %v; want an empty set", ss.String())
+	}
+}
+
+func TestSyncSetUpdate(t *testing.T) {
+	// The set always holds exactly one of `a` or `b`. Writers swap them inside Update,
+	// and readers check they never see both or neither.
+	const a, b = "a", "b"
+	ss := NewSyncSet([]string{a})
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				ss.Update(func(s *Set[string]) {
+					if s.TryRemove(a) {
+						s.Add(b)
+					} else {
+						s.Discard(b)
+						s.Add(a)
+					}
+				})
+			}
+		}()
+	}
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				snapshot := ss.Snapshot()
+				if snapshot.Len() != 1 {
+					t.Errorf("saw the update half done: %v", snapshot)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
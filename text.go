@@ -0,0 +1,100 @@
+package set
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ReadLines will return a set of the lines read from `r`, such as an allowlist of IDs
+// with one per line. Leading and trailing whitespace is trimmed, and blank lines are
+// skipped.
+func ReadLines(r io.Reader) (Set[string], error) {
+	result := NewSet([]string{})
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		result.Add(line)
+	}
+	if err := scanner.Err(); err != nil {
+		return Set[string]{}, err
+	}
+	return result, nil
+}
+
+// ReadCSV will return a set of every field of every record read from `r`. Records may
+// have different numbers of fields. Leading and trailing whitespace is trimmed, and
+// empty fields are skipped.
+func ReadCSV(r io.Reader) (Set[string], error) {
+	result := NewSet([]string{})
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Set[string]{}, err
+		}
+		for _, field := range record {
+			field = strings.TrimSpace(field)
+			if field != "" {
+				result.Add(field)
+			}
+		}
+	}
+	return result, nil
+}
+
+// ReadInts will return a set of the integers read from `r`. They can be one per line,
+// comma separated, or a mix of both. Blank lines are skipped, and anything after a '#'
+// is treated as a comment. Returns an error naming the line number of the first number
+// that cannot be parsed.
+func ReadInts(r io.Reader) (Set[int], error) {
+	result := NewSet([]int{})
+	scanner := bufio.NewScanner(r)
+	line_number := 0
+	for scanner.Scan() {
+		line_number += 1
+		line := scanner.Text()
+		if idx := strings.IndexByte(line, '#'); idx >= 0 {
+			line = line[:idx]
+		}
+
+		for _, field := range strings.Split(line, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			v, err := strconv.Atoi(field)
+			if err != nil {
+				return Set[int]{}, fmt.Errorf("line %d: %w", line_number, err)
+			}
+			result.Add(v)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Set[int]{}, err
+	}
+	return result, nil
+}
+
+// WriteLines writes each item of the set to `w` on its own line, formatted with %v, in
+// no particular order. The output of a Set[string] or Set[int] can be read back with
+// `ReadLines` or `ReadInts`.
+func (s *Set[T]) WriteLines(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for v := range s.data {
+		if _, err := fmt.Fprintln(bw, v); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
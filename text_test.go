@@ -0,0 +1,89 @@
+package set
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReadLines(t *testing.T) {
+	input := "alice\n  bob  \n\nalice\r\ncarol"
+	got, err := ReadLines(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	want := NewSet([]string{"alice", "bob", "carol"})
+	if !got.Equals(want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	input := "a,b,c\nd\n\"e,f\", ,a\n"
+	got, err := ReadCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	want := NewSet([]string{"a", "b", "c", "d", "e,f"})
+	if !got.Equals(want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestReadInts(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		input   string
+		want    Set[int]
+		wantErr string
+	}{
+		{
+			desc:  "one per line",
+			input: "1\n2\n\n-3\n",
+			want:  NewSet([]int{1, 2, -3}),
+		},
+		{
+			desc:  "comma separated with comments",
+			input: "1, 2,3 # the first few\n# nothing here\n4,\n",
+			want:  NewSet([]int{1, 2, 3, 4}),
+		},
+		{
+			desc:    "bad number",
+			input:   "1\n2\nthree\n",
+			wantErr: "line 3",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			got, err := ReadInts(strings.NewReader(tC.input))
+			if tC.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tC.wantErr) {
+					t.Errorf("got error %v, want one mentioning %q", err, tC.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if !got.Equals(tC.want) {
+				t.Errorf("got %v; want %v", got, tC.want)
+			}
+		})
+	}
+}
+
+func TestWriteLines(t *testing.T) {
+	s := NewSet([]int{10, -2, 7})
+	var buf bytes.Buffer
+	if err := s.WriteLines(&buf); err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	got, err := ReadInts(&buf)
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	if !got.Equals(s) {
+		t.Errorf("got %v; want %v", got, s)
+	}
+}